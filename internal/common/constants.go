@@ -1,22 +1,154 @@
 package common
 
+import "github.com/InstaySystem/is_v1-be/internal/types"
+
 const (
-	ExchangeEmail       = "email.send"
-	QueueNameAuthEmail  = "email.send.auth"
-	RoutingKeyAuthEmail = "email.send.auth"
+	ExchangeEmail             = "email.send"
+	QueueNameAuthEmail        = "email.send.auth"
+	RoutingKeyAuthEmail       = "email.send.auth"
+	QueueNameTranscriptEmail  = "email.send.transcript"
+	RoutingKeyTranscriptEmail = "email.send.transcript"
 
-	ExchangeFile         = "file.action"
-	QueueNameDeleteFile  = "file.action.delete"
-	RoutingKeyDeleteFile = "file.action.delete"
+	ExchangeFile           = "file.action"
+	QueueNameDeleteFile    = "file.action.delete"
+	RoutingKeyDeleteFile   = "file.action.delete"
+	QueueNameProcessImage  = "file.action.process_image"
+	RoutingKeyProcessImage = "file.action.process_image"
 
 	ExchangeNotification          = "notification.send"
 	QueueNameServiceNotification  = "notification.send.service"
 	RoutingKeyServiceNotification = "notification.send.service"
 	QueueNameRequestNotification  = "notification.send.request"
 	RoutingKeyRequestNotification = "notification.send.request"
+	QueueNameChatNotification     = "notification.send.chat"
+	RoutingKeyChatNotification    = "notification.send.chat"
 
 	RoleAdmin            = "admin"
 	RoleAdminDisplayName = "Quản trị viên"
 	RoleStaff            = "staff"
 	RoleStaffDisplayName = "Nhân viên"
+
+	MaxMessageAttachments = 10
+	MaxAttachmentSize     = 10 * 1024 * 1024
+	ThumbnailSize         = 320
+	ThumbnailPrefix       = "thumbnails/"
+
+	MaxServiceImageSize          = 25 * 1024 * 1024
+	ServiceImageMaxDimension     = 2560
+	ServiceImageVariantPrefix    = "variants/"
+	ServiceImageStatusPending    = "pending"
+	ServiceImageStatusReady      = "ready"
+	ServiceImageStatusFailed     = "failed"
+	ServiceImageRequeueBatchSize = 500
+
+	ChatDepartment = "customer-care"
+
+	CannedResponseTimeLayout = "15:04 02/01/2006"
+
+	TranscriptTimeLayout    = "15:04 02/01/2006"
+	TranscriptArchivePrefix = "archives/chats/"
+	TranscriptBatchSize     = 50
+
+	ChatbotTimeLayout = "15:04"
+
+	SLABatchSize = 100
+
+	MaxRequestAttachments = 5
+
+	RequestScheduleBatchSize = 100
+
+	OpeningHourLayout = "15:04"
+
+	MaxAcceptLanguages = 5
+
+	MaxCatalogueFileSize       = 5 * 1024 * 1024
+	MaxCatalogueRows           = 5000
+	MaxCatalogueXMLSize        = 32 * 1024 * 1024
+	CatalogueImageKeySeparator = "|"
+	CatalogueFormulaPrefixes   = "=+-@"
+	CatalogueSheetName         = "catalogue"
+
+	MaxUploadFileSize     = MaxServiceImageSize
+	UploadStatusPending   = "pending"
+	UploadStatusConfirmed = "confirmed"
+	UploadSweepBatchSize  = 500
+
+	ReviewStatusApproved = "approved"
+	ReviewStatusHidden   = "hidden"
+	ReviewStatusFlagged  = "flagged"
+	ReviewEditedReason   = "edited by guest after moderation"
+
+	UploadPurposeServiceImage = "service_image"
+	UploadPurposeChatImage    = "chat_image"
+	UploadPurposeRequestPhoto = "request_photo"
+	UploadPurposeAvatar       = "avatar"
+	MaxAvatarSize             = 5 * 1024 * 1024
 )
+
+// ServiceImageVariantWidths are the responsive widths generated for every
+// service image, each rendered as both JPEG and WebP.
+var ServiceImageVariantWidths = []int{320, 640, 1280}
+
+var CatalogueColumns = []string{
+	"service_type_slug",
+	"service_type_name",
+	"department",
+	"service_slug",
+	"service_name",
+	"price",
+	"is_active",
+	"description",
+	"image_keys",
+}
+
+var AllowedAttachmentTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+	"application/pdf",
+}
+
+var AllowedRequestAttachmentTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+}
+
+var AllowedImageTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+}
+
+var UploadPolicies = map[string]types.UploadPolicy{
+	UploadPurposeServiceImage: {
+		Prefix:       "services/",
+		AllowedTypes: AllowedImageTypes,
+		MaxSize:      MaxServiceImageSize,
+		DailyQuota:   200,
+		ClientTypes:  []string{"staff"},
+		Public:       true,
+	},
+	UploadPurposeChatImage: {
+		Prefix:       "chats/",
+		AllowedTypes: AllowedAttachmentTypes,
+		MaxSize:      MaxAttachmentSize,
+		DailyQuota:   100,
+		ClientTypes:  []string{"staff", "guest"},
+	},
+	UploadPurposeRequestPhoto: {
+		Prefix:       "requests/",
+		AllowedTypes: AllowedRequestAttachmentTypes,
+		MaxSize:      MaxAttachmentSize,
+		DailyQuota:   30,
+		ClientTypes:  []string{"guest"},
+	},
+	UploadPurposeAvatar: {
+		Prefix:       "avatars/",
+		AllowedTypes: AllowedImageTypes,
+		MaxSize:      MaxAvatarSize,
+		DailyQuota:   10,
+		ClientTypes:  []string{"staff"},
+		Public:       true,
+	},
+}
@@ -41,6 +41,8 @@ var (
 
 	ErrDepartmentNotFound = NewAPIError(http.StatusNotFound, "department not found")
 
+	ErrDepartmentHeadNotInDepartment = NewAPIError(http.StatusBadRequest, "department head does not belong to department")
+
 	ErrDepartmentRequired = NewAPIError(http.StatusBadRequest, "departmentid is require")
 
 	ErrServiceTypeAlreadyExists = NewAPIError(http.StatusConflict, "service type already exists")
@@ -55,6 +57,30 @@ var (
 
 	ErrServiceNotFound = NewAPIError(http.StatusNotFound, "service not found")
 
+	ErrServiceUnavailable = NewAPIError(http.StatusConflict, "service is not available at this time")
+
+	ErrInvalidServiceSlot = NewAPIError(http.StatusBadRequest, "invalid service time slot")
+
+	ErrServiceSlotFull = NewAPIError(http.StatusConflict, "service time slot is fully booked")
+
+	ErrInvalidServiceOptions = NewAPIError(http.StatusBadRequest, "invalid service options")
+
+	ErrServiceOutOfStock = NewAPIError(http.StatusConflict, "service is out of stock")
+
+	ErrInvalidStockAdjustment = NewAPIError(http.StatusBadRequest, "stock is not tracked or adjustment exceeds current stock")
+
+	ErrPricingRuleNotFound = NewAPIError(http.StatusNotFound, "pricing rule not found")
+
+	ErrPromoCodeAlreadyExists = NewAPIError(http.StatusConflict, "promo code already exists")
+
+	ErrInvalidPricingRule = NewAPIError(http.StatusBadRequest, "invalid pricing rule")
+
+	ErrInvalidPromoCode = NewAPIError(http.StatusBadRequest, "invalid or expired promo code")
+
+	ErrInvalidSlotSettings = NewAPIError(http.StatusBadRequest, "slot duration and capacity must be set together")
+
+	ErrSlotsReserved = NewAPIError(http.StatusConflict, "upcoming time slots are already booked beyond the new slot settings")
+
 	ErrHasServiceImageNotFound = NewAPIError(http.StatusNotFound, "has service image not found")
 
 	ErrRequestTypeAlreadyExists = NewAPIError(http.StatusConflict, "request type already exists")
@@ -81,6 +107,14 @@ var (
 
 	ErrOrderServiceNotFound = NewAPIError(http.StatusNotFound, "order service not found")
 
+	ErrServiceOrderNotFound = NewAPIError(http.StatusNotFound, "service order not found")
+
+	ErrServiceOrderMixedDepartments = NewAPIError(http.StatusBadRequest, "services in one order must belong to the same department")
+
+	ErrInvalidDeliveryTime = NewAPIError(http.StatusBadRequest, "delivery time must be between now and check-out")
+
+	ErrOrderServiceInServiceOrder = NewAPIError(http.StatusConflict, "order service belongs to a service order")
+
 	ErrChatNotFound = NewAPIError(http.StatusNotFound, "chat not found")
 
 	ErrInvalidStatus = NewAPIError(http.StatusConflict, "invalid status")
@@ -89,7 +123,79 @@ var (
 
 	ErrReviewNotFound = NewAPIError(http.StatusNotFound, "review not found")
 
+	ErrModerationReasonRequired = NewAPIError(http.StatusBadRequest, "reason is require when hiding or flagging a review")
+
+	ErrReviewNotApproved = NewAPIError(http.StatusConflict, "only approved reviews can be featured")
+
+	ErrReviewResponseRequired = NewAPIError(http.StatusBadRequest, "response is require")
+
 	ErrRoomCurrentlyOccupied = NewAPIError(http.StatusConflict, "room currently occupied")
+
+	ErrEmptyMessage = NewAPIError(http.StatusBadRequest, "content or attachments is require")
+
+	ErrTooManyAttachments = NewAPIError(http.StatusBadRequest, "too many attachments")
+
+	ErrAttachmentNotFound = NewAPIError(http.StatusNotFound, "attachment not found")
+
+	ErrUnsupportedAttachmentType = NewAPIError(http.StatusUnsupportedMediaType, "unsupported attachment type")
+
+	ErrAttachmentTooLarge = NewAPIError(http.StatusRequestEntityTooLarge, "attachment too large")
+
+	ErrChatAlreadyAssigned = NewAPIError(http.StatusConflict, "chat already assigned")
+
+	ErrChatAssignedToOther = NewAPIError(http.StatusForbidden, "chat is assigned to another staff")
+
+	ErrTransferTargetRequired = NewAPIError(http.StatusBadRequest, "assignee or department is require")
+
+	ErrRequestScheduleNotFound = NewAPIError(http.StatusNotFound, "request schedule not found")
+
+	ErrInvalidScheduleTime = NewAPIError(http.StatusBadRequest, "scheduled time must be between now and check-out")
+
+	ErrRequestAlreadyAssigned = NewAPIError(http.StatusConflict, "request already assigned")
+
+	ErrRequestAssignedToOther = NewAPIError(http.StatusForbidden, "request is assigned to another staff")
+
+	ErrRequestNoteContentRequired = NewAPIError(http.StatusBadRequest, "content is require")
+
+	ErrOrderServiceAlreadyAssigned = NewAPIError(http.StatusConflict, "order service already assigned")
+
+	ErrOrderServiceAssignedToOther = NewAPIError(http.StatusForbidden, "order service is assigned to another staff")
+
+	ErrAssigneeNotInDepartment = NewAPIError(http.StatusBadRequest, "assignee does not belong to department")
+
+	ErrCannedResponseNotFound = NewAPIError(http.StatusNotFound, "canned response not found")
+
+	ErrCannedResponseAlreadyExists = NewAPIError(http.StatusConflict, "canned response already exists")
+
+	ErrChatArchived = NewAPIError(http.StatusGone, "chat archived")
+
+	ErrFAQNotFound = NewAPIError(http.StatusNotFound, "faq not found")
+
+	ErrInvalidLocale = NewAPIError(http.StatusBadRequest, "invalid locale")
+
+	ErrTranslationNotFound = NewAPIError(http.StatusNotFound, "translation not found")
+
+	ErrUnsupportedCatalogueFormat = NewAPIError(http.StatusUnsupportedMediaType, "catalogue file must be .csv or .xlsx")
+
+	ErrInvalidCatalogueFile = NewAPIError(http.StatusBadRequest, "catalogue file is empty, unreadable or missing required columns")
+
+	ErrFileNotFound = NewAPIError(http.StatusNotFound, "file not found")
+
+	ErrInvalidSignedURL = NewAPIError(http.StatusForbidden, "invalid or expired signed url")
+
+	ErrFileTooLarge = NewAPIError(http.StatusRequestEntityTooLarge, "file too large")
+
+	ErrUnsupportedFileType = NewAPIError(http.StatusUnsupportedMediaType, "unsupported file type")
+
+	ErrUploadQuotaExceeded = NewAPIError(http.StatusTooManyRequests, "upload quota exceeded")
+
+	ErrInvalidUploadKey = NewAPIError(http.StatusBadRequest, "file was not uploaded for this purpose")
+
+	ErrUploadExpired = NewAPIError(http.StatusGone, "uploaded file expired, please upload it again")
+
+	ErrCatalogueFileTooLarge = NewAPIError(http.StatusRequestEntityTooLarge, "catalogue file too large")
+
+	ErrTooManyCatalogueRows = NewAPIError(http.StatusBadRequest, "catalogue file has too many rows")
 )
 
 type APIError struct {
@@ -1,6 +1,9 @@
 package common
 
 import (
+	"strings"
+	"time"
+
 	"github.com/InstaySystem/is_v1-be/internal/model"
 	"github.com/InstaySystem/is_v1-be/internal/types"
 )
@@ -154,6 +157,7 @@ func ToDepartmentResponse(department *model.Department) *types.DepartmentRespons
 		UpdatedAt:   department.UpdatedAt,
 		CreatedBy:   ToBasicUserResponse(department.CreatedBy),
 		UpdatedBy:   ToBasicUserResponse(department.UpdatedBy),
+		Head:        ToBasicUserResponse(department.Head),
 		StaffCount:  department.StaffCount,
 	}
 }
@@ -197,10 +201,134 @@ func ToServiceTypeResponse(serviceType *model.ServiceType) *types.ServiceTypeRes
 		CreatedBy:    ToBasicUserResponse(serviceType.CreatedBy),
 		UpdatedBy:    ToBasicUserResponse(serviceType.UpdatedBy),
 		Department:   ToSimpleDepartmentResponse(serviceType.Department),
+		OpeningHours: ToOpeningHoursResponse(serviceType.OpeningHours),
+		Translations: ToServiceTypeTranslationsResponse(serviceType.Translations),
 		ServiceCount: serviceType.ServiceCount,
 	}
 }
 
+func ToServiceTypeTranslationsResponse(translations []*model.ServiceTypeTranslation) []*types.TranslationResponse {
+	translationsRes := make([]*types.TranslationResponse, 0, len(translations))
+	for _, translation := range translations {
+		translationsRes = append(translationsRes, &types.TranslationResponse{
+			Locale: translation.Locale,
+			Name:   translation.Name,
+		})
+	}
+
+	return translationsRes
+}
+
+func ToServiceTranslationsResponse(translations []*model.ServiceTranslation) []*types.TranslationResponse {
+	translationsRes := make([]*types.TranslationResponse, 0, len(translations))
+	for _, translation := range translations {
+		translationsRes = append(translationsRes, &types.TranslationResponse{
+			Locale:      translation.Locale,
+			Name:        translation.Name,
+			Description: translation.Description,
+		})
+	}
+
+	return translationsRes
+}
+
+func ToRequestTypeTranslationsResponse(translations []*model.RequestTypeTranslation) []*types.TranslationResponse {
+	translationsRes := make([]*types.TranslationResponse, 0, len(translations))
+	for _, translation := range translations {
+		translationsRes = append(translationsRes, &types.TranslationResponse{
+			Locale: translation.Locale,
+			Name:   translation.Name,
+		})
+	}
+
+	return translationsRes
+}
+
+func ToOpeningHoursResponse(hours []*model.ServiceOpeningHour) []*types.OpeningHourResponse {
+	if len(hours) == 0 {
+		return make([]*types.OpeningHourResponse, 0)
+	}
+
+	hoursRes := make([]*types.OpeningHourResponse, 0, len(hours))
+	for _, hour := range hours {
+		hoursRes = append(hoursRes, &types.OpeningHourResponse{
+			DayOfWeek: hour.DayOfWeek,
+			OpenTime:  hour.OpenTime,
+			CloseTime: hour.CloseTime,
+		})
+	}
+
+	return hoursRes
+}
+
+func ToServiceOptionGroupsResponse(groups []*model.ServiceOptionGroup) []*types.ServiceOptionGroupResponse {
+	if len(groups) == 0 {
+		return make([]*types.ServiceOptionGroupResponse, 0)
+	}
+
+	groupsRes := make([]*types.ServiceOptionGroupResponse, 0, len(groups))
+	for _, group := range groups {
+		optionsRes := make([]*types.ServiceOptionResponse, 0, len(group.Options))
+		for _, option := range group.Options {
+			optionsRes = append(optionsRes, &types.ServiceOptionResponse{
+				ID:         option.ID,
+				Name:       option.Name,
+				PriceDelta: option.PriceDelta,
+			})
+		}
+
+		groupsRes = append(groupsRes, &types.ServiceOptionGroupResponse{
+			ID:            group.ID,
+			Name:          group.Name,
+			SelectionType: group.SelectionType,
+			IsRequired:    group.IsRequired,
+			Options:       optionsRes,
+		})
+	}
+
+	return groupsRes
+}
+
+func ToOrderServiceOptionsResponse(options []*model.OrderServiceOption) []*types.OrderServiceOptionResponse {
+	if len(options) == 0 {
+		return make([]*types.OrderServiceOptionResponse, 0)
+	}
+
+	optionsRes := make([]*types.OrderServiceOptionResponse, 0, len(options))
+	for _, option := range options {
+		optionsRes = append(optionsRes, &types.OrderServiceOptionResponse{
+			GroupName:  option.GroupName,
+			OptionName: option.OptionName,
+			PriceDelta: option.PriceDelta,
+		})
+	}
+
+	return optionsRes
+}
+
+func ToServiceSlotsResponse(service *model.Service) []*types.ServiceSlotResponse {
+	if service.SlotDurationMinutes == nil || service.SlotCapacity == nil || len(service.AvailableSlots) == 0 {
+		return make([]*types.ServiceSlotResponse, 0)
+	}
+
+	duration := time.Duration(*service.SlotDurationMinutes) * time.Minute
+	slotsRes := make([]*types.ServiceSlotResponse, 0, len(service.AvailableSlots))
+	for _, slot := range service.AvailableSlots {
+		var available uint32
+		if slot.Reserved < *service.SlotCapacity {
+			available = *service.SlotCapacity - slot.Reserved
+		}
+
+		slotsRes = append(slotsRes, &types.ServiceSlotResponse{
+			StartAt:   slot.StartAt,
+			EndAt:     slot.StartAt.Add(duration),
+			Available: available,
+		})
+	}
+
+	return slotsRes
+}
+
 func ToSimpleDepartmentsResponse(departments []*model.Department) []*types.SimpleDepartmentResponse {
 	if len(departments) == 0 {
 		return make([]*types.SimpleDepartmentResponse, 0)
@@ -245,8 +373,9 @@ func ToSimpleServiceImageResponse(image *model.ServiceImage) *types.SimpleServic
 	}
 
 	return &types.SimpleServiceImageResponse{
-		ID:  image.ID,
-		Key: image.Key,
+		ID:       image.ID,
+		Key:      image.Key,
+		Variants: ToServiceImageVariantsResponse(image.Variants),
 	}
 }
 
@@ -261,6 +390,7 @@ func ToBasicServiceResponse(service *model.Service) *types.BasicServiceResponse
 		Slug:        service.Slug,
 		Price:       service.Price,
 		IsActive:    service.IsActive,
+		IsSoldOut:   isServiceSoldOut(service),
 		ServiceType: ToSimpleServiceTypeResponse(service.ServiceType),
 		Thumbnail:   ToSimpleServiceImageResponse(service.ServiceImages[0]),
 	}
@@ -272,15 +402,24 @@ func ToSimpleServiceResponse(service *model.Service) *types.SimpleServiceRespons
 	}
 
 	return &types.SimpleServiceResponse{
-		ID:            service.ID,
-		Name:          service.Name,
-		Price:         service.Price,
-		Description:   service.Description,
-		ServiceType:   ToSimpleServiceTypeResponse(service.ServiceType),
-		ServiceImages: ToServiceImagesResponse(service.ServiceImages),
+		ID:                  service.ID,
+		Name:                service.Name,
+		Price:               service.Price,
+		Description:         service.Description,
+		ServiceType:         ToSimpleServiceTypeResponse(service.ServiceType),
+		ServiceImages:       ToServiceImagesResponse(service.ServiceImages),
+		OpeningHours:        ToOpeningHoursResponse(service.OpeningHours),
+		OptionGroups:        ToServiceOptionGroupsResponse(service.OptionGroups),
+		SlotDurationMinutes: service.SlotDurationMinutes,
+		Slots:               ToServiceSlotsResponse(service),
+		IsSoldOut:           isServiceSoldOut(service),
 	}
 }
 
+func isServiceSoldOut(service *model.Service) bool {
+	return service.Stock != nil && *service.Stock == 0
+}
+
 func ToBasicServicesResponse(services []*model.Service) []*types.BasicServiceResponse {
 	if len(services) == 0 {
 		return make([]*types.BasicServiceResponse, 0)
@@ -317,7 +456,29 @@ func ToServiceImageResponse(image *model.ServiceImage) *types.ServiceImageRespon
 		Key:         image.Key,
 		IsThumbnail: image.IsThumbnail,
 		SortOrder:   image.SortOrder,
+		Status:      image.Status,
+		Width:       image.Width,
+		Height:      image.Height,
+		Variants:    ToServiceImageVariantsResponse(image.Variants),
+	}
+}
+
+func ToServiceImageVariantsResponse(variants []*model.ServiceImageVariant) []*types.ServiceImageVariantResponse {
+	if len(variants) == 0 {
+		return make([]*types.ServiceImageVariantResponse, 0)
+	}
+
+	variantsRes := make([]*types.ServiceImageVariantResponse, 0, len(variants))
+	for _, variant := range variants {
+		variantsRes = append(variantsRes, &types.ServiceImageVariantResponse{
+			Key:    variant.Key,
+			Format: variant.Format,
+			Width:  variant.Width,
+			Height: variant.Height,
+		})
 	}
+
+	return variantsRes
 }
 
 func ToServiceImagesResponse(images []*model.ServiceImage) []*types.ServiceImageResponse {
@@ -339,17 +500,25 @@ func ToServiceResponse(service *model.Service) *types.ServiceResponse {
 	}
 
 	return &types.ServiceResponse{
-		ID:            service.ID,
-		Name:          service.Name,
-		Price:         service.Price,
-		IsActive:      service.IsActive,
-		Description:   service.Description,
-		CreatedAt:     service.CreatedAt,
-		UpdatedAt:     service.UpdatedAt,
-		ServiceType:   ToSimpleServiceTypeResponse(service.ServiceType),
-		CreatedBy:     ToBasicUserResponse(service.CreatedBy),
-		UpdatedBy:     ToBasicUserResponse(service.UpdatedBy),
-		ServiceImages: ToServiceImagesResponse(service.ServiceImages),
+		ID:                  service.ID,
+		Name:                service.Name,
+		Price:               service.Price,
+		IsActive:            service.IsActive,
+		Description:         service.Description,
+		CreatedAt:           service.CreatedAt,
+		UpdatedAt:           service.UpdatedAt,
+		ServiceType:         ToSimpleServiceTypeResponse(service.ServiceType),
+		CreatedBy:           ToBasicUserResponse(service.CreatedBy),
+		UpdatedBy:           ToBasicUserResponse(service.UpdatedBy),
+		ServiceImages:       ToServiceImagesResponse(service.ServiceImages),
+		OpeningHours:        ToOpeningHoursResponse(service.OpeningHours),
+		OptionGroups:        ToServiceOptionGroupsResponse(service.OptionGroups),
+		SlotDurationMinutes: service.SlotDurationMinutes,
+		SlotCapacity:        service.SlotCapacity,
+		Stock:               service.Stock,
+		LowStockThreshold:   service.LowStockThreshold,
+		IsSoldOut:           isServiceSoldOut(service),
+		Translations:        ToServiceTranslationsResponse(service.Translations),
 	}
 }
 
@@ -372,13 +541,16 @@ func ToRequestTypeResponse(requestType *model.RequestType) *types.RequestTypeRes
 	}
 
 	return &types.RequestTypeResponse{
-		ID:         requestType.ID,
-		Name:       requestType.Name,
-		CreatedAt:  requestType.CreatedAt,
-		UpdatedAt:  requestType.UpdatedAt,
-		CreatedBy:  ToBasicUserResponse(requestType.CreatedBy),
-		UpdatedBy:  ToBasicUserResponse(requestType.UpdatedBy),
-		Department: ToSimpleDepartmentResponse(requestType.Department),
+		ID:                 requestType.ID,
+		Name:               requestType.Name,
+		AcceptSLAMinutes:   requestType.AcceptSLAMinutes,
+		CompleteSLAMinutes: requestType.CompleteSLAMinutes,
+		CreatedAt:          requestType.CreatedAt,
+		UpdatedAt:          requestType.UpdatedAt,
+		CreatedBy:          ToBasicUserResponse(requestType.CreatedBy),
+		UpdatedBy:          ToBasicUserResponse(requestType.UpdatedBy),
+		Department:         ToSimpleDepartmentResponse(requestType.Department),
+		Translations:       ToRequestTypeTranslationsResponse(requestType.Translations),
 	}
 }
 
@@ -601,15 +773,21 @@ func ToSimpleOrderServiceResponse(orderService *model.OrderService) *types.Simpl
 	}
 
 	return &types.SimpleOrderServiceResponse{
-		ID:           orderService.ID,
-		Service:      ToBasicServiceResponse(orderService.Service),
-		Quantity:     orderService.Quantity,
-		TotalPrice:   orderService.TotalPrice,
-		Status:       orderService.Status,
-		GuestNote:    orderService.GuestNote,
-		StaffNote:    orderService.StaffNote,
-		CancelReason: orderService.CancelReason,
-		CreatedAt:    orderService.CreatedAt,
+		ID:             orderService.ID,
+		Service:        ToBasicServiceResponse(orderService.Service),
+		Quantity:       orderService.Quantity,
+		ListPrice:      orderService.ListPrice,
+		TotalPrice:     orderService.TotalPrice,
+		PricingRule:    orderService.PricingRuleName,
+		PromoCode:      orderService.PromoCode,
+		Status:         orderService.Status,
+		SlotStartAt:    orderService.SlotStartAt,
+		ServiceOrderID: orderService.ServiceOrderID,
+		Options:        ToOrderServiceOptionsResponse(orderService.Options),
+		GuestNote:      orderService.GuestNote,
+		StaffNote:      orderService.StaffNote,
+		CancelReason:   orderService.CancelReason,
+		CreatedAt:      orderService.CreatedAt,
 	}
 }
 
@@ -623,8 +801,10 @@ func ToBasicOrderServiceResponse(orderService *model.OrderService) *types.BasicO
 		Service:    orderService.Service.Name,
 		Room:       orderService.OrderRoom.Room.Name,
 		Quantity:   orderService.Quantity,
+		ListPrice:  orderService.ListPrice,
 		TotalPrice: orderService.TotalPrice,
 		Status:     orderService.Status,
+		Assignee:   ToBasicUserResponse(orderService.Assignee),
 		CreatedAt:  orderService.CreatedAt,
 	}
 }
@@ -642,6 +822,70 @@ func ToBasicOrderServicesResponse(orderServices []*model.OrderService) []*types.
 	return orderServicesRes
 }
 
+func ToBasicServiceOrderResponse(serviceOrder *model.ServiceOrder) *types.BasicServiceOrderResponse {
+	if serviceOrder == nil {
+		return nil
+	}
+
+	return &types.BasicServiceOrderResponse{
+		ID:         serviceOrder.ID,
+		Room:       serviceOrder.OrderRoom.Room.Name,
+		LineCount:  len(serviceOrder.Lines),
+		TotalPrice: serviceOrder.TotalPrice,
+		Status:     serviceOrder.Status,
+		DeliveryAt: serviceOrder.DeliveryAt,
+		CreatedAt:  serviceOrder.CreatedAt,
+	}
+}
+
+func ToBasicServiceOrdersResponse(serviceOrders []*model.ServiceOrder) []*types.BasicServiceOrderResponse {
+	if len(serviceOrders) == 0 {
+		return make([]*types.BasicServiceOrderResponse, 0)
+	}
+
+	serviceOrdersRes := make([]*types.BasicServiceOrderResponse, 0, len(serviceOrders))
+	for _, serviceOrder := range serviceOrders {
+		serviceOrdersRes = append(serviceOrdersRes, ToBasicServiceOrderResponse(serviceOrder))
+	}
+
+	return serviceOrdersRes
+}
+
+func ToServiceOrderResponse(serviceOrder *model.ServiceOrder) *types.ServiceOrderResponse {
+	if serviceOrder == nil {
+		return nil
+	}
+
+	return &types.ServiceOrderResponse{
+		ID:           serviceOrder.ID,
+		OrderRoom:    ToBasicOrderRoomResponse(serviceOrder.OrderRoom),
+		TotalPrice:   serviceOrder.TotalPrice,
+		Status:       serviceOrder.Status,
+		GuestNote:    serviceOrder.GuestNote,
+		DeliveryAt:   serviceOrder.DeliveryAt,
+		StaffNote:    serviceOrder.StaffNote,
+		CancelReason: serviceOrder.CancelReason,
+		RejectReason: serviceOrder.RejectReason,
+		UpdatedBy:    ToBasicUserResponse(serviceOrder.UpdatedBy),
+		CreatedAt:    serviceOrder.CreatedAt,
+		UpdatedAt:    serviceOrder.UpdatedAt,
+		Lines:        ToSimpleOrderServicesResponse(serviceOrder.Lines),
+	}
+}
+
+func ToServiceOrdersResponse(serviceOrders []*model.ServiceOrder) []*types.ServiceOrderResponse {
+	if len(serviceOrders) == 0 {
+		return make([]*types.ServiceOrderResponse, 0)
+	}
+
+	serviceOrdersRes := make([]*types.ServiceOrderResponse, 0, len(serviceOrders))
+	for _, serviceOrder := range serviceOrders {
+		serviceOrdersRes = append(serviceOrdersRes, ToServiceOrderResponse(serviceOrder))
+	}
+
+	return serviceOrdersRes
+}
+
 func ToBasicOrderRoomResponse(orderRoom *model.OrderRoom) *types.BasicOrderRoomResponse {
 	if orderRoom == nil {
 		return nil
@@ -672,18 +916,26 @@ func ToOrderServiceResponse(orderService *model.OrderService) *types.OrderServic
 	}
 
 	return &types.OrderServiceResponse{
-		ID:           orderService.ID,
-		Service:      ToBasicServiceResponse(orderService.Service),
-		OrderRoom:    ToBasicOrderRoomResponse(orderService.OrderRoom),
-		Quantity:     orderService.Quantity,
-		TotalPrice:   orderService.TotalPrice,
-		Status:       orderService.Status,
-		CreatedAt:    orderService.CreatedAt,
-		UpdatedAt:    orderService.UpdatedAt,
-		GuestNote:    orderService.GuestNote,
-		StaffNote:    orderService.StaffNote,
-		CancelReason: orderService.CancelReason,
-		UpdatedBy:    ToBasicUserResponse(orderService.UpdatedBy),
+		ID:             orderService.ID,
+		Service:        ToBasicServiceResponse(orderService.Service),
+		OrderRoom:      ToBasicOrderRoomResponse(orderService.OrderRoom),
+		Quantity:       orderService.Quantity,
+		ListPrice:      orderService.ListPrice,
+		TotalPrice:     orderService.TotalPrice,
+		PricingRule:    orderService.PricingRuleName,
+		PromoCode:      orderService.PromoCode,
+		Status:         orderService.Status,
+		SlotStartAt:    orderService.SlotStartAt,
+		ServiceOrderID: orderService.ServiceOrderID,
+		Options:        ToOrderServiceOptionsResponse(orderService.Options),
+		CreatedAt:      orderService.CreatedAt,
+		UpdatedAt:      orderService.UpdatedAt,
+		GuestNote:      orderService.GuestNote,
+		StaffNote:      orderService.StaffNote,
+		CancelReason:   orderService.CancelReason,
+		UpdatedBy:      ToBasicUserResponse(orderService.UpdatedBy),
+		Assignee:       ToBasicUserResponse(orderService.Assignee),
+		AssignedAt:     orderService.AssignedAt,
 	}
 }
 
@@ -792,11 +1044,157 @@ func ToSimpleChatWithMessagesResponse(chat *model.Chat) *types.SimpleChatWithMes
 	}
 
 	return &types.SimpleChatWithMessageResponse{
-		ID:        chat.ID,
-		OrderRoom: ToSimpleOrderRoomResponse(chat.OrderRoom),
-		ExpiredAt: chat.ExpiredAt,
-		Messages:  ToSimpleMessagesResponse(chat.Messages),
+		ID:          chat.ID,
+		OrderRoom:   ToSimpleOrderRoomResponse(chat.OrderRoom),
+		ExpiredAt:   chat.ExpiredAt,
+		Assignee:    ToBasicUserResponse(chat.Assignee),
+		AssignedAt:  chat.AssignedAt,
+		Department:  ToSimpleDepartmentResponse(chat.Department),
+		EscalatedAt: chat.EscalatedAt,
+		Messages:    ToSimpleMessagesResponse(chat.Messages),
+		Transfers:   ToChatTransfersResponse(chat.Transfers),
+	}
+}
+
+func ToChatTranscriptResponse(chat *model.Chat, exportedAt time.Time) *types.ChatTranscriptResponse {
+	if chat == nil {
+		return nil
+	}
+
+	transcript := &types.ChatTranscriptResponse{
+		ChatID:     chat.ID,
+		ExportedAt: exportedAt,
+		Messages:   make([]*types.TranscriptMessageResponse, 0, len(chat.Messages)),
+	}
+	if chat.OrderRoom != nil {
+		if chat.OrderRoom.Room != nil {
+			transcript.RoomName = chat.OrderRoom.Room.Name
+		}
+		if booking := chat.OrderRoom.Booking; booking != nil {
+			transcript.BookingNumber = booking.BookingNumber
+			transcript.GuestFullName = booking.GuestFullName
+			transcript.CheckIn = &booking.CheckIn
+			transcript.CheckOut = &booking.CheckOut
+		}
+	}
+
+	for _, message := range chat.Messages {
+		senderName := transcript.GuestFullName
+		if message.SenderType != "guest" {
+			senderName = "Instay"
+			if message.Sender != nil {
+				senderName = strings.TrimSpace(message.Sender.LastName + " " + message.Sender.FirstName)
+			}
+		}
+
+		attachments := make([]string, 0, len(message.Attachments))
+		for _, attachment := range message.Attachments {
+			attachments = append(attachments, attachment.FileName)
+		}
+
+		transcript.Messages = append(transcript.Messages, &types.TranscriptMessageResponse{
+			ID:          message.ID,
+			SenderType:  message.SenderType,
+			SenderName:  senderName,
+			Content:     message.Content,
+			Attachments: attachments,
+			CreatedAt:   message.CreatedAt,
+		})
+	}
+
+	return transcript
+}
+
+func ToFAQResponse(faq *model.FAQ) *types.FAQResponse {
+	if faq == nil {
+		return nil
+	}
+
+	return &types.FAQResponse{
+		ID:        faq.ID,
+		Question:  faq.Question,
+		Answer:    faq.Answer,
+		Keywords:  strings.Split(faq.Keywords, ","),
+		IsActive:  faq.IsActive,
+		CreatedAt: faq.CreatedAt,
+		UpdatedAt: faq.UpdatedAt,
+		CreatedBy: ToBasicUserResponse(faq.CreatedBy),
+		UpdatedBy: ToBasicUserResponse(faq.UpdatedBy),
+	}
+}
+
+func ToFAQsResponse(faqs []*model.FAQ) []*types.FAQResponse {
+	if len(faqs) == 0 {
+		return make([]*types.FAQResponse, 0)
+	}
+
+	faqsRes := make([]*types.FAQResponse, 0, len(faqs))
+	for _, faq := range faqs {
+		faqsRes = append(faqsRes, ToFAQResponse(faq))
+	}
+
+	return faqsRes
+}
+
+func ToCannedResponseResponse(cannedResponse *model.CannedResponse) *types.CannedResponseResponse {
+	if cannedResponse == nil {
+		return nil
+	}
+
+	return &types.CannedResponseResponse{
+		ID:         cannedResponse.ID,
+		Title:      cannedResponse.Title,
+		Shortcut:   cannedResponse.Shortcut,
+		Content:    cannedResponse.Content,
+		Department: ToSimpleDepartmentResponse(cannedResponse.Department),
+		CreatedAt:  cannedResponse.CreatedAt,
+		UpdatedAt:  cannedResponse.UpdatedAt,
+		CreatedBy:  ToBasicUserResponse(cannedResponse.CreatedBy),
+		UpdatedBy:  ToBasicUserResponse(cannedResponse.UpdatedBy),
+	}
+}
+
+func ToCannedResponsesResponse(cannedResponses []*model.CannedResponse) []*types.CannedResponseResponse {
+	if len(cannedResponses) == 0 {
+		return make([]*types.CannedResponseResponse, 0)
 	}
+
+	cannedResponsesRes := make([]*types.CannedResponseResponse, 0, len(cannedResponses))
+	for _, cannedResponse := range cannedResponses {
+		cannedResponsesRes = append(cannedResponsesRes, ToCannedResponseResponse(cannedResponse))
+	}
+
+	return cannedResponsesRes
+}
+
+func ToChatTransferResponse(transfer *model.ChatTransfer) *types.ChatTransferResponse {
+	if transfer == nil {
+		return nil
+	}
+
+	return &types.ChatTransferResponse{
+		ID:           transfer.ID,
+		Action:       transfer.Action,
+		FromStaff:    ToBasicUserResponse(transfer.FromStaff),
+		ToStaff:      ToBasicUserResponse(transfer.ToStaff),
+		ToDepartment: ToSimpleDepartmentResponse(transfer.ToDepartment),
+		Note:         transfer.Note,
+		CreatedBy:    ToBasicUserResponse(transfer.CreatedBy),
+		CreatedAt:    transfer.CreatedAt,
+	}
+}
+
+func ToChatTransfersResponse(transfers []*model.ChatTransfer) []*types.ChatTransferResponse {
+	if len(transfers) == 0 {
+		return make([]*types.ChatTransferResponse, 0)
+	}
+
+	transfersRes := make([]*types.ChatTransferResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		transfersRes = append(transfersRes, ToChatTransferResponse(transfer))
+	}
+
+	return transfersRes
 }
 
 func ToSimpleRequestTypeResponse(requestType *model.RequestType) *types.SimpleRequestTypeResponse {
@@ -821,6 +1219,8 @@ func ToSimpleRequestResponse(request *model.Request) *types.SimpleRequestRespons
 		Content:     request.Content,
 		RequestType: ToSimpleRequestTypeResponse(request.RequestType),
 		Status:      request.Status,
+		Priority:    request.Priority,
+		ScheduleID:  request.ScheduleID,
 		CreatedAt:   request.CreatedAt,
 	}
 }
@@ -868,17 +1268,116 @@ func ToRequestResponse(request *model.Request) *types.RequestResponse {
 	}
 
 	return &types.RequestResponse{
-		ID:          request.ID,
-		RequestType: ToSimpleRequestTypeResponse(request.RequestType),
-		OrderRoom:   ToBasicOrderRoomResponse(request.OrderRoom),
-		Content:     request.Content,
-		Status:      request.Status,
-		CreatedAt:   request.CreatedAt,
-		UpdatedAt:   request.UpdatedAt,
-		UpdatedBy:   ToBasicUserResponse(request.UpdatedBy),
+		ID:                 request.ID,
+		RequestType:        ToSimpleRequestTypeResponse(request.RequestType),
+		OrderRoom:          ToBasicOrderRoomResponse(request.OrderRoom),
+		Content:            request.Content,
+		Status:             request.Status,
+		Priority:           request.Priority,
+		ScheduleID:         request.ScheduleID,
+		AcceptDueAt:        request.AcceptDueAt,
+		CompleteDueAt:      request.CompleteDueAt,
+		AcceptedAt:         request.AcceptedAt,
+		DoneAt:             request.DoneAt,
+		AcceptBreachedAt:   request.AcceptBreachedAt,
+		CompleteBreachedAt: request.CompleteBreachedAt,
+		Assignee:           ToBasicUserResponse(request.Assignee),
+		AssignedAt:         request.AssignedAt,
+		CreatedAt:          request.CreatedAt,
+		UpdatedAt:          request.UpdatedAt,
+		UpdatedBy:          ToBasicUserResponse(request.UpdatedBy),
+		Attachments:        ToRequestAttachmentsResponse(request.Attachments),
+		Timeline:           ToRequestNotesResponse(request.Notes),
+	}
+}
+
+func ToRequestScheduleResponse(schedule *model.RequestSchedule) *types.RequestScheduleResponse {
+	if schedule == nil {
+		return nil
+	}
+
+	return &types.RequestScheduleResponse{
+		ID:          schedule.ID,
+		RequestType: ToSimpleRequestTypeResponse(schedule.RequestType),
+		Content:     schedule.Content,
+		Priority:    schedule.Priority,
+		Recurrence:  schedule.Recurrence,
+		Status:      schedule.Status,
+		NextRunAt:   schedule.NextRunAt,
+		LastRunAt:   schedule.LastRunAt,
+		CancelledAt: schedule.CancelledAt,
+		CreatedAt:   schedule.CreatedAt,
 	}
 }
 
+func ToRequestSchedulesResponse(schedules []*model.RequestSchedule) []*types.RequestScheduleResponse {
+	if len(schedules) == 0 {
+		return make([]*types.RequestScheduleResponse, 0)
+	}
+
+	schedulesRes := make([]*types.RequestScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		schedulesRes = append(schedulesRes, ToRequestScheduleResponse(schedule))
+	}
+
+	return schedulesRes
+}
+
+func ToRequestAttachmentResponse(attachment *model.RequestAttachment) *types.RequestAttachmentResponse {
+	if attachment == nil {
+		return nil
+	}
+
+	return &types.RequestAttachmentResponse{
+		ID:          attachment.ID,
+		Key:         attachment.Key,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		URL:         attachment.URL,
+	}
+}
+
+func ToRequestAttachmentsResponse(attachments []*model.RequestAttachment) []*types.RequestAttachmentResponse {
+	if len(attachments) == 0 {
+		return make([]*types.RequestAttachmentResponse, 0)
+	}
+
+	attachmentsRes := make([]*types.RequestAttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		attachmentsRes = append(attachmentsRes, ToRequestAttachmentResponse(attachment))
+	}
+
+	return attachmentsRes
+}
+
+func ToRequestNoteResponse(note *model.RequestNote) *types.RequestNoteResponse {
+	if note == nil {
+		return nil
+	}
+
+	return &types.RequestNoteResponse{
+		ID:        note.ID,
+		Type:      note.Type,
+		Content:   note.Content,
+		Staff:     ToBasicUserResponse(note.Staff),
+		CreatedAt: note.CreatedAt,
+	}
+}
+
+func ToRequestNotesResponse(notes []*model.RequestNote) []*types.RequestNoteResponse {
+	if len(notes) == 0 {
+		return make([]*types.RequestNoteResponse, 0)
+	}
+
+	notesRes := make([]*types.RequestNoteResponse, 0, len(notes))
+	for _, note := range notes {
+		notesRes = append(notesRes, ToRequestNoteResponse(note))
+	}
+
+	return notesRes
+}
+
 func ToBasicRequestResponse(request *model.Request) *types.BasicRequestResponse {
 	if request == nil {
 		return nil
@@ -889,6 +1388,8 @@ func ToBasicRequestResponse(request *model.Request) *types.BasicRequestResponse
 		RequestType: request.RequestType.Name,
 		Room:        request.OrderRoom.Room.Name,
 		Status:      request.Status,
+		Priority:    request.Priority,
+		Assignee:    ToBasicUserResponse(request.Assignee),
 		CreatedAt:   request.CreatedAt,
 	}
 }
@@ -923,15 +1424,19 @@ func ToSimpleMessageResponse(message *model.Message) *types.SimpleMessageRespons
 	}
 
 	return &types.SimpleMessageResponse{
-		ID:         message.ID,
-		Content:    message.Content,
-		ImageKey:   message.ImageKey,
-		SenderType: message.SenderType,
-		Sender:     ToBasicUserResponse(message.Sender),
-		CreatedAt:  message.CreatedAt,
-		IsRead:     message.IsRead,
-		ReadAt:     message.ReadAt,
-		StaffReads: ToMessageStaffsResponse(message.StaffsRead),
+		ID:                 message.ID,
+		Content:            message.Content,
+		Language:           message.Language,
+		TranslatedContent:  message.TranslatedContent,
+		TranslatedLanguage: message.TranslatedLanguage,
+		Attachments:        ToMessageAttachmentsResponse(message.Attachments),
+		SenderType:         message.SenderType,
+		Sender:             ToBasicUserResponse(message.Sender),
+		CreatedAt:          message.CreatedAt,
+		DeliveredAt:        message.DeliveredAt,
+		IsRead:             message.IsRead,
+		ReadAt:             message.ReadAt,
+		StaffReads:         ToMessageStaffsResponse(message.StaffsRead),
 	}
 }
 
@@ -941,13 +1446,17 @@ func ToBasicMessageResponse(message *model.Message) *types.BasicMessageResponse
 	}
 
 	return &types.BasicMessageResponse{
-		ID:         message.ID,
-		Content:    message.Content,
-		ImageKey:   message.ImageKey,
-		SenderType: message.SenderType,
-		CreatedAt:  message.CreatedAt,
-		IsRead:     message.IsRead,
-		ReadAt:     message.ReadAt,
+		ID:                 message.ID,
+		Content:            message.Content,
+		Language:           message.Language,
+		TranslatedContent:  message.TranslatedContent,
+		TranslatedLanguage: message.TranslatedLanguage,
+		Attachments:        ToMessageAttachmentsResponse(message.Attachments),
+		SenderType:         message.SenderType,
+		CreatedAt:          message.CreatedAt,
+		DeliveredAt:        message.DeliveredAt,
+		IsRead:             message.IsRead,
+		ReadAt:             message.ReadAt,
 	}
 }
 
@@ -982,16 +1491,20 @@ func ToMessageResponse(message *model.Message) *types.MessageResponse {
 	}
 
 	return &types.MessageResponse{
-		ID:         message.ID,
-		Content:    message.Content,
-		ImageKey:   message.ImageKey,
-		SenderType: message.SenderType,
-		CreatedAt:  message.CreatedAt,
-		IsRead:     message.IsRead,
-		ReadAt:     message.ReadAt,
-		StaffReads: ToMessageStaffsResponse(message.StaffsRead),
-		Sender:     ToBasicUserResponse(message.Sender),
-		ChatID:     message.ChatID,
+		ID:                 message.ID,
+		Content:            message.Content,
+		Language:           message.Language,
+		TranslatedContent:  message.TranslatedContent,
+		TranslatedLanguage: message.TranslatedLanguage,
+		Attachments:        ToMessageAttachmentsResponse(message.Attachments),
+		SenderType:         message.SenderType,
+		CreatedAt:          message.CreatedAt,
+		DeliveredAt:        message.DeliveredAt,
+		IsRead:             message.IsRead,
+		ReadAt:             message.ReadAt,
+		StaffReads:         ToMessageStaffsResponse(message.StaffsRead),
+		Sender:             ToBasicUserResponse(message.Sender),
+		ChatID:             message.ChatID,
 	}
 }
 
@@ -1034,6 +1547,10 @@ func ToSimpleChatResponse(chat *model.Chat) *types.SimpleChatResponse {
 		ID:          chat.ID,
 		OrderRoom:   ToSimpleOrderRoomResponse(chat.OrderRoom),
 		ExpiredAt:   chat.ExpiredAt,
+		Assignee:    ToBasicUserResponse(chat.Assignee),
+		AssignedAt:  chat.AssignedAt,
+		Department:  ToSimpleDepartmentResponse(chat.Department),
+		EscalatedAt: chat.EscalatedAt,
 		LastMessage: lastMessage,
 	}
 }
@@ -1119,26 +1636,65 @@ func ToSimpleReviewResponse(review *model.Review) *types.SimpleReviewResponse {
 	}
 
 	return &types.SimpleReviewResponse{
-		ID:        review.ID,
-		Star:      review.Star,
-		Content:   review.Content,
-		CreatedAt: review.CreatedAt,
+		ID:          review.ID,
+		Star:        review.Star,
+		Content:     review.Content,
+		CreatedAt:   review.CreatedAt,
+		Response:    review.Response,
+		RespondedAt: review.RespondedAt,
 	}
 }
 
-func ToReviewResponse(review *model.Review) *types.ReviewResponse {
+func ToPublicReviewResponse(review *model.Review) *types.PublicReviewResponse {
 	if review == nil {
 		return nil
 	}
 
-	return &types.ReviewResponse{
+	return &types.PublicReviewResponse{
 		ID:          review.ID,
-		Email:       review.Email,
 		Star:        review.Star,
 		Content:     review.Content,
+		IsFeatured:  review.IsFeatured,
 		CreatedAt:   review.CreatedAt,
-		UpdatedAt:   review.UpdatedAt,
-		OrderRoomID: review.OrderRoomID,
+		Response:    review.Response,
+		RespondedAt: review.RespondedAt,
+	}
+}
+
+func ToPublicReviewsResponse(reviews []*model.Review) []*types.PublicReviewResponse {
+	if len(reviews) == 0 {
+		return make([]*types.PublicReviewResponse, 0)
+	}
+
+	reviewsRes := make([]*types.PublicReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		reviewsRes = append(reviewsRes, ToPublicReviewResponse(review))
+	}
+
+	return reviewsRes
+}
+
+func ToReviewResponse(review *model.Review) *types.ReviewResponse {
+	if review == nil {
+		return nil
+	}
+
+	return &types.ReviewResponse{
+		ID:               review.ID,
+		Email:            review.Email,
+		Star:             review.Star,
+		Content:          review.Content,
+		Status:           review.Status,
+		ModerationReason: review.ModerationReason,
+		ModeratedBy:      ToBasicUserResponse(review.ModeratedBy),
+		ModeratedAt:      review.ModeratedAt,
+		IsFeatured:       review.IsFeatured,
+		Response:         review.Response,
+		RespondedBy:      ToBasicUserResponse(review.RespondedBy),
+		RespondedAt:      review.RespondedAt,
+		CreatedAt:        review.CreatedAt,
+		UpdatedAt:        review.UpdatedAt,
+		OrderRoomID:      review.OrderRoomID,
 	}
 }
 
@@ -1154,3 +1710,72 @@ func ToReviewsResponse(reviews []*model.Review) []*types.ReviewResponse {
 
 	return reviewsRes
 }
+
+func ToMessageAttachmentResponse(attachment *model.MessageAttachment) *types.MessageAttachmentResponse {
+	if attachment == nil {
+		return nil
+	}
+
+	return &types.MessageAttachmentResponse{
+		ID:           attachment.ID,
+		Key:          attachment.Key,
+		FileName:     attachment.FileName,
+		ContentType:  attachment.ContentType,
+		Size:         attachment.Size,
+		ThumbnailKey: attachment.ThumbnailKey,
+		URL:          attachment.URL,
+		ThumbnailURL: attachment.ThumbnailURL,
+	}
+}
+
+func ToMessageAttachmentsResponse(attachments []*model.MessageAttachment) []*types.MessageAttachmentResponse {
+	if len(attachments) == 0 {
+		return make([]*types.MessageAttachmentResponse, 0)
+	}
+
+	attachmentsRes := make([]*types.MessageAttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		attachmentsRes = append(attachmentsRes, ToMessageAttachmentResponse(attachment))
+	}
+
+	return attachmentsRes
+}
+
+func ToPricingRuleResponse(rule *model.PricingRule) *types.PricingRuleResponse {
+	if rule == nil {
+		return nil
+	}
+
+	return &types.PricingRuleResponse{
+		ID:            rule.ID,
+		Name:          rule.Name,
+		ServiceTypeID: rule.ServiceTypeID,
+		ServiceID:     rule.ServiceID,
+		DayType:       rule.DayType,
+		StartTime:     rule.StartTime,
+		EndTime:       rule.EndTime,
+		StartsAt:      rule.StartsAt,
+		EndsAt:        rule.EndsAt,
+		PromoCode:     rule.PromoCode,
+		DiscountType:  rule.DiscountType,
+		DiscountValue: rule.DiscountValue,
+		IsActive:      rule.IsActive,
+		CreatedAt:     rule.CreatedAt,
+		UpdatedAt:     rule.UpdatedAt,
+		CreatedBy:     ToBasicUserResponse(rule.CreatedBy),
+		UpdatedBy:     ToBasicUserResponse(rule.UpdatedBy),
+	}
+}
+
+func ToPricingRulesResponse(rules []*model.PricingRule) []*types.PricingRuleResponse {
+	if len(rules) == 0 {
+		return make([]*types.PricingRuleResponse, 0)
+	}
+
+	rulesRes := make([]*types.PricingRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		rulesRes = append(rulesRes, ToPricingRuleResponse(rule))
+	}
+
+	return rulesRes
+}
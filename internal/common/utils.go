@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/InstaySystem/is_v1-be/internal/types"
@@ -125,3 +128,88 @@ func ExtractRootDomain(host string) string {
 
 	return host
 }
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+func NormalizeLocale(locale string) (string, bool) {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if len(locale) > 20 || !localePattern.MatchString(locale) {
+		return "", false
+	}
+
+	return locale, true
+}
+
+// ParseAcceptLanguage returns the locales of an Accept-Language header ordered
+// by preference. A regional tag is followed by its base language so "en-GB"
+// still matches an "en" translation.
+func ParseAcceptLanguage(header string) []string {
+	type weightedLocale struct {
+		locale string
+		weight float64
+	}
+
+	var weighted []weightedLocale
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		locale, ok := NormalizeLocale(tag)
+		if !ok {
+			continue
+		}
+
+		weight := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil || parsed <= 0 {
+				continue
+			}
+			weight = parsed
+		}
+
+		weighted = append(weighted, weightedLocale{locale, weight})
+	}
+
+	slices.SortStableFunc(weighted, func(a, b weightedLocale) int {
+		switch {
+		case a.weight > b.weight:
+			return -1
+		case a.weight < b.weight:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	locales := make([]string, 0, len(weighted))
+	for _, w := range weighted {
+		if !slices.Contains(locales, w.locale) {
+			locales = append(locales, w.locale)
+		}
+		if base, _, found := strings.Cut(w.locale, "-"); found && !slices.Contains(locales, base) {
+			locales = append(locales, base)
+		}
+		if len(locales) >= MaxAcceptLanguages {
+			break
+		}
+	}
+
+	return locales
+}
+
+// IsUploadKeyFor reports whether key was issued for an upload of purpose.
+func IsUploadKeyFor(key, purpose string) bool {
+	policy, ok := UploadPolicies[purpose]
+	return ok && strings.HasPrefix(key, policy.Prefix)
+}
+
+// IsTrackedUploadKey reports whether key was issued by the presigned upload
+// flow, which records every key it hands out. Older keys have no prefix.
+func IsTrackedUploadKey(key string) bool {
+	for _, policy := range UploadPolicies {
+		if strings.HasPrefix(key, policy.Prefix) {
+			return true
+		}
+	}
+
+	return false
+}
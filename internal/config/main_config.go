@@ -38,6 +38,10 @@ type Config struct {
 		UseSSL   bool   `mapstructure:"use_ssl"`
 	} `mapstructure:"redis"`
 
+	MQ struct {
+		Driver string `mapstructure:"driver"`
+	} `mapstructure:"mq"`
+
 	RabbitMQ struct {
 		Host     string `mapstructure:"host"`
 		Port     int    `mapstructure:"port"`
@@ -56,10 +60,31 @@ type Config struct {
 		DBName   string `mapstructure:"db_name"`
 	} `mapstructure:"postgresql"`
 
+	Storage struct {
+		Driver string `mapstructure:"driver"`
+	} `mapstructure:"storage"`
+
 	GCS struct {
 		Bucket string `mapstructure:"bucket"`
 	} `mapstructure:"gcs"`
 
+	S3 struct {
+		Endpoint        string        `mapstructure:"endpoint"`
+		PublicEndpoint  string        `mapstructure:"public_endpoint"`
+		Region          string        `mapstructure:"region"`
+		Bucket          string        `mapstructure:"bucket"`
+		AccessKeyID     string        `mapstructure:"access_key_id"`
+		SecretAccessKey string        `mapstructure:"secret_access_key"`
+		UsePathStyle    bool          `mapstructure:"use_path_style"`
+		Timeout         time.Duration `mapstructure:"timeout"`
+	} `mapstructure:"s3"`
+
+	LocalStorage struct {
+		Root      string `mapstructure:"root"`
+		BaseURL   string `mapstructure:"base_url"`
+		SecretKey string `mapstructure:"secret_key"`
+	} `mapstructure:"local_storage"`
+
 	SMTP struct {
 		Host     string `mapstructure:"host"`
 		Port     int    `mapstructure:"port"`
@@ -74,6 +99,45 @@ type Config struct {
 		Password string `mapstructure:"password"`
 	} `mapstructure:"imap"`
 
+	Translation struct {
+		Driver           string        `mapstructure:"driver"`
+		Endpoint         string        `mapstructure:"endpoint"`
+		APIKey           string        `mapstructure:"api_key"`
+		Timeout          time.Duration `mapstructure:"timeout"`
+		StaffLanguage    string        `mapstructure:"staff_language"`
+		TranslateReplies bool          `mapstructure:"translate_replies"`
+	} `mapstructure:"translation"`
+
+	Chatbot struct {
+		Enabled         bool   `mapstructure:"enabled"`
+		StaffedFrom     string `mapstructure:"staffed_from"`
+		StaffedTo       string `mapstructure:"staffed_to"`
+		Timezone        string `mapstructure:"timezone"`
+		FallbackMessage string `mapstructure:"fallback_message"`
+	} `mapstructure:"chatbot"`
+
+	Chat struct {
+		TranscriptEmail   bool          `mapstructure:"transcript_email"`
+		TranscriptFont    string        `mapstructure:"transcript_font"`
+		RetentionDays     int           `mapstructure:"retention_days"`
+		RetentionMode     string        `mapstructure:"retention_mode"`
+		RetentionInterval time.Duration `mapstructure:"retention_interval"`
+	} `mapstructure:"chat"`
+
+	SLA struct {
+		SweepInterval time.Duration `mapstructure:"sweep_interval"`
+	} `mapstructure:"sla"`
+
+	Upload struct {
+		GracePeriod   time.Duration `mapstructure:"grace_period"`
+		SweepInterval time.Duration `mapstructure:"sweep_interval"`
+	} `mapstructure:"upload"`
+
+	Availability struct {
+		Timezone string `mapstructure:"timezone"`
+		SlotDays int    `mapstructure:"slot_days"`
+	} `mapstructure:"availability"`
+
 	Admin struct {
 		Username string `mapstructure:"username"`
 		Password string `mapstructure:"password"`
@@ -95,6 +159,8 @@ func LoadConfig() (*Config, error) {
 	viper.BindEnv("redis.password", "RD_PASSWORD")
 	viper.BindEnv("redis.use_ssl", "RD_USE_SSL")
 
+	viper.BindEnv("mq.driver", "MQ_DRIVER")
+
 	viper.BindEnv("rabbitmq.host", "RMQ_HOST")
 	viper.BindEnv("rabbitmq.port", "RMQ_PORT")
 	viper.BindEnv("rabbitmq.user", "RMQ_USER")
@@ -102,8 +168,23 @@ func LoadConfig() (*Config, error) {
 	viper.BindEnv("rabbitmq.vhost", "RMQ_VHOST")
 	viper.BindEnv("rabbitmq.use_ssl", "RMQ_USE_SSL")
 
+	viper.BindEnv("storage.driver", "STORAGE_DRIVER")
+
 	viper.BindEnv("gcs.bucket", "GCS_BUCKET")
 
+	viper.BindEnv("s3.endpoint", "S3_ENDPOINT")
+	viper.BindEnv("s3.public_endpoint", "S3_PUBLIC_ENDPOINT")
+	viper.BindEnv("s3.region", "S3_REGION")
+	viper.BindEnv("s3.bucket", "S3_BUCKET")
+	viper.BindEnv("s3.access_key_id", "S3_ACCESS_KEY_ID")
+	viper.BindEnv("s3.secret_access_key", "S3_SECRET_ACCESS_KEY")
+	viper.BindEnv("s3.use_path_style", "S3_USE_PATH_STYLE")
+	viper.BindEnv("s3.timeout", "S3_TIMEOUT")
+
+	viper.BindEnv("local_storage.root", "LOCAL_STORAGE_ROOT")
+	viper.BindEnv("local_storage.base_url", "LOCAL_STORAGE_BASE_URL")
+	viper.BindEnv("local_storage.secret_key", "LOCAL_STORAGE_SECRET_KEY")
+
 	viper.BindEnv("smtp.host", "SMTP_HOST")
 	viper.BindEnv("smtp.port", "SMTP_PORT")
 	viper.BindEnv("smtp.user", "SMTP_USER")
@@ -114,6 +195,33 @@ func LoadConfig() (*Config, error) {
 	viper.BindEnv("imap.user", "IMAP_USER")
 	viper.BindEnv("imap.password", "IMAP_PASSWORD")
 
+	viper.BindEnv("translation.driver", "TRANSLATION_DRIVER")
+	viper.BindEnv("translation.endpoint", "TRANSLATION_ENDPOINT")
+	viper.BindEnv("translation.api_key", "TRANSLATION_API_KEY")
+	viper.BindEnv("translation.timeout", "TRANSLATION_TIMEOUT")
+	viper.BindEnv("translation.staff_language", "TRANSLATION_STAFF_LANGUAGE")
+	viper.BindEnv("translation.translate_replies", "TRANSLATION_TRANSLATE_REPLIES")
+
+	viper.BindEnv("chatbot.enabled", "CHATBOT_ENABLED")
+	viper.BindEnv("chatbot.staffed_from", "CHATBOT_STAFFED_FROM")
+	viper.BindEnv("chatbot.staffed_to", "CHATBOT_STAFFED_TO")
+	viper.BindEnv("chatbot.timezone", "CHATBOT_TIMEZONE")
+	viper.BindEnv("chatbot.fallback_message", "CHATBOT_FALLBACK_MESSAGE")
+
+	viper.BindEnv("chat.transcript_email", "CHAT_TRANSCRIPT_EMAIL")
+	viper.BindEnv("chat.transcript_font", "CHAT_TRANSCRIPT_FONT")
+	viper.BindEnv("chat.retention_days", "CHAT_RETENTION_DAYS")
+	viper.BindEnv("chat.retention_mode", "CHAT_RETENTION_MODE")
+	viper.BindEnv("chat.retention_interval", "CHAT_RETENTION_INTERVAL")
+
+	viper.BindEnv("sla.sweep_interval", "SLA_SWEEP_INTERVAL")
+
+	viper.BindEnv("upload.grace_period", "UPLOAD_GRACE_PERIOD")
+	viper.BindEnv("upload.sweep_interval", "UPLOAD_SWEEP_INTERVAL")
+
+	viper.BindEnv("availability.timezone", "AVAILABILITY_TIMEZONE")
+	viper.BindEnv("availability.slot_days", "AVAILABILITY_SLOT_DAYS")
+
 	viper.BindEnv("admin.username", "AD_USERNAME")
 	viper.BindEnv("admin.password", "AD_PASSWORD")
 	viper.BindEnv("admin.email", "AD_EMAIL")
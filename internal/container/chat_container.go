@@ -1,10 +1,16 @@
 package container
 
 import (
+	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/handler"
+	"github.com/InstaySystem/is_v1-be/internal/provider/mq"
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
+	"github.com/InstaySystem/is_v1-be/internal/provider/translation"
 	"github.com/InstaySystem/is_v1-be/internal/repository"
 	"github.com/InstaySystem/is_v1-be/internal/service"
 	svcImpl "github.com/InstaySystem/is_v1-be/internal/service/implement"
+	"github.com/InstaySystem/is_v1-be/pkg/imaging"
+	"github.com/InstaySystem/is_v1-be/pkg/pdf"
 	"github.com/InstaySystem/is_v1-be/pkg/snowflake"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -20,10 +26,19 @@ func NewChatContainer(
 	chatRepo repository.ChatRepository,
 	orderRepo repository.OrderRepository,
 	userRepo repository.UserRepository,
+	departmentRepo repository.DepartmentRepository,
+	notificationRepo repository.Notification,
+	fileRepo repository.FileRepository,
 	sfGen snowflake.Generator,
 	logger *zap.Logger,
+	storageProvider storage.StorageProvider,
+	cfg *config.Config,
+	imgProcessor imaging.Processor,
+	mqProvider mq.MessageQueueProvider,
+	pdfGen pdf.Generator,
+	translator translation.TranslationProvider,
 ) *ChatContainer {
-	svc := svcImpl.NewChatService(db, chatRepo, orderRepo, userRepo, sfGen, logger)
+	svc := svcImpl.NewChatService(db, chatRepo, orderRepo, userRepo, departmentRepo, notificationRepo, fileRepo, sfGen, logger, storageProvider, cfg, imgProcessor, mqProvider, pdfGen, translator)
 	hdl := handler.NewChatHandler(svc)
 
 	return &ChatContainer{
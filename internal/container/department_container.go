@@ -14,10 +14,11 @@ type DepartmentContainer struct {
 
 func NewDepartmentContainer(
 	departmentRepo repository.DepartmentRepository,
+	userRepo repository.UserRepository,
 	sfGen snowflake.Generator,
 	logger *zap.Logger,
 ) *DepartmentContainer {
-	svc := svcImpl.NewDepartmentService(departmentRepo, sfGen, logger)
+	svc := svcImpl.NewDepartmentService(departmentRepo, userRepo, sfGen, logger)
 	hdl := handler.NewDepartmentHandler(svc)
 
 	return &DepartmentContainer{hdl}
@@ -1,24 +1,35 @@
 package container
 
 import (
-	"cloud.google.com/go/storage"
 	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/handler"
+	"github.com/InstaySystem/is_v1-be/internal/provider/mq"
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
+	"github.com/InstaySystem/is_v1-be/internal/repository"
+	"github.com/InstaySystem/is_v1-be/internal/service"
 	svcImpl "github.com/InstaySystem/is_v1-be/internal/service/implement"
+	"github.com/InstaySystem/is_v1-be/pkg/snowflake"
 	"go.uber.org/zap"
 )
 
 type FileContainer struct {
 	Hdl *handler.FileHandler
+	Svc service.FileService
 }
 
 func NewFileContainer(
 	cfg *config.Config,
-	client *storage.Client,
+	fileRepo repository.FileRepository,
+	sfGen snowflake.Generator,
+	storageProvider storage.StorageProvider,
+	mqProvider mq.MessageQueueProvider,
 	logger *zap.Logger,
 ) *FileContainer {
-	svc := svcImpl.NewFileService(client, cfg, logger)
+	svc := svcImpl.NewFileService(fileRepo, sfGen, storageProvider, mqProvider, cfg, logger)
 	hdl := handler.NewFileHandler(svc)
 
-	return &FileContainer{hdl}
+	return &FileContainer{
+		hdl,
+		svc,
+	}
 }
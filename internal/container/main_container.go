@@ -1,7 +1,6 @@
 package container
 
 import (
-	"cloud.google.com/go/storage"
 	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/hub"
 	"github.com/InstaySystem/is_v1-be/internal/middleware"
@@ -9,9 +8,13 @@ import (
 	"github.com/InstaySystem/is_v1-be/internal/provider/jwt"
 	"github.com/InstaySystem/is_v1-be/internal/provider/mq"
 	"github.com/InstaySystem/is_v1-be/internal/provider/smtp"
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
+	"github.com/InstaySystem/is_v1-be/internal/provider/translation"
 	"github.com/InstaySystem/is_v1-be/internal/repository"
 	repoImpl "github.com/InstaySystem/is_v1-be/internal/repository/implement"
 	"github.com/InstaySystem/is_v1-be/pkg/bcrypt"
+	"github.com/InstaySystem/is_v1-be/pkg/imaging"
+	"github.com/InstaySystem/is_v1-be/pkg/pdf"
 	"github.com/InstaySystem/is_v1-be/pkg/snowflake"
 	"github.com/rabbitmq/amqp091-go"
 	"github.com/redis/go-redis/v9"
@@ -52,16 +55,31 @@ func NewContainer(
 	cfg *config.Config,
 	db *gorm.DB,
 	rdb *redis.Client,
-	gcs *storage.Client,
+	storageProvider storage.StorageProvider,
 	sf *sonyflake.Sonyflake,
 	logger *zap.Logger,
 	rmq *amqp091.Connection,
 ) *Container {
 	sfGen := snowflake.NewGenerator(sf)
 	bHash := bcrypt.NewHasher(10)
+	imgProcessor := imaging.NewProcessor(80)
+	pdfGen := pdf.NewGenerator(cfg.Chat.TranscriptFont)
 	jwtProvider := jwt.NewJWTProvider(cfg.JWT.SecretKey)
 	smtpProvider := smtp.NewSMTPProvider(cfg)
-	mqProvider := mq.NewMessageQueueProvider(rmq, logger)
+	var mqProvider mq.MessageQueueProvider
+	switch cfg.MQ.Driver {
+	case "", "rabbitmq":
+		mqProvider = mq.NewMessageQueueProvider(rmq, logger)
+	case "memory":
+		mqProvider = mq.NewMemoryMessageQueueProvider(logger)
+	}
+	var translator translation.TranslationProvider
+	switch cfg.Translation.Driver {
+	case "google":
+		translator = translation.NewGoogleTranslationProvider(cfg.Translation.Endpoint, cfg.Translation.APIKey, cfg.Translation.Timeout)
+	case "fake":
+		translator = translation.NewFakeTranslationProvider()
+	}
 	cacheProvider := cache.NewCacheProvider(rdb)
 	sseHub := hub.NewSSEHub()
 
@@ -75,18 +93,19 @@ func NewContainer(
 	notificationRepo := repoImpl.NewNotificationRepository(db)
 	chatRepo := repoImpl.NewChatRepository(db)
 	reviewRepo := repoImpl.NewReviewRepository(db)
+	fileRepo := repoImpl.NewFileRepository(db)
 
-	fileCtn := NewFileContainer(cfg, gcs, logger)
+	fileCtn := NewFileContainer(cfg, fileRepo, sfGen, storageProvider, mqProvider, logger)
 	authCtn := NewAuthContainer(cfg, db, userRepo, logger, bHash, jwtProvider, cacheProvider, mqProvider)
 	userCtn := NewUserContainer(userRepo, sfGen, logger, bHash, cfg.JWT.RefreshExpiresIn, cacheProvider)
-	departmentCtn := NewDepartmentContainer(departmentRepo, sfGen, logger)
-	serviceCtn := NewServiceContainer(db, serviceRepo, sfGen, logger, mqProvider)
-	requestCtn := NewRequestContainer(db, requestRepo, orderRepo, notificationRepo, sfGen, logger, mqProvider)
+	departmentCtn := NewDepartmentContainer(departmentRepo, userRepo, sfGen, logger)
+	serviceCtn := NewServiceContainer(db, serviceRepo, departmentRepo, fileRepo, sfGen, logger, mqProvider, storageProvider, imgProcessor, cfg)
+	requestCtn := NewRequestContainer(db, requestRepo, orderRepo, notificationRepo, userRepo, fileRepo, sfGen, logger, mqProvider, storageProvider, cfg)
 	roomCtn := NewRoomContainer(roomRepo, sfGen, logger)
 	bookingCtn := NewBookingContainer(bookingRepo, logger)
-	orderCtn := NewOrderContainer(db, orderRepo, bookingRepo, roomRepo, serviceRepo, notificationRepo, chatRepo, sfGen, logger, cacheProvider, jwtProvider, mqProvider, cfg.JWT.GuestName)
+	orderCtn := NewOrderContainer(db, orderRepo, bookingRepo, roomRepo, serviceRepo, notificationRepo, chatRepo, userRepo, sfGen, logger, cacheProvider, jwtProvider, mqProvider, cfg)
 	notificationCtn := NewNotificationContainer(db, notificationRepo, logger, sfGen)
-	chatCtn := NewChatContainer(db, chatRepo, orderRepo, userRepo, sfGen, logger)
+	chatCtn := NewChatContainer(db, chatRepo, orderRepo, userRepo, departmentRepo, notificationRepo, fileRepo, sfGen, logger, storageProvider, cfg, imgProcessor, mqProvider, pdfGen, translator)
 	reviewCtn := NewReviewContainer(reviewRepo, sfGen, logger)
 	dashboardCtn := NewDashboardContainer(userRepo, roomRepo, serviceRepo, bookingRepo, orderRepo, requestRepo, reviewRepo, logger)
 	wsHub := hub.NewWSHub(chatCtn.Svc)
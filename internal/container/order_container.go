@@ -1,6 +1,7 @@
 package container
 
 import (
+	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/handler"
 	"github.com/InstaySystem/is_v1-be/internal/provider/cache"
 	"github.com/InstaySystem/is_v1-be/internal/provider/jwt"
@@ -24,15 +25,16 @@ func NewOrderContainer(
 	serviceRepo repository.ServiceRepository,
 	notificationRepo repository.Notification,
 	chatRepo repository.ChatRepository,
+	userRepo repository.UserRepository,
 	sfGen snowflake.Generator,
 	logger *zap.Logger,
 	cacheProvider cache.CacheProvider,
 	jwtProvider jwt.JWTProvider,
 	mqProvider mq.MessageQueueProvider,
-	guestName string,
+	cfg *config.Config,
 ) *OrderContainer {
-	svc := svcImpl.NewOrderService(db, orderRepo, bookingRepo, roomRepo, serviceRepo, notificationRepo, chatRepo, sfGen, logger, cacheProvider, jwtProvider, mqProvider)
-	hdl := handler.NewOrderHandler(svc, guestName)
+	svc := svcImpl.NewOrderService(db, orderRepo, bookingRepo, roomRepo, serviceRepo, notificationRepo, chatRepo, userRepo, sfGen, logger, cacheProvider, jwtProvider, mqProvider, cfg)
+	hdl := handler.NewOrderHandler(svc, cfg.JWT.GuestName)
 
 	return &OrderContainer{hdl}
 }
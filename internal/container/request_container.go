@@ -1,9 +1,12 @@
 package container
 
 import (
+	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/handler"
 	"github.com/InstaySystem/is_v1-be/internal/provider/mq"
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
 	"github.com/InstaySystem/is_v1-be/internal/repository"
+	"github.com/InstaySystem/is_v1-be/internal/service"
 	svcImpl "github.com/InstaySystem/is_v1-be/internal/service/implement"
 	"github.com/InstaySystem/is_v1-be/pkg/snowflake"
 	"go.uber.org/zap"
@@ -12,6 +15,7 @@ import (
 
 type RequestContainer struct {
 	Hdl *handler.RequestHandler
+	Svc service.RequestService
 }
 
 func NewRequestContainer(
@@ -19,12 +23,16 @@ func NewRequestContainer(
 	requestRepo repository.RequestRepository,
 	orderRepo repository.OrderRepository,
 	notificationRepo repository.Notification,
+	userRepo repository.UserRepository,
+	fileRepo repository.FileRepository,
 	sfGen snowflake.Generator,
 	logger *zap.Logger,
 	mqProvider mq.MessageQueueProvider,
+	storageProvider storage.StorageProvider,
+	cfg *config.Config,
 ) *RequestContainer {
-	svc := svcImpl.NewRequestService(db, requestRepo, orderRepo, notificationRepo, sfGen, logger, mqProvider)
+	svc := svcImpl.NewRequestService(db, requestRepo, orderRepo, notificationRepo, userRepo, fileRepo, sfGen, logger, mqProvider, storageProvider, cfg)
 	hdl := handler.NewRequestHandler(svc)
 
-	return &RequestContainer{hdl}
+	return &RequestContainer{hdl, svc}
 }
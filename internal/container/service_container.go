@@ -1,28 +1,38 @@
 package container
 
 import (
+	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/handler"
 	"github.com/InstaySystem/is_v1-be/internal/provider/mq"
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
 	"github.com/InstaySystem/is_v1-be/internal/repository"
+	"github.com/InstaySystem/is_v1-be/internal/service"
 	svcImpl "github.com/InstaySystem/is_v1-be/internal/service/implement"
+	"github.com/InstaySystem/is_v1-be/pkg/imaging"
 	"github.com/InstaySystem/is_v1-be/pkg/snowflake"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 type ServiceContainer struct {
+	Svc service.ServiceService
 	Hdl *handler.ServiceHandler
 }
 
 func NewServiceContainer(
 	db *gorm.DB,
 	serviceRepo repository.ServiceRepository,
+	departmentRepo repository.DepartmentRepository,
+	fileRepo repository.FileRepository,
 	sfGen snowflake.Generator,
 	logger *zap.Logger,
 	mqProvider mq.MessageQueueProvider,
+	storageProvider storage.StorageProvider,
+	imgProcessor imaging.Processor,
+	cfg *config.Config,
 ) *ServiceContainer {
-	svc := svcImpl.NewServiceService(serviceRepo, db, sfGen, logger, mqProvider)
+	svc := svcImpl.NewServiceService(serviceRepo, departmentRepo, fileRepo, db, sfGen, logger, mqProvider, storageProvider, imgProcessor, cfg)
 	hdl := handler.NewServiceHandler(svc)
 
-	return &ServiceContainer{hdl}
+	return &ServiceContainer{svc, hdl}
 }
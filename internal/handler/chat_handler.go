@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -36,6 +37,12 @@ func (h *ChatHandler) GetChatsForAdmin(c *gin.Context) {
 		return
 	}
 
+	departmentID, err := chatDepartmentScope(user)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	var query types.ChatPaginationQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
 		mess := common.HandleValidationError(err)
@@ -43,7 +50,7 @@ func (h *ChatHandler) GetChatsForAdmin(c *gin.Context) {
 		return
 	}
 
-	chats, meta, err := h.chatSvc.GetChatsForAdmin(ctx, query, user.ID)
+	chats, meta, err := h.chatSvc.GetChatsForAdmin(ctx, query, user.ID, departmentID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -55,6 +62,47 @@ func (h *ChatHandler) GetChatsForAdmin(c *gin.Context) {
 	})
 }
 
+func (h *ChatHandler) SearchMessages(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	departmentID, err := chatDepartmentScope(user)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var query types.MessageSearchQuery
+	if err = c.ShouldBindQuery(&query); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	messages, meta, err := h.chatSvc.SearchMessages(ctx, query, departmentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Search messages successfully", gin.H{
+		"messages": messages,
+		"meta":     meta,
+	})
+}
+
 func (h *ChatHandler) GetChatByID(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
@@ -78,7 +126,13 @@ func (h *ChatHandler) GetChatByID(c *gin.Context) {
 		return
 	}
 
-	chat, err := h.chatSvc.GetChatByID(ctx, chatID, user.ID)
+	departmentID, err := chatDepartmentScope(user)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	chat, err := h.chatSvc.GetChatByID(ctx, chatID, user.ID, departmentID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -89,6 +143,52 @@ func (h *ChatHandler) GetChatByID(c *gin.Context) {
 	})
 }
 
+func (h *ChatHandler) ExportTranscript(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	chatIDStr := c.Param("id")
+	chatID, err := strconv.ParseInt(chatIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	departmentID, err := chatDepartmentScope(user)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var query types.TranscriptQuery
+	if err = c.ShouldBindQuery(&query); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	data, contentType, fileName, err := h.chatSvc.ExportTranscript(ctx, chatID, user.ID, departmentID, query.Format)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	c.Data(http.StatusOK, contentType, data)
+}
+
 func (h *ChatHandler) GetMyChat(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
@@ -109,3 +209,383 @@ func (h *ChatHandler) GetMyChat(c *gin.Context) {
 		"chat": common.ToBasicChatWithMessagesResponse(chat),
 	})
 }
+
+func (h *ChatHandler) ClaimChat(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	chatIDStr := c.Param("id")
+	chatID, err := strconv.ParseInt(chatIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	departmentID, err := chatDepartmentScope(user)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err = h.chatSvc.ClaimChat(ctx, chatID, user.ID, departmentID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Claim chat successfully", nil)
+}
+
+func (h *ChatHandler) AssignChat(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	chatIDStr := c.Param("id")
+	chatID, err := strconv.ParseInt(chatIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	departmentID, err := chatDepartmentScope(user)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req types.AssignChatRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err = h.chatSvc.AssignChat(ctx, chatID, user.ID, departmentID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Assign chat successfully", nil)
+}
+
+func (h *ChatHandler) TransferChat(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	chatIDStr := c.Param("id")
+	chatID, err := strconv.ParseInt(chatIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	departmentID, err := chatDepartmentScope(user)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req types.TransferChatRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err = h.chatSvc.TransferChat(ctx, chatID, user.ID, departmentID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Transfer chat successfully", nil)
+}
+
+func (h *ChatHandler) CreateCannedResponse(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.CreateCannedResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err := h.chatSvc.CreateCannedResponse(ctx, user.ID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusCreated, "Canned response created successfully", nil)
+}
+
+func (h *ChatHandler) GetCannedResponses(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var departmentID *int64
+	if user.Role != common.RoleAdmin {
+		if user.Department == nil {
+			c.Error(common.ErrForbidden)
+			return
+		}
+		departmentID = &user.Department.ID
+	}
+
+	var query types.CannedResponseQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	cannedResponses, err := h.chatSvc.GetCannedResponses(ctx, query, departmentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Get canned responses successfully", gin.H{
+		"canned_responses": common.ToCannedResponsesResponse(cannedResponses),
+	})
+}
+
+func (h *ChatHandler) UpdateCannedResponse(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	cannedResponseIDStr := c.Param("id")
+	cannedResponseID, err := strconv.ParseInt(cannedResponseIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.UpdateCannedResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err = h.chatSvc.UpdateCannedResponse(ctx, cannedResponseID, user.ID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Canned response updated successfully", nil)
+}
+
+func (h *ChatHandler) DeleteCannedResponse(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	cannedResponseIDStr := c.Param("id")
+	cannedResponseID, err := strconv.ParseInt(cannedResponseIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	if err = h.chatSvc.DeleteCannedResponse(ctx, cannedResponseID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Canned response deleted successfully", nil)
+}
+
+func (h *ChatHandler) CreateFAQ(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.CreateFAQRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err := h.chatSvc.CreateFAQ(ctx, user.ID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusCreated, "FAQ created successfully", nil)
+}
+
+func (h *ChatHandler) GetFAQs(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var query types.FAQQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	faqs, err := h.chatSvc.GetFAQs(ctx, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Get FAQs successfully", gin.H{
+		"faqs": common.ToFAQsResponse(faqs),
+	})
+}
+
+func (h *ChatHandler) UpdateFAQ(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	faqIDStr := c.Param("id")
+	faqID, err := strconv.ParseInt(faqIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.UpdateFAQRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err = h.chatSvc.UpdateFAQ(ctx, faqID, user.ID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "FAQ updated successfully", nil)
+}
+
+func (h *ChatHandler) DeleteFAQ(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	faqIDStr := c.Param("id")
+	faqID, err := strconv.ParseInt(faqIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	if err = h.chatSvc.DeleteFAQ(ctx, faqID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "FAQ deleted successfully", nil)
+}
+
+func chatDepartmentScope(user *types.UserData) (*int64, error) {
+	if user.Role == common.RoleAdmin {
+		return nil, nil
+	}
+	if user.Department == nil {
+		return nil, common.ErrForbidden
+	}
+	if user.Department.Name == common.ChatDepartment {
+		return nil, nil
+	}
+
+	return &user.Department.ID, nil
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/common"
@@ -21,13 +22,19 @@ func NewFileHandler(fileSvc service.FileService) *FileHandler {
 
 // UploadPresignedURLs godoc
 // @Summary      Get Upload Presigned URLs
-// @Description  Tạo một hoặc nhiều URL (presigned) để client upload file lên storage
+// @Description  Tạo một hoặc nhiều URL (presigned) để nhân viên hoặc khách upload file lên storage theo mục đích (loại file, dung lượng, hạn mức mỗi ngày). Client phải gửi kèm các header trong `headers`
 // @Tags         Files
 // @Accept       json
 // @Produce      json
-// @Param        payload                       body      types.UploadPresignedURLsRequest  true  "Danh sách file cần tạo URL (tên file, content type)"
+// @Security     ApiKeyAuth
+// @Param        payload                       body      types.UploadPresignedURLsRequest  true  "Danh sách file cần tạo URL (tên file, content type, dung lượng, mục đích)"
 // @Success      200                           {object}  types.APIResponse{data=object{presigned_urls=[]types.UploadPresignedURLResponse}}  "Tạo URL upload thành công"
 // @Failure      400                           {object}  types.APIResponse  "Bad Request (validation error)"
+// @Failure      401                           {object}  types.APIResponse  "Unauthorized"
+// @Failure      403                           {object}  types.APIResponse  "Forbidden (không được upload cho mục đích này)"
+// @Failure      413                           {object}  types.APIResponse  "File quá lớn"
+// @Failure      415                           {object}  types.APIResponse  "Loại file không được hỗ trợ"
+// @Failure      429                           {object}  types.APIResponse  "Vượt hạn mức upload"
 // @Failure      500                           {object}  types.APIResponse  "Internal Server Error"
 // @Router       /files/presigned-urls/uploads [post]
 func (h *FileHandler) UploadPresignedURLs(c *gin.Context) {
@@ -41,7 +48,7 @@ func (h *FileHandler) UploadPresignedURLs(c *gin.Context) {
 		return
 	}
 
-	presignedURLs, err := h.fileSvc.CreateUploadURLs(ctx, req)
+	presignedURLs, err := h.fileSvc.CreateUploadURLs(ctx, c.GetInt64("client_id"), c.GetString("client_type"), req)
 	if err != nil {
 		c.Error(err)
 		return
@@ -54,13 +61,16 @@ func (h *FileHandler) UploadPresignedURLs(c *gin.Context) {
 
 // ViewPresignedURLs godoc
 // @Summary      Get View Presigned URLs
-// @Description  Tạo một hoặc nhiều URL (presigned) để client xem/tải file từ storage
+// @Description  Tạo một hoặc nhiều URL (presigned) để client xem/tải file từ storage. Khách chỉ xem được file công khai, file mình upload và file đính kèm trong chat/yêu cầu của mình
 // @Tags         Files
 // @Accept       json
 // @Produce      json
+// @Security     ApiKeyAuth
 // @Param        payload                     body      types.ViewPresignedURLsRequest  true  "Danh sách file key cần xem"
 // @Success      200                         {object}  types.APIResponse{data=object{presigned_urls=[]types.ViewPresignedURLResponse}}  "Tạo URL xem thành công"
 // @Failure      400                         {object}  types.APIResponse  "Bad Request (validation error)"
+// @Failure      401                         {object}  types.APIResponse  "Unauthorized"
+// @Failure      403                         {object}  types.APIResponse  "Forbidden (không có quyền xem file)"
 // @Failure      500                         {object}  types.APIResponse  "Internal Server Error"
 // @Router       /files/presigned-urls/views [post]
 func (h *FileHandler) ViewPresignedURLs(c *gin.Context) {
@@ -74,7 +84,7 @@ func (h *FileHandler) ViewPresignedURLs(c *gin.Context) {
 		return
 	}
 
-	presignedURLs, err := h.fileSvc.CreateViewURLs(ctx, req)
+	presignedURLs, err := h.fileSvc.CreateViewURLs(ctx, c.GetInt64("client_id"), c.GetString("client_type"), req)
 	if err != nil {
 		c.Error(err)
 		return
@@ -84,3 +94,66 @@ func (h *FileHandler) ViewPresignedURLs(c *gin.Context) {
 		"presigned_url": presignedURLs,
 	})
 }
+
+// GetObject godoc
+// @Summary      Get Object
+// @Description  Tải file qua signed URL khi storage chạy ở chế độ local
+// @Tags         Files
+// @Produce      octet-stream
+// @Param        key                  path      string  true  "File key"
+// @Param        expires              query     int     true  "Thời điểm hết hạn (unix)"
+// @Param        signature            query     string  true  "Chữ ký"
+// @Success      200                  {file}    file    "Nội dung file"
+// @Failure      403                  {object}  types.APIResponse  "Forbidden (chữ ký không hợp lệ hoặc đã hết hạn)"
+// @Failure      404                  {object}  types.APIResponse  "Not Found"
+// @Failure      500                  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /files/objects/{key} [get]
+func (h *FileHandler) GetObject(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Minute)
+	defer cancel()
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	reader, attrs, err := h.fileSvc.GetObject(ctx, key, c.Request.URL.Query())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer reader.Close()
+
+	contentType := attrs.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.DataFromReader(http.StatusOK, attrs.Size, contentType, reader, nil)
+}
+
+// PutObject godoc
+// @Summary      Put Object
+// @Description  Upload file qua signed URL khi storage chạy ở chế độ local, Content-Type phải khớp với lúc tạo URL
+// @Tags         Files
+// @Accept       octet-stream
+// @Produce      json
+// @Param        key                  path      string  true  "File key"
+// @Param        expires              query     int     true  "Thời điểm hết hạn (unix)"
+// @Param        signature            query     string  true  "Chữ ký"
+// @Success      200                  {object}  types.APIResponse  "Upload file thành công"
+// @Failure      403                  {object}  types.APIResponse  "Forbidden (chữ ký không hợp lệ hoặc đã hết hạn)"
+// @Failure      404                  {object}  types.APIResponse  "Not Found"
+// @Failure      413                  {object}  types.APIResponse  "File quá lớn"
+// @Failure      500                  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /files/objects/{key} [put]
+func (h *FileHandler) PutObject(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Minute)
+	defer cancel()
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	if err := h.fileSvc.PutObject(ctx, key, c.GetHeader("Content-Type"), c.Request.ContentLength, c.Request.URL.Query(), c.Request.Body); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Upload file successfully", nil)
+}
@@ -42,15 +42,12 @@ func (h *NotificationHandler) GetNotificationsForAdmin(c *gin.Context) {
 		return
 	}
 
-	if user.Department == nil {
-		common.ToAPIResponse(c, http.StatusOK, "Get notification list successfully", gin.H{
-			"notifications": []any{},
-			"meta":          &types.MetaResponse{},
-		})
-		return
+	var departmentID *int64
+	if user.Department != nil {
+		departmentID = &user.Department.ID
 	}
 
-	notifications, meta, err := h.notificationSvc.GetNotificationsForAdmin(ctx, query, user.ID, user.Department.ID)
+	notifications, meta, err := h.notificationSvc.GetNotificationsForAdmin(ctx, query, user.ID, departmentID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -78,14 +75,12 @@ func (h *NotificationHandler) CountUnreadNotificationsForAdmin(c *gin.Context) {
 		return
 	}
 
-	if user.Department == nil {
-		common.ToAPIResponse(c, http.StatusOK, "Count unread notification successfully", gin.H{
-			"count": 0,
-		})
-		return
+	var departmentID *int64
+	if user.Department != nil {
+		departmentID = &user.Department.ID
 	}
 
-	count, err := h.notificationSvc.CountUnreadNotificationsForAdmin(ctx, user.ID, user.Department.ID)
+	count, err := h.notificationSvc.CountUnreadNotificationsForAdmin(ctx, user.ID, departmentID)
 	if err != nil {
 		c.Error(err)
 		return
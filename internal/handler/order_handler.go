@@ -200,7 +200,7 @@ func (h *OrderHandler) GetOrderServicesForAdmin(c *gin.Context) {
 		departmentID = &user.Department.ID
 	}
 
-	orderServices, meta, err := h.orderSvc.GetOrderServicesForAdmin(ctx, query, departmentID)
+	orderServices, meta, err := h.orderSvc.GetOrderServicesForAdmin(ctx, query, user.ID, departmentID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -298,6 +298,89 @@ func (h *OrderHandler) UpdateOrderServiceForAdmin(c *gin.Context) {
 	common.ToAPIResponse(c, http.StatusOK, "Order service updated successfully", nil)
 }
 
+func (h *OrderHandler) ClaimOrderService(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	orderServiceIDStr := c.Param("id")
+	orderServiceID, err := strconv.ParseInt(orderServiceIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var departmentID *int64
+	if user.Department == nil {
+		departmentID = nil
+	} else {
+		departmentID = &user.Department.ID
+	}
+
+	if err = h.orderSvc.ClaimOrderService(ctx, orderServiceID, user.ID, departmentID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Order service claimed successfully", nil)
+}
+
+func (h *OrderHandler) AssignOrderService(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	orderServiceIDStr := c.Param("id")
+	orderServiceID, err := strconv.ParseInt(orderServiceIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.AssignOrderServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	var departmentID *int64
+	if user.Department == nil {
+		departmentID = nil
+	} else {
+		departmentID = &user.Department.ID
+	}
+
+	if err = h.orderSvc.AssignOrderService(ctx, orderServiceID, user.ID, departmentID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Order service assigned successfully", nil)
+}
+
 func (h *OrderHandler) GetOrderServicesForGuest(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
@@ -318,3 +401,212 @@ func (h *OrderHandler) GetOrderServicesForGuest(c *gin.Context) {
 		"order_services": common.ToSimpleOrderServicesResponse(orderServices),
 	})
 }
+
+func (h *OrderHandler) CreateServiceOrder(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	orderRoomID := c.GetInt64("order_room_id")
+	if orderRoomID == 0 {
+		c.Error(common.ErrForbidden)
+		return
+	}
+
+	var req types.CreateServiceOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	id, err := h.orderSvc.CreateServiceOrder(ctx, orderRoomID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusCreated, "Service order created successfully", gin.H{
+		"id": id,
+	})
+}
+
+func (h *OrderHandler) GetServiceOrdersForGuest(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	orderRoomID := c.GetInt64("order_room_id")
+	if orderRoomID == 0 {
+		c.Error(common.ErrForbidden)
+		return
+	}
+
+	serviceOrders, err := h.orderSvc.GetServiceOrdersForGuest(ctx, orderRoomID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Get service order list successfully", gin.H{
+		"service_orders": common.ToServiceOrdersResponse(serviceOrders),
+	})
+}
+
+func (h *OrderHandler) UpdateServiceOrderForGuest(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	serviceOrderIDStr := c.Param("id")
+	serviceOrderID, err := strconv.ParseInt(serviceOrderIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	orderRoomID := c.GetInt64("order_room_id")
+	if orderRoomID == 0 {
+		c.Error(common.ErrForbidden)
+		return
+	}
+
+	var req types.UpdateServiceOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err = h.orderSvc.UpdateServiceOrderForGuest(ctx, orderRoomID, serviceOrderID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Service order updated successfully", nil)
+}
+
+func (h *OrderHandler) GetServiceOrdersForAdmin(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var query types.ServiceOrderPaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	var departmentID *int64
+	if user.Department == nil {
+		departmentID = nil
+	} else {
+		departmentID = &user.Department.ID
+	}
+
+	serviceOrders, meta, err := h.orderSvc.GetServiceOrdersForAdmin(ctx, query, departmentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Get service order list successfully", gin.H{
+		"service_orders": common.ToBasicServiceOrdersResponse(serviceOrders),
+		"meta":           meta,
+	})
+}
+
+func (h *OrderHandler) GetServiceOrderByID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	serviceOrderIDStr := c.Param("id")
+	serviceOrderID, err := strconv.ParseInt(serviceOrderIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var departmentID *int64
+	if user.Department == nil {
+		departmentID = nil
+	} else {
+		departmentID = &user.Department.ID
+	}
+
+	serviceOrder, err := h.orderSvc.GetServiceOrderByID(ctx, user.ID, serviceOrderID, departmentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Get service order information successfully", gin.H{
+		"service_order": common.ToServiceOrderResponse(serviceOrder),
+	})
+}
+
+func (h *OrderHandler) UpdateServiceOrderForAdmin(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	serviceOrderIDStr := c.Param("id")
+	serviceOrderID, err := strconv.ParseInt(serviceOrderIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.UpdateServiceOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	var departmentID *int64
+	if user.Department == nil {
+		departmentID = nil
+	} else {
+		departmentID = &user.Department.ID
+	}
+
+	if err = h.orderSvc.UpdateServiceOrderForAdmin(ctx, departmentID, user.ID, serviceOrderID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Service order updated successfully", nil)
+}
@@ -70,7 +70,7 @@ func (h *RequestHandler) GetRequestTypesForGuest(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	requestTypes, err := h.requestSvc.GetRequestTypesForGuest(ctx)
+	requestTypes, err := h.requestSvc.GetRequestTypesForGuest(ctx, common.ParseAcceptLanguage(c.GetHeader("Accept-Language")))
 	if err != nil {
 		c.Error(err)
 		return
@@ -219,6 +219,80 @@ func (h *RequestHandler) GetRequestsForGuest(c *gin.Context) {
 	})
 }
 
+func (h *RequestHandler) CreateRequestSchedule(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	orderRoomID := c.GetInt64("order_room_id")
+	if orderRoomID == 0 {
+		c.Error(common.ErrForbidden)
+		return
+	}
+
+	var req types.CreateRequestScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	id, err := h.requestSvc.CreateRequestSchedule(ctx, orderRoomID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusCreated, "Request schedule created successfully", gin.H{
+		"id": id,
+	})
+}
+
+func (h *RequestHandler) GetRequestSchedulesForGuest(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	orderRoomID := c.GetInt64("order_room_id")
+	if orderRoomID == 0 {
+		c.Error(common.ErrForbidden)
+		return
+	}
+
+	schedules, err := h.requestSvc.GetRequestSchedulesForGuest(ctx, orderRoomID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Get request schedule list successfully", gin.H{
+		"schedules": common.ToRequestSchedulesResponse(schedules),
+	})
+}
+
+func (h *RequestHandler) CancelRequestSchedule(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	scheduleIDStr := c.Param("id")
+	scheduleID, err := strconv.ParseInt(scheduleIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	orderRoomID := c.GetInt64("order_room_id")
+	if orderRoomID == 0 {
+		c.Error(common.ErrForbidden)
+		return
+	}
+
+	if err := h.requestSvc.CancelRequestSchedule(ctx, orderRoomID, scheduleID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Request schedule cancelled successfully", nil)
+}
+
 func (h *RequestHandler) GetRequestByID(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
@@ -290,7 +364,7 @@ func (h *RequestHandler) GetRequestsForAdmin(c *gin.Context) {
 		departmentID = &user.Department.ID
 	}
 
-	requests, meta, err := h.requestSvc.GetRequestsForAdmin(ctx, query, departmentID)
+	requests, meta, err := h.requestSvc.GetRequestsForAdmin(ctx, query, user.ID, departmentID)
 	if err != nil {
 		c.Error(err)
 		return
@@ -346,3 +420,206 @@ func (h *RequestHandler) UpdateRequestForAdmin(c *gin.Context) {
 
 	common.ToAPIResponse(c, http.StatusOK, "Request updated successfully", nil)
 }
+
+func (h *RequestHandler) ClaimRequest(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	requestIDStr := c.Param("id")
+	requestID, err := strconv.ParseInt(requestIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var departmentID *int64
+	if user.Department == nil {
+		departmentID = nil
+	} else {
+		departmentID = &user.Department.ID
+	}
+
+	if err = h.requestSvc.ClaimRequest(ctx, requestID, user.ID, departmentID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Request claimed successfully", nil)
+}
+
+func (h *RequestHandler) AssignRequest(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	requestIDStr := c.Param("id")
+	requestID, err := strconv.ParseInt(requestIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.AssignRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	var departmentID *int64
+	if user.Department == nil {
+		departmentID = nil
+	} else {
+		departmentID = &user.Department.ID
+	}
+
+	if err = h.requestSvc.AssignRequest(ctx, requestID, user.ID, departmentID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Request assigned successfully", nil)
+}
+
+func (h *RequestHandler) CreateRequestNote(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	requestIDStr := c.Param("id")
+	requestID, err := strconv.ParseInt(requestIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.CreateRequestNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	var departmentID *int64
+	if user.Department == nil {
+		departmentID = nil
+	} else {
+		departmentID = &user.Department.ID
+	}
+
+	if err = h.requestSvc.CreateRequestNote(ctx, requestID, user.ID, departmentID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusCreated, "Request note created successfully", nil)
+}
+
+// UpsertRequestTypeTranslation godoc
+// @Summary      Upsert Request type Translation
+// @Description  Thêm hoặc cập nhật bản dịch tên loại yêu cầu
+// @Tags         Requests
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   							  path      int  true  "Request type ID"
+// @Param        locale   						  path      string  true  "Locale (vd: en, ja, zh-cn)"
+// @Param        payload  						  body      types.NameTranslationRequest  true  "Nội dung bản dịch"
+// @Success      200  							  {object}  types.APIResponse  "Lưu bản dịch thành công"
+// @Failure      400  							  {object}  types.APIResponse  "Bad Request"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      404  							  {object}  types.APIResponse  "Request type không tìm thấy"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/request-types/{id}/translations/{locale} [put]
+func (h *RequestHandler) UpsertRequestTypeTranslation(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	requestTypeIDStr := c.Param("id")
+	requestTypeID, err := strconv.ParseInt(requestTypeIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	var req types.NameTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err = h.requestSvc.UpsertRequestTypeTranslation(ctx, requestTypeID, c.Param("locale"), req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Request type translation saved successfully", nil)
+}
+
+// DeleteRequestTypeTranslation godoc
+// @Summary      Delete Request type Translation
+// @Description  Xoá bản dịch theo ngôn ngữ
+// @Tags         Requests
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   							  path      int  true  "Request type ID"
+// @Param        locale   						  path      string  true  "Locale"
+// @Success      200  							  {object}  types.APIResponse  "Xoá bản dịch thành công"
+// @Failure      400  							  {object}  types.APIResponse  "Bad Request"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      404  							  {object}  types.APIResponse  "Bản dịch không tìm thấy"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/request-types/{id}/translations/{locale} [delete]
+func (h *RequestHandler) DeleteRequestTypeTranslation(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	requestTypeIDStr := c.Param("id")
+	requestTypeID, err := strconv.ParseInt(requestTypeIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	if err = h.requestSvc.DeleteRequestTypeTranslation(ctx, requestTypeID, c.Param("locale")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Request type translation deleted successfully", nil)
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/common"
@@ -112,3 +113,147 @@ func (h *ReviewHandler) UpdateMyReview(c *gin.Context) {
 
 	common.ToAPIResponse(c, http.StatusOK, "Review updated successfully", nil)
 }
+
+func (h *ReviewHandler) GetPublicReviews(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var query types.PublicReviewPaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	reviews, meta, err := h.reviewSvc.GetPublicReviews(ctx, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Get review list successfully", gin.H{
+		"reviews": common.ToPublicReviewsResponse(reviews),
+		"meta":    meta,
+	})
+}
+
+func (h *ReviewHandler) RespondReview(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	reviewIDStr := c.Param("id")
+	reviewID, err := strconv.ParseInt(reviewIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.RespondReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err = h.reviewSvc.RespondReview(ctx, reviewID, user.ID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Review response saved successfully", nil)
+}
+
+func (h *ReviewHandler) DeleteReviewResponse(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	reviewIDStr := c.Param("id")
+	reviewID, err := strconv.ParseInt(reviewIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	if err = h.reviewSvc.DeleteReviewResponse(ctx, reviewID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Review response deleted successfully", nil)
+}
+
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	reviewIDStr := c.Param("id")
+	reviewID, err := strconv.ParseInt(reviewIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err = h.reviewSvc.ModerateReview(ctx, reviewID, user.ID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Review moderated successfully", nil)
+}
+
+func (h *ReviewHandler) FeatureReview(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	reviewIDStr := c.Param("id")
+	reviewID, err := strconv.ParseInt(reviewIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	var req types.FeatureReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err = h.reviewSvc.FeatureReview(ctx, reviewID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Review featured status updated successfully", nil)
+}
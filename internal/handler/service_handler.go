@@ -2,6 +2,8 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -367,7 +369,7 @@ func (h *ServiceHandler) GetServiceTypesForGuest(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	serviceTypes, err := h.serviceSvc.GetServiceTypesForGuest(ctx)
+	serviceTypes, err := h.serviceSvc.GetServiceTypesForGuest(ctx, common.ParseAcceptLanguage(c.GetHeader("Accept-Language")))
 	if err != nil {
 		c.Error(err)
 		return
@@ -378,6 +380,59 @@ func (h *ServiceHandler) GetServiceTypesForGuest(c *gin.Context) {
 	})
 }
 
+// AdjustServiceStock godoc
+// @Summary      Adjust Service Stock
+// @Description  Cộng hoặc trừ tồn kho của một dịch vụ
+// @Tags         Services
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   							  path      int  true  "Service ID"
+// @Param        payload  						  body      types.AdjustServiceStockRequest  true  "Số lượng thay đổi"
+// @Success      200  							  {object}  types.APIResponse  "Cập nhật tồn kho thành công"
+// @Failure      400  							  {object}  types.APIResponse  "Bad Request"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      404  							  {object}  types.APIResponse  "Service không tìm thấy"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/services/{id}/stock [patch]
+func (h *ServiceHandler) AdjustServiceStock(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	serviceIDStr := c.Param("id")
+	serviceID, err := strconv.ParseInt(serviceIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.AdjustServiceStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err := h.serviceSvc.AdjustServiceStock(ctx, serviceID, user.ID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Service stock updated successfully", nil)
+}
+
 // DeleteService godoc
 // @Summary      Delete Service
 // @Description  Xoá một dịch vụ bằng ID
@@ -417,7 +472,7 @@ func (h *ServiceHandler) GetServiceTypeBySlugWithServices(c *gin.Context) {
 
 	serviceTypeSlug := c.Param("slug")
 
-	serviceType, err := h.serviceSvc.GetServiceTypeBySlugWithServices(ctx, serviceTypeSlug)
+	serviceType, err := h.serviceSvc.GetServiceTypeBySlugWithServices(ctx, serviceTypeSlug, common.ParseAcceptLanguage(c.GetHeader("Accept-Language")))
 	if err != nil {
 		c.Error(err)
 		return
@@ -434,7 +489,7 @@ func (h *ServiceHandler) GetServiceBySlug(c *gin.Context) {
 
 	serviceSlug := c.Param("slug")
 
-	service, err := h.serviceSvc.GetServiceBySlug(ctx, serviceSlug)
+	service, err := h.serviceSvc.GetServiceBySlug(ctx, serviceSlug, common.ParseAcceptLanguage(c.GetHeader("Accept-Language")))
 	if err != nil {
 		c.Error(err)
 		return
@@ -444,3 +499,429 @@ func (h *ServiceHandler) GetServiceBySlug(c *gin.Context) {
 		"service": common.ToSimpleServiceResponse(service),
 	})
 }
+
+// CreatePricingRule godoc
+// @Summary      Create Pricing Rule
+// @Description  Tạo quy tắc giá (giờ vàng, ngày trong tuần, mã khuyến mãi)
+// @Tags         Services
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        payload  						  body      types.PricingRuleRequest  true  "Thông tin quy tắc giá"
+// @Success      201  							  {object}  types.APIResponse  "Tạo quy tắc giá thành công"
+// @Failure      400  							  {object}  types.APIResponse  "Bad Request"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      409  							  {object}  types.APIResponse  "Mã khuyến mãi đã tồn tại"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/pricing-rules [post]
+func (h *ServiceHandler) CreatePricingRule(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.PricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	id, err := h.serviceSvc.CreatePricingRule(ctx, user.ID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusCreated, "Pricing rule created successfully", gin.H{
+		"id": id,
+	})
+}
+
+// GetPricingRules godoc
+// @Summary      Get Pricing Rules
+// @Description  Lấy tất cả quy tắc giá
+// @Tags         Services
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  							  {object}  types.APIResponse{data=[]types.PricingRuleResponse}  "Lấy quy tắc giá thành công"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/pricing-rules [get]
+func (h *ServiceHandler) GetPricingRules(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	rules, err := h.serviceSvc.GetPricingRules(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Get pricing rules successfully", gin.H{
+		"pricing_rules": common.ToPricingRulesResponse(rules),
+	})
+}
+
+// UpdatePricingRule godoc
+// @Summary      Update Pricing Rule
+// @Description  Cập nhật toàn bộ một quy tắc giá
+// @Tags         Services
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   							  path      int  true  "Pricing Rule ID"
+// @Param        payload  						  body      types.PricingRuleRequest  true  "Thông tin quy tắc giá"
+// @Success      200  							  {object}  types.APIResponse  "Cập nhật quy tắc giá thành công"
+// @Failure      400  							  {object}  types.APIResponse  "Bad Request"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      404  							  {object}  types.APIResponse  "Quy tắc giá không tìm thấy"
+// @Failure      409  							  {object}  types.APIResponse  "Mã khuyến mãi đã tồn tại"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/pricing-rules/{id} [put]
+func (h *ServiceHandler) UpdatePricingRule(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	ruleIDStr := c.Param("id")
+	ruleID, err := strconv.ParseInt(ruleIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var req types.PricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err := h.serviceSvc.UpdatePricingRule(ctx, ruleID, user.ID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Pricing rule updated successfully", nil)
+}
+
+// DeletePricingRule godoc
+// @Summary      Delete Pricing Rule
+// @Description  Xoá một quy tắc giá bằng ID
+// @Tags         Services
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   							  path      int  true  "Pricing Rule ID"
+// @Success      200  							  {object}  types.APIResponse  "Xoá quy tắc giá thành công"
+// @Failure      400  							  {object}  types.APIResponse  "Bad Request (ID không hợp lệ)"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      404  							  {object}  types.APIResponse  "Quy tắc giá không tìm thấy"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/pricing-rules/{id} [delete]
+func (h *ServiceHandler) DeletePricingRule(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	ruleIDStr := c.Param("id")
+	ruleID, err := strconv.ParseInt(ruleIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	if err := h.serviceSvc.DeletePricingRule(ctx, ruleID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Pricing rule deleted successfully", nil)
+}
+
+// UpsertServiceTypeTranslation godoc
+// @Summary      Upsert Service type Translation
+// @Description  Thêm hoặc cập nhật bản dịch tên loại dịch vụ
+// @Tags         Services
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   							  path      int  true  "Service type ID"
+// @Param        locale   						  path      string  true  "Locale (vd: en, ja, zh-cn)"
+// @Param        payload  						  body      types.NameTranslationRequest  true  "Nội dung bản dịch"
+// @Success      200  							  {object}  types.APIResponse  "Lưu bản dịch thành công"
+// @Failure      400  							  {object}  types.APIResponse  "Bad Request"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      404  							  {object}  types.APIResponse  "Service type không tìm thấy"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/service-types/{id}/translations/{locale} [put]
+func (h *ServiceHandler) UpsertServiceTypeTranslation(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	serviceTypeIDStr := c.Param("id")
+	serviceTypeID, err := strconv.ParseInt(serviceTypeIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	var req types.NameTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err = h.serviceSvc.UpsertServiceTypeTranslation(ctx, serviceTypeID, c.Param("locale"), req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Service type translation saved successfully", nil)
+}
+
+// DeleteServiceTypeTranslation godoc
+// @Summary      Delete Service type Translation
+// @Description  Xoá bản dịch theo ngôn ngữ
+// @Tags         Services
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   							  path      int  true  "Service type ID"
+// @Param        locale   						  path      string  true  "Locale"
+// @Success      200  							  {object}  types.APIResponse  "Xoá bản dịch thành công"
+// @Failure      400  							  {object}  types.APIResponse  "Bad Request"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      404  							  {object}  types.APIResponse  "Bản dịch không tìm thấy"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/service-types/{id}/translations/{locale} [delete]
+func (h *ServiceHandler) DeleteServiceTypeTranslation(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	serviceTypeIDStr := c.Param("id")
+	serviceTypeID, err := strconv.ParseInt(serviceTypeIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	if err = h.serviceSvc.DeleteServiceTypeTranslation(ctx, serviceTypeID, c.Param("locale")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Service type translation deleted successfully", nil)
+}
+
+// UpsertServiceTranslation godoc
+// @Summary      Upsert Service Translation
+// @Description  Thêm hoặc cập nhật bản dịch tên và mô tả dịch vụ
+// @Tags         Services
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   							  path      int  true  "Service ID"
+// @Param        locale   						  path      string  true  "Locale (vd: en, ja, zh-cn)"
+// @Param        payload  						  body      types.ServiceTranslationRequest  true  "Nội dung bản dịch"
+// @Success      200  							  {object}  types.APIResponse  "Lưu bản dịch thành công"
+// @Failure      400  							  {object}  types.APIResponse  "Bad Request"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      404  							  {object}  types.APIResponse  "Service không tìm thấy"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/services/{id}/translations/{locale} [put]
+func (h *ServiceHandler) UpsertServiceTranslation(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	serviceIDStr := c.Param("id")
+	serviceID, err := strconv.ParseInt(serviceIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	var req types.ServiceTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	if err = h.serviceSvc.UpsertServiceTranslation(ctx, serviceID, c.Param("locale"), req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Service translation saved successfully", nil)
+}
+
+// DeleteServiceTranslation godoc
+// @Summary      Delete Service Translation
+// @Description  Xoá bản dịch theo ngôn ngữ
+// @Tags         Services
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   							  path      int  true  "Service ID"
+// @Param        locale   						  path      string  true  "Locale"
+// @Success      200  							  {object}  types.APIResponse  "Xoá bản dịch thành công"
+// @Failure      400  							  {object}  types.APIResponse  "Bad Request"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      404  							  {object}  types.APIResponse  "Bản dịch không tìm thấy"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/services/{id}/translations/{locale} [delete]
+func (h *ServiceHandler) DeleteServiceTranslation(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	serviceIDStr := c.Param("id")
+	serviceID, err := strconv.ParseInt(serviceIDStr, 10, 64)
+	if err != nil {
+		c.Error(common.ErrInvalidID)
+		return
+	}
+
+	if err = h.serviceSvc.DeleteServiceTranslation(ctx, serviceID, c.Param("locale")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.ToAPIResponse(c, http.StatusOK, "Service translation deleted successfully", nil)
+}
+
+// ImportServiceCatalogue godoc
+// @Summary      Import Service Catalogue
+// @Description  Nhập loại dịch vụ, dịch vụ, giá và ảnh từ file CSV/XLSX (upsert theo slug, hỗ trợ dry run)
+// @Tags         Services
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        file   						  formData  file  true  "File .csv hoặc .xlsx"
+// @Param        dry_run  						  query     bool  false "Chỉ kiểm tra, không ghi dữ liệu"
+// @Success      200  							  {object}  types.APIResponse{data=object{report=types.CatalogueImportReport}}  "Nhập danh mục thành công"
+// @Failure      400  							  {object}  types.APIResponse{data=object{report=types.CatalogueImportReport}}  "Bad Request"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      413  							  {object}  types.APIResponse  "File quá lớn"
+// @Failure      415  							  {object}  types.APIResponse  "Định dạng file không hỗ trợ"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/services/import [post]
+func (h *ServiceHandler) ImportServiceCatalogue(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		c.Error(common.ErrUnAuth)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		c.Error(common.ErrInvalidUser)
+		return
+	}
+
+	var query types.CatalogueImportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.Error(common.ErrInvalidCatalogueFile)
+		return
+	}
+	if fileHeader.Size > common.MaxCatalogueFileSize {
+		c.Error(common.ErrCatalogueFileTooLarge)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Error(common.ErrInvalidCatalogueFile)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, common.MaxCatalogueFileSize))
+	if err != nil {
+		c.Error(common.ErrInvalidCatalogueFile)
+		return
+	}
+
+	report, err := h.serviceSvc.ImportServiceCatalogue(ctx, user.ID, fileHeader.Filename, data, query.DryRun)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if len(report.Errors) > 0 {
+		common.ToAPIResponse(c, http.StatusBadRequest, "Catalogue has invalid rows", gin.H{
+			"report": report,
+		})
+		return
+	}
+
+	mess := "Catalogue imported successfully"
+	if query.DryRun {
+		mess = "Catalogue validated successfully"
+	}
+	common.ToAPIResponse(c, http.StatusOK, mess, gin.H{
+		"report": report,
+	})
+}
+
+// ExportServiceCatalogue godoc
+// @Summary      Export Service Catalogue
+// @Description  Xuất loại dịch vụ, dịch vụ, giá và ảnh ra file CSV/XLSX để chỉnh sửa và nhập lại
+// @Tags         Services
+// @Produce      octet-stream
+// @Security     ApiKeyAuth
+// @Param        format  						  query     string  false  "csv hoặc xlsx (mặc định csv)"
+// @Success      200  							  {file}    file  "File danh mục dịch vụ"
+// @Failure      400  							  {object}  types.APIResponse  "Bad Request"
+// @Failure      401  							  {object}  types.APIResponse  "Unauthorized"
+// @Failure      500  							  {object}  types.APIResponse  "Internal Server Error"
+// @Router       /admin/services/export [get]
+func (h *ServiceHandler) ExportServiceCatalogue(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	var query types.CatalogueExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		mess := common.HandleValidationError(err)
+		common.ToAPIResponse(c, http.StatusBadRequest, mess, nil)
+		return
+	}
+
+	data, contentType, fileName, err := h.serviceSvc.ExportServiceCatalogue(ctx, query.Format)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+	c.Data(http.StatusOK, contentType, data)
+}
@@ -27,12 +27,6 @@ func NewWSHandler(hub *hub.WSHub) *WSHandler {
 }
 
 func (h *WSHandler) ServeWS(c *gin.Context) {
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
-	if err != nil {
-		c.Error(err)
-		return
-	}
-
 	clientID := c.GetInt64("client_id")
 	clientType := c.GetString("client_type")
 	staffAny, _ := c.Get("staff")
@@ -41,6 +35,27 @@ func (h *WSHandler) ServeWS(c *gin.Context) {
 		return
 	}
 
+	var departmentID *int64
+	if clientType == "staff" {
+		userAny, exists := c.Get("user")
+		if !exists {
+			c.Error(common.ErrUnAuth)
+			return
+		}
+
+		user, ok := userAny.(*types.UserData)
+		if !ok {
+			c.Error(common.ErrInvalidUser)
+			return
+		}
+
+		var err error
+		if departmentID, err = chatDepartmentScope(user); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
 	var staffData *types.StaffData
 	var ok bool
 	if staffAny != nil {
@@ -51,7 +66,13 @@ func (h *WSHandler) ServeWS(c *gin.Context) {
 		}
 	}
 
-	client := hub.NewWSClient(h.hub, conn, clientID, clientType, staffData)
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	client := hub.NewWSClient(h.hub, conn, clientID, clientType, staffData, departmentID)
 	h.hub.Register <- client
 
 	go client.WritePump()
@@ -107,7 +107,7 @@ func (h *SSEHub) SendToClient(clientID int64, event types.SSEEventData) {
 				fmt.Printf("[SSE-DEBUG] Condition Check: Dept != nil? %v | Values Match? %v\n", client.DepartmentID != nil, isDeptMatch)
 			}
 
-			if client.ClientID == clientID && client.DepartmentID != nil && *client.DepartmentID == *event.DepartmentID {
+			if client.ClientID == clientID && client.Type == event.Type && (client.DepartmentID == nil || *client.DepartmentID == *event.DepartmentID) {
 				fmt.Println("[SSE-DEBUG] >>> MATCHED! Attempting to send to channel...")
 				select {
 				case client.Send <- data:
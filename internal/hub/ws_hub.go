@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/common"
@@ -11,6 +12,7 @@ import (
 	"github.com/InstaySystem/is_v1-be/internal/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -20,32 +22,67 @@ const (
 
 	pingPeriod = (pongWait * 9) / 10
 
-	maxMessageSize = 2048
+	maxMessageSize = 8192
+
+	sendMessageTimeout = 30 * time.Second
+
+	eventSendMessage = "send_message"
 
 	eventMarkRead = "mark_read"
 
+	eventMarkDelivered = "mark_delivered"
+
+	eventTyping = "typing"
+
+	eventJoinChat = "join_chat"
+
+	eventLeaveChat = "leave_chat"
+
 	eventNewMessage = "new_message"
 
+	eventPresence = "presence"
+
 	eventError = "error"
 )
 
+var eventLimits = map[string]struct {
+	every time.Duration
+	burst int
+}{
+	eventSendMessage:   {time.Second, 5},
+	eventMarkRead:      {time.Second, 5},
+	eventMarkDelivered: {time.Second, 5},
+	eventTyping:        {2 * time.Second, 2},
+	eventJoinChat:      {time.Second, 5},
+	eventLeaveChat:     {time.Second, 5},
+}
+
 var (
 	newline = []byte{'\n'}
 	space   = []byte{' '}
 )
 
+// WSClient is one socket. For staff, DepartmentID is the chat scope used by
+// the admin chat endpoints: nil means every chat.
 type WSClient struct {
-	Hub         *WSHub
-	Conn        *websocket.Conn
-	Send        chan []byte
-	ID          string
-	ClientID    int64
-	StaffData   *types.StaffData
-	Type        string
-	ActiveChats map[int64]bool
+	Hub          *WSHub
+	Conn         *websocket.Conn
+	Send         chan []byte
+	ID           string
+	ClientID     int64
+	StaffData    *types.StaffData
+	DepartmentID *int64
+	Type         string
+	ActiveChats  map[int64]activeChat
+	limiters     map[string]*rate.Limiter
 }
 
-func NewWSClient(hub *WSHub, conn *websocket.Conn, clientID int64, clientType string, staffData *types.StaffData) *WSClient {
+type activeChat struct {
+	orderRoomID  int64
+	departmentID *int64
+}
+
+func NewWSClient(hub *WSHub, conn *websocket.Conn, clientID int64, clientType string, staffData *types.StaffData, departmentID *int64) *WSClient {
 	return &WSClient{
 		hub,
 		conn,
@@ -53,22 +90,37 @@ func NewWSClient(hub *WSHub, conn *websocket.Conn, clientID int64, clientType st
 		uuid.NewString(),
 		clientID,
 		staffData,
+		departmentID,
 		clientType,
-		make(map[int64]bool),
+		make(map[int64]activeChat),
+		newEventLimiters(),
 	}
 }
 
+func newEventLimiters() map[string]*rate.Limiter {
+	limiters := make(map[string]*rate.Limiter, len(eventLimits))
+	for event, limit := range eventLimits {
+		limiters[event] = rate.NewLimiter(rate.Every(limit.every), limit.burst)
+	}
+
+	return limiters
+}
+
 type WSHub struct {
 	Clients     map[string]map[string]*WSClient
 	Register    chan *WSClient
 	Unregister  chan *WSClient
 	SendMessage chan *MessagePayload
 	ChatSvc     service.ChatService
+	mu          sync.RWMutex
 }
 
+// MessagePayload is delivered to every client under TargetKey. Staff only
+// receive it when their scope covers DepartmentID, the chat's department.
 type MessagePayload struct {
-	TargetKey string
-	Data      []byte
+	TargetKey    string
+	DepartmentID *int64
+	Data         []byte
 }
 
 func NewWSHub(chatSvc service.ChatService) *WSHub {
@@ -78,6 +130,7 @@ func NewWSHub(chatSvc service.ChatService) *WSHub {
 		make(chan *WSClient),
 		make(chan *MessagePayload),
 		chatSvc,
+		sync.RWMutex{},
 	}
 }
 
@@ -106,13 +159,31 @@ func (c *WSClient) ReadPump() {
 			continue
 		}
 
+		limiter, ok := c.limiters[req.Event]
+		if !ok {
+			c.sendError("unknown action")
+			continue
+		}
+		if !limiter.Allow() {
+			if req.Event != eventTyping {
+				c.sendError("too many requests")
+			}
+			continue
+		}
+
 		switch req.Event {
-		case "send_message":
+		case eventSendMessage:
 			c.handleSendMessage(req.Data)
-		case "mark_read":
+		case eventMarkRead:
 			c.handleMarkRead(req.Data)
-		default:
-			c.sendError("unknown action")
+		case eventMarkDelivered:
+			c.handleMarkDelivered(req.Data)
+		case eventTyping:
+			c.handleTyping(req.Data)
+		case eventJoinChat:
+			c.handleJoinChat(req.Data)
+		case eventLeaveChat:
+			c.handleLeaveChat(req.Data)
 		}
 	}
 }
@@ -124,11 +195,15 @@ func (c *WSClient) handleSendMessage(content []byte) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), sendMessageTimeout)
 	defer cancel()
 
-	message, err := c.Hub.ChatSvc.CreateMessage(ctx, req.ChatID, c.ClientID, c.Type, req)
+	message, err := c.Hub.ChatSvc.CreateMessage(ctx, req.ChatID, c.ClientID, c.Type, c.DepartmentID, req)
 	if err != nil {
+		if apiErr, ok := err.(*common.APIError); ok {
+			c.sendError(apiErr.Message)
+			return
+		}
 		c.sendError("send message failed")
 		return
 	}
@@ -138,20 +213,18 @@ func (c *WSClient) handleSendMessage(content []byte) {
 		Data:  common.ToMessageResponse(message),
 	}
 
-	resBytes, _ := json.Marshal(res)
-
 	targets := []string{
 		"staff",
 		fmt.Sprintf("guest_%d", message.Chat.OrderRoomID),
 	}
 
-	for _, t := range targets {
-		msgPayload := &MessagePayload{
-			TargetKey: t,
-			Data:      resBytes,
-		}
+	c.Hub.broadcast(res, message.Chat.DepartmentID, targets...)
 
-		c.Hub.SendMessage <- msgPayload
+	if message.AutoReply != nil {
+		c.Hub.broadcast(types.WSResponse{
+			Event: eventNewMessage,
+			Data:  common.ToMessageResponse(message.AutoReply),
+		}, message.Chat.DepartmentID, targets...)
 	}
 }
 
@@ -165,7 +238,7 @@ func (c *WSClient) handleMarkRead(content []byte) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	chat, err := c.Hub.ChatSvc.UpdateReadMessages(ctx, req.ChatID, c.ClientID, c.Type)
+	chat, err := c.Hub.ChatSvc.UpdateReadMessages(ctx, req.ChatID, c.ClientID, c.Type, c.DepartmentID)
 	if err != nil {
 		c.sendError("read message failed")
 		return
@@ -186,20 +259,141 @@ func (c *WSClient) handleMarkRead(content []byte) {
 		},
 	}
 
-	resBytes, _ := json.Marshal(res)
-	targets := []string{
-		"staff",
-		fmt.Sprintf("guest_%d", chat.OrderRoomID),
+	c.Hub.broadcast(res, chat.DepartmentID, "staff", fmt.Sprintf("guest_%d", chat.OrderRoomID))
+}
+
+func (c *WSClient) handleMarkDelivered(content []byte) {
+	var req types.UpdateDeliveredMessagesRequest
+	if err := json.Unmarshal(content, &req); err != nil {
+		c.sendError("invalid message")
+		return
 	}
 
-	for _, t := range targets {
-		msgPayload := &MessagePayload{
-			TargetKey: t,
-			Data:      resBytes,
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	chat, deliveredAt, err := c.Hub.ChatSvc.UpdateDeliveredMessages(ctx, req.ChatID, c.ClientID, c.Type, c.DepartmentID)
+	if err != nil {
+		c.sendError("deliver message failed")
+		return
+	}
+
+	res := types.WSResponse{
+		Event: eventMarkDelivered,
+		Data: types.UpdateDeliveredMessagesResponse{
+			ChatID:       req.ChatID,
+			ReceiverType: c.Type,
+			DeliveredAt:  deliveredAt,
+		},
+	}
+
+	c.Hub.broadcast(res, chat.DepartmentID, "staff", fmt.Sprintf("guest_%d", chat.OrderRoomID))
+}
+
+func (c *WSClient) handleTyping(content []byte) {
+	var req types.ChatTypingRequest
+	if err := json.Unmarshal(content, &req); err != nil {
+		c.sendError("invalid message")
+		return
+	}
+
+	chat, err := c.resolveChat(req.ChatID)
+	if err != nil {
+		c.sendError("chat not found")
+		return
+	}
+
+	var sender *types.BasicUserResponse
+	if c.Type == "staff" && c.StaffData != nil {
+		sender = (*types.BasicUserResponse)(c.StaffData)
+	}
+
+	res := types.WSResponse{
+		Event: eventTyping,
+		Data: types.ChatTypingResponse{
+			ChatID:     req.ChatID,
+			SenderType: c.Type,
+			Sender:     sender,
+			IsTyping:   req.IsTyping,
+		},
+	}
+
+	targets := []string{"staff"}
+	if c.Type == "staff" {
+		targets = append(targets, fmt.Sprintf("guest_%d", chat.orderRoomID))
+	}
+
+	c.Hub.broadcast(res, chat.departmentID, targets...)
+}
+
+func (c *WSClient) handleJoinChat(content []byte) {
+	var req types.ChatPresenceRequest
+	if err := json.Unmarshal(content, &req); err != nil {
+		c.sendError("invalid message")
+		return
+	}
 
-		c.Hub.SendMessage <- msgPayload
+	chat, err := c.resolveChat(req.ChatID)
+	if err != nil {
+		c.sendError("chat not found")
+		return
 	}
+
+	c.Hub.mu.Lock()
+	c.ActiveChats[req.ChatID] = chat
+	res := c.Hub.presenceLocked(req.ChatID, chat.orderRoomID)
+	c.Hub.mu.Unlock()
+
+	c.Hub.broadcast(res, chat.departmentID, "staff", fmt.Sprintf("guest_%d", chat.orderRoomID))
+}
+
+func (c *WSClient) handleLeaveChat(content []byte) {
+	var req types.ChatPresenceRequest
+	if err := json.Unmarshal(content, &req); err != nil {
+		c.sendError("invalid message")
+		return
+	}
+
+	c.Hub.mu.Lock()
+	chat, ok := c.ActiveChats[req.ChatID]
+	if !ok {
+		c.Hub.mu.Unlock()
+		return
+	}
+	delete(c.ActiveChats, req.ChatID)
+	res := c.Hub.presenceLocked(req.ChatID, chat.orderRoomID)
+	c.Hub.mu.Unlock()
+
+	c.Hub.broadcast(res, chat.departmentID, "staff", fmt.Sprintf("guest_%d", chat.orderRoomID))
+}
+
+func (c *WSClient) resolveChat(chatID int64) (activeChat, error) {
+	c.Hub.mu.RLock()
+	chat, ok := c.ActiveChats[chatID]
+	c.Hub.mu.RUnlock()
+	if ok {
+		return chat, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	found, err := c.Hub.ChatSvc.GetChatForClient(ctx, chatID, c.ClientID, c.Type, c.DepartmentID)
+	if err != nil {
+		return activeChat{}, err
+	}
+
+	return activeChat{found.OrderRoomID, found.DepartmentID}, nil
+}
+
+// canSee reports whether a staff client's chat scope covers a chat in
+// departmentID.
+func (c *WSClient) canSee(departmentID *int64) bool {
+	if c.Type != "staff" || c.DepartmentID == nil {
+		return true
+	}
+
+	return departmentID != nil && *departmentID == *c.DepartmentID
 }
 
 func (c *WSClient) sendError(msg string) {
@@ -256,13 +450,16 @@ func (h *WSHub) Run() {
 	for {
 		select {
 		case client := <-h.Register:
+			h.mu.Lock()
 			key := client.getKey()
 			if _, ok := h.Clients[key]; !ok {
 				h.Clients[key] = make(map[string]*WSClient)
 			}
 			h.Clients[key][client.ID] = client
+			h.mu.Unlock()
 
 		case client := <-h.Unregister:
+			h.mu.Lock()
 			key := client.getKey()
 			if conns, ok := h.Clients[key]; ok {
 				if _, exists := conns[client.ID]; exists {
@@ -271,25 +468,88 @@ func (h *WSHub) Run() {
 					if len(conns) == 0 {
 						delete(h.Clients, key)
 					}
+
+					for chatID, chat := range client.ActiveChats {
+						data, _ := json.Marshal(h.presenceLocked(chatID, chat.orderRoomID))
+						h.deliverLocked(&MessagePayload{"staff", chat.departmentID, data})
+						h.deliverLocked(&MessagePayload{fmt.Sprintf("guest_%d", chat.orderRoomID), chat.departmentID, data})
+					}
 				}
 			}
+			h.mu.Unlock()
 
 		case msg := <-h.SendMessage:
-			if conns, ok := h.Clients[msg.TargetKey]; ok {
-				for _, client := range conns {
-					select {
-					case client.Send <- msg.Data:
-					default:
-						close(client.Send)
-						delete(conns, client.ID)
-					}
-				}
+			h.mu.Lock()
+			h.deliverLocked(msg)
+			h.mu.Unlock()
+		}
+	}
+}
 
-				if len(conns) == 0 {
-					delete(h.Clients, msg.TargetKey)
-				}
-			}
+func (h *WSHub) broadcast(res types.WSResponse, departmentID *int64, targets ...string) {
+	resBytes, _ := json.Marshal(res)
+
+	for _, t := range targets {
+		msgPayload := &MessagePayload{
+			TargetKey:    t,
+			DepartmentID: departmentID,
+			Data:         resBytes,
+		}
+
+		h.SendMessage <- msgPayload
+	}
+}
+
+func (h *WSHub) deliverLocked(msg *MessagePayload) {
+	conns, ok := h.Clients[msg.TargetKey]
+	if !ok {
+		return
+	}
+
+	for _, client := range conns {
+		if !client.canSee(msg.DepartmentID) {
+			continue
 		}
+
+		select {
+		case client.Send <- msg.Data:
+		default:
+			close(client.Send)
+			delete(conns, client.ID)
+		}
+	}
+
+	if len(conns) == 0 {
+		delete(h.Clients, msg.TargetKey)
+	}
+}
+
+func (h *WSHub) presenceLocked(chatID, orderRoomID int64) types.WSResponse {
+	viewing := make([]*types.BasicUserResponse, 0)
+	seenViewing := make(map[int64]bool)
+	online := make(map[int64]bool)
+
+	for _, client := range h.Clients["staff"] {
+		online[client.ClientID] = true
+
+		if _, ok := client.ActiveChats[chatID]; !ok || seenViewing[client.ClientID] || client.StaffData == nil {
+			continue
+		}
+
+		seenViewing[client.ClientID] = true
+		viewing = append(viewing, (*types.BasicUserResponse)(client.StaffData))
+	}
+
+	guestOnline := len(h.Clients[fmt.Sprintf("guest_%d", orderRoomID)]) > 0
+
+	return types.WSResponse{
+		Event: eventPresence,
+		Data: types.ChatPresenceResponse{
+			ChatID:        chatID,
+			ViewingStaffs: viewing,
+			OnlineStaffs:  len(online),
+			GuestOnline:   guestOnline,
+		},
 	}
 }
 
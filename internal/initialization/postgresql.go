@@ -3,6 +3,7 @@ package initialization
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/model"
@@ -10,27 +11,94 @@ import (
 	"gorm.io/gorm"
 )
 
+// staleCheckConstraints lists check constraints whose definition changed after
+// the table was first created. AutoMigrate never alters an existing constraint,
+// so the stale one is dropped and recreated from the model tag.
+var staleCheckConstraints = []struct {
+	Table    string
+	Name     string
+	Contains string
+}{
+	{"messages", "chk_messages_sender_type", "'system'"},
+	{"notifications", "chk_notifications_type", "'stock'"},
+}
+
+// dataBackfills bring rows written before a schema change in line with it.
+// They run after every AutoMigrate, so each statement must be idempotent.
+var dataBackfills = []string{
+	// Service-order notifications used to share the "service" type.
+	`UPDATE notifications SET type = 'service_order'
+	WHERE type = 'service' AND content_id IN (SELECT id FROM service_orders)`,
+	// Low-stock alerts pointed at the order service that triggered them.
+	`UPDATE notifications SET type = 'stock', content_id = order_services.service_id
+	FROM order_services
+	WHERE notifications.type = 'service' AND notifications.content_id = order_services.id
+	AND (notifications.content LIKE '%sắp hết hàng, còn lại%' OR notifications.content LIKE '%đã hết hàng')`,
+	// Order services booked before list prices were recorded were charged
+	// the list price, so their total doubles as it.
+	`UPDATE order_services SET list_price = total_price
+	WHERE list_price = 0 AND total_price <> 0 AND pricing_rule_id IS NULL`,
+	// Messages carried a single image in messages.image_key before they had
+	// attachments. Move those images over, then drop the column.
+	`DO $$
+	BEGIN
+		IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'messages' AND column_name = 'image_key') THEN
+			INSERT INTO message_attachments (id, message_id, key, file_name, content_type, size, sort_order)
+			SELECT id, id, image_key, regexp_replace(image_key, '^.*/', ''),
+				CASE
+					WHEN lower(image_key) LIKE '%.png' THEN 'image/png'
+					WHEN lower(image_key) LIKE '%.webp' THEN 'image/webp'
+					ELSE 'image/jpeg'
+				END,
+				0, 1
+			FROM messages
+			WHERE image_key IS NOT NULL
+			ON CONFLICT DO NOTHING;
+			ALTER TABLE messages DROP COLUMN image_key;
+		END IF;
+	END $$`,
+}
+
 var allModels = []any{
 	&model.User{},
 	&model.Department{},
 	&model.ServiceType{},
+	&model.ServiceTypeTranslation{},
 	&model.Service{},
+	&model.ServiceTranslation{},
 	&model.ServiceImage{},
+	&model.ServiceImageVariant{},
+	&model.ServiceOpeningHour{},
+	&model.ServiceSlot{},
+	&model.ServiceOptionGroup{},
+	&model.ServiceOption{},
+	&model.PricingRule{},
 	&model.RequestType{},
+	&model.RequestTypeTranslation{},
 	&model.Request{},
+	&model.RequestAttachment{},
+	&model.RequestNote{},
+	&model.RequestSchedule{},
 	&model.RoomType{},
 	&model.Floor{},
 	&model.Room{},
 	&model.Source{},
 	&model.Booking{},
 	&model.OrderRoom{},
+	&model.ServiceOrder{},
 	&model.OrderService{},
+	&model.OrderServiceOption{},
 	&model.Notification{},
 	&model.NotificationStaff{},
 	&model.Chat{},
 	&model.Message{},
 	&model.MessageStaff{},
+	&model.MessageAttachment{},
+	&model.ChatTransfer{},
+	&model.CannedResponse{},
+	&model.FAQ{},
 	&model.Review{},
+	&model.Upload{},
 }
 
 type DB struct {
@@ -72,5 +140,55 @@ func (d *DB) Close() {
 }
 
 func runAutoMigrations(db *gorm.DB) error {
-	return db.AutoMigrate(allModels...)
+	if err := ensureTextSearchConfigs(db); err != nil {
+		return err
+	}
+
+	if err := dropStaleCheckConstraints(db); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(allModels...); err != nil {
+		return err
+	}
+
+	return runDataBackfills(db)
+}
+
+func runDataBackfills(db *gorm.DB) error {
+	for _, stmt := range dataBackfills {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func dropStaleCheckConstraints(db *gorm.DB) error {
+	for _, c := range staleCheckConstraints {
+		var definition string
+		if err := db.Raw("SELECT pg_get_constraintdef(oid) FROM pg_constraint WHERE conname = ?", c.Name).Scan(&definition).Error; err != nil {
+			return err
+		}
+		if definition == "" || strings.Contains(definition, c.Contains) {
+			continue
+		}
+
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", c.Table, c.Name)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ensureTextSearchConfigs(db *gorm.DB) error {
+	return db.Exec(`DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'vietnamese') THEN
+		CREATE TEXT SEARCH CONFIGURATION vietnamese (COPY = simple);
+	END IF;
+END
+$$`).Error
 }
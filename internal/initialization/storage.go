@@ -0,0 +1,34 @@
+package initialization
+
+import (
+	"fmt"
+
+	"github.com/InstaySystem/is_v1-be/internal/config"
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
+)
+
+func InitStorage(cfg *config.Config) (storage.StorageProvider, error) {
+	switch cfg.Storage.Driver {
+	case "", "gcs":
+		client, err := InitGCS(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return storage.NewGCSStorageProvider(client, cfg.GCS.Bucket), nil
+	case "s3":
+		return storage.NewS3StorageProvider(
+			cfg.S3.Endpoint,
+			cfg.S3.PublicEndpoint,
+			cfg.S3.Region,
+			cfg.S3.Bucket,
+			cfg.S3.AccessKeyID,
+			cfg.S3.SecretAccessKey,
+			cfg.S3.UsePathStyle,
+			cfg.S3.Timeout,
+		)
+	case "local":
+		return storage.NewLocalStorageProvider(cfg.LocalStorage.Root, cfg.LocalStorage.BaseURL, cfg.LocalStorage.SecretKey)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Storage.Driver)
+	}
+}
@@ -315,6 +315,7 @@ func (m *AuthMiddleware) IsGuestOrStaffHasDepartment(department *string) gin.Han
 					c.Set("department_id", nil)
 				}
 				c.Set("staff", common.ToStaffData(user))
+				c.Set("user", common.ToUserData(user))
 				c.Next()
 				return
 			}
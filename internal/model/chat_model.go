@@ -3,29 +3,79 @@ package model
 import "time"
 
 type Chat struct {
-	ID            int64      `gorm:"type:bigint;primaryKey" json:"id"`
-	OrderRoomID   int64      `gorm:"type:bigint;not null;uniqueIndex:chats_order_room_id_key" json:"order_room_id"`
-	ExpiredAt     time.Time  `json:"expired_at"`
-	LastMessageAt *time.Time `gorm:"index:chats_last_message_at_idx" json:"last_message_at"`
+	ID               int64      `gorm:"type:bigint;primaryKey" json:"id"`
+	OrderRoomID      int64      `gorm:"type:bigint;not null;uniqueIndex:chats_order_room_id_key" json:"order_room_id"`
+	ExpiredAt        time.Time  `json:"expired_at"`
+	LastMessageAt    *time.Time `gorm:"index:chats_last_message_at_idx" json:"last_message_at"`
+	AssigneeID       *int64     `gorm:"type:bigint;index:chats_assignee_id_idx" json:"assignee_id"`
+	AssignedAt       *time.Time `json:"assigned_at"`
+	DepartmentID     *int64     `gorm:"type:bigint;index:chats_department_id_idx" json:"department_id"`
+	TranscriptSentAt *time.Time `json:"transcript_sent_at"`
+	ArchivedAt       *time.Time `gorm:"index:chats_archived_at_idx" json:"archived_at"`
+	ArchiveKey       *string    `gorm:"type:varchar(150)" json:"archive_key"`
+	GuestLanguage    *string    `gorm:"type:varchar(10)" json:"guest_language"`
+	EscalatedAt      *time.Time `json:"escalated_at"`
 
-	OrderRoom *OrderRoom `gorm:"foreignKey:OrderRoomID;references:ID;constraint:fk_chats_order_room,OnUpdate:CASCADE,OnDelete:CASCADE" json:"order_room"`
-	Messages  []*Message `gorm:"foreignKey:ChatID;references:ID;constraint:fk_messages_chat,OnUpdate:CASCADE,OnDelete:CASCADE" json:"messages"`
+	OrderRoom  *OrderRoom      `gorm:"foreignKey:OrderRoomID;references:ID;constraint:fk_chats_order_room,OnUpdate:CASCADE,OnDelete:CASCADE" json:"order_room"`
+	Messages   []*Message      `gorm:"foreignKey:ChatID;references:ID;constraint:fk_messages_chat,OnUpdate:CASCADE,OnDelete:CASCADE" json:"messages"`
+	Assignee   *User           `gorm:"foreignKey:AssigneeID;references:ID;constraint:fk_chats_assignee,OnUpdate:CASCADE,OnDelete:SET NULL" json:"assignee"`
+	Department *Department     `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_chats_department,OnUpdate:CASCADE,OnDelete:SET NULL" json:"department"`
+	Transfers  []*ChatTransfer `gorm:"foreignKey:ChatID;references:ID;constraint:fk_chat_transfers_chat,OnUpdate:CASCADE,OnDelete:CASCADE" json:"transfers"`
+}
+
+type ChatTransfer struct {
+	ID             int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	ChatID         int64     `gorm:"type:bigint;not null;index:chat_transfers_chat_id_idx" json:"chat_id"`
+	Action         string    `gorm:"type:varchar(20);not null;check:action IN ('assign', 'claim', 'transfer')" json:"action"`
+	FromStaffID    *int64    `gorm:"type:bigint" json:"from_staff_id"`
+	ToStaffID      *int64    `gorm:"type:bigint" json:"to_staff_id"`
+	ToDepartmentID *int64    `gorm:"type:bigint" json:"to_department_id"`
+	Note           *string   `gorm:"type:text" json:"note"`
+	CreatedByID    int64     `gorm:"type:bigint;not null" json:"created_by_id"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	Chat         *Chat       `gorm:"foreignKey:ChatID;references:ID;constraint:fk_chat_transfers_chat,OnUpdate:CASCADE,OnDelete:CASCADE" json:"chat"`
+	FromStaff    *User       `gorm:"foreignKey:FromStaffID;references:ID;constraint:fk_chat_transfers_from_staff,OnUpdate:CASCADE,OnDelete:SET NULL" json:"from_staff"`
+	ToStaff      *User       `gorm:"foreignKey:ToStaffID;references:ID;constraint:fk_chat_transfers_to_staff,OnUpdate:CASCADE,OnDelete:SET NULL" json:"to_staff"`
+	ToDepartment *Department `gorm:"foreignKey:ToDepartmentID;references:ID;constraint:fk_chat_transfers_to_department,OnUpdate:CASCADE,OnDelete:SET NULL" json:"to_department"`
+	CreatedBy    *User       `gorm:"foreignKey:CreatedByID;references:ID;constraint:fk_chat_transfers_created_by,OnUpdate:CASCADE,OnDelete:CASCADE" json:"created_by"`
 }
 
 type Message struct {
-	ID         int64      `gorm:"type:bigint;primaryKey" json:"id"`
-	ChatID     int64      `gorm:"type:bigint;not null" json:"chat_id"`
-	SenderType string     `gorm:"type:varchar(20);not null;check:sender_type IN ('guest', 'staff')" json:"sender_type"`
-	SenderID   *int64     `gorm:"type:bigint" json:"sender_id"`
-	ImageKey   *string    `gorm:"type:varchar(150);uniqueIndex:messages_image_key_key" json:"image_key"`
-	Content    *string    `gorm:"type:text" json:"content"`
-	CreatedAt  time.Time  `json:"created_at"`
-	IsRead     bool       `gorm:"type:boolean" json:"is_read"`
-	ReadAt     *time.Time `json:"read_at"`
-
-	Chat       *Chat           `gorm:"foreignKey:ChatID;references:ID;constraint:fk_messages_chat,OnUpdate:CASCADE,OnDelete:CASCADE" json:"chat"`
-	Sender     *User           `gorm:"foreignKey:SenderID;references:ID;constraint:fk_messages_sender,OnUpdate:CASCADE,OnDelete:CASCADE" json:"sender"`
-	StaffsRead []*MessageStaff `gorm:"foreignKey:MessageID;references:ID;constraint:fk_message_staffs_message,OnUpdate:CASCADE,OnDelete:CASCADE" json:"staffs_read"`
+	ID                 int64      `gorm:"type:bigint;primaryKey" json:"id"`
+	ChatID             int64      `gorm:"type:bigint;not null" json:"chat_id"`
+	SenderType         string     `gorm:"type:varchar(20);not null;check:sender_type IN ('guest', 'staff', 'system')" json:"sender_type"`
+	SenderID           *int64     `gorm:"type:bigint" json:"sender_id"`
+	Content            *string    `gorm:"type:text" json:"content"`
+	Language           *string    `gorm:"type:varchar(10)" json:"language"`
+	TranslatedContent  *string    `gorm:"type:text" json:"translated_content"`
+	TranslatedLanguage *string    `gorm:"type:varchar(10)" json:"translated_language"`
+	CreatedAt          time.Time  `json:"created_at"`
+	DeliveredAt        *time.Time `json:"delivered_at"`
+	IsRead             bool       `gorm:"type:boolean" json:"is_read"`
+	ReadAt             *time.Time `json:"read_at"`
+	SearchVector       string     `gorm:"->:false;type:tsvector GENERATED ALWAYS AS (to_tsvector('vietnamese', coalesce(content, '')) || to_tsvector('english', coalesce(content, ''))) STORED;index:messages_search_vector_idx,type:gin" json:"-"`
+
+	Chat        *Chat                `gorm:"foreignKey:ChatID;references:ID;constraint:fk_messages_chat,OnUpdate:CASCADE,OnDelete:CASCADE" json:"chat"`
+	Sender      *User                `gorm:"foreignKey:SenderID;references:ID;constraint:fk_messages_sender,OnUpdate:CASCADE,OnDelete:CASCADE" json:"sender"`
+	StaffsRead  []*MessageStaff      `gorm:"foreignKey:MessageID;references:ID;constraint:fk_message_staffs_message,OnUpdate:CASCADE,OnDelete:CASCADE" json:"staffs_read"`
+	Attachments []*MessageAttachment `gorm:"foreignKey:MessageID;references:ID;constraint:fk_message_attachments_message,OnUpdate:CASCADE,OnDelete:CASCADE" json:"attachments"`
+	AutoReply   *Message             `gorm:"-" json:"-"`
+}
+
+type MessageAttachment struct {
+	ID           int64   `gorm:"type:bigint;primaryKey" json:"id"`
+	MessageID    int64   `gorm:"type:bigint;not null;index:message_attachments_message_id_idx" json:"message_id"`
+	Key          string  `gorm:"type:varchar(150);uniqueIndex:message_attachments_key_key;not null" json:"key"`
+	FileName     string  `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType  string  `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size         int64   `gorm:"type:bigint;not null" json:"size"`
+	ThumbnailKey *string `gorm:"type:varchar(150)" json:"thumbnail_key"`
+	SortOrder    uint32  `gorm:"type:integer;not null" json:"sort_order"`
+
+	Message      *Message `gorm:"foreignKey:MessageID;references:ID;constraint:fk_message_attachments_message,OnUpdate:CASCADE,OnDelete:CASCADE" json:"message"`
+	URL          string   `gorm:"-" json:"url"`
+	ThumbnailURL string   `gorm:"-" json:"thumbnail_url"`
 }
 
 type MessageStaff struct {
@@ -37,3 +87,34 @@ type MessageStaff struct {
 	Message *Message `gorm:"foreignKey:MessageID;references:ID;constraint:fk_message_staffs_message,OnUpdate:CASCADE,OnDelete:CASCADE" json:"message"`
 	Staff   *User    `gorm:"foreignKey:StaffID;references:ID;constraint:fk_message_staffs_staff,OnUpdate:CASCADE,OnDelete:CASCADE" json:"staff"`
 }
+
+type CannedResponse struct {
+	ID           int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	Title        string    `gorm:"type:varchar(150);not null" json:"title"`
+	Shortcut     string    `gorm:"type:varchar(50);not null;uniqueIndex:canned_responses_department_id_shortcut_key" json:"shortcut"`
+	Content      string    `gorm:"type:text;not null" json:"content"`
+	DepartmentID int64     `gorm:"type:bigint;not null;uniqueIndex:canned_responses_department_id_shortcut_key" json:"department_id"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedByID  int64     `gorm:"type:bigint;not null" json:"created_by_id"`
+	UpdatedByID  int64     `gorm:"type:bigint;not null" json:"updated_by_id"`
+
+	Department *Department `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_canned_responses_department,OnUpdate:CASCADE,OnDelete:CASCADE" json:"department"`
+	CreatedBy  *User       `gorm:"foreignKey:CreatedByID;references:ID;constraint:fk_canned_responses_created_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"created_by"`
+	UpdatedBy  *User       `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_canned_responses_updated_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
+}
+
+type FAQ struct {
+	ID          int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	Question    string    `gorm:"type:varchar(255);not null" json:"question"`
+	Answer      string    `gorm:"type:text;not null" json:"answer"`
+	Keywords    string    `gorm:"type:text;not null" json:"keywords"`
+	IsActive    bool      `gorm:"type:boolean;not null;default:true" json:"is_active"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedByID int64     `gorm:"type:bigint;not null" json:"created_by_id"`
+	UpdatedByID int64     `gorm:"type:bigint;not null" json:"updated_by_id"`
+
+	CreatedBy *User `gorm:"foreignKey:CreatedByID;references:ID;constraint:fk_faqs_created_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"created_by"`
+	UpdatedBy *User `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_faqs_updated_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
+}
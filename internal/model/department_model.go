@@ -11,12 +11,16 @@ type Department struct {
 	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 	CreatedByID *int64    `gorm:"type:bigint" json:"created_by_id"`
 	UpdatedByID *int64    `gorm:"type:bigint" json:"updated_by_id"`
+	HeadID      *int64    `gorm:"type:bigint" json:"head_id"`
 
-	Staffs        []*User         `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_users_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"staffs"`
-	ServiceTypes  []*ServiceType  `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_service_types_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"service_types"`
-	RequestTypes  []*RequestType  `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_request_types_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"request_types"`
-	Notifications []*Notification `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_notifications_department,OnUpdate:CASCADE,OnDelete:CASCADE" json:"notifications"`
-	CreatedBy     *User           `gorm:"foreignKey:CreatedByID;references:ID;constraint:-" json:"created_by"`
-	UpdatedBy     *User           `gorm:"foreignKey:UpdatedByID;references:ID;constraint:-" json:"updated_by"`
-	StaffCount    int64           `gorm:"-" json:"staff_count"`
+	Staffs          []*User           `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_users_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"staffs"`
+	ServiceTypes    []*ServiceType    `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_service_types_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"service_types"`
+	RequestTypes    []*RequestType    `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_request_types_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"request_types"`
+	Notifications   []*Notification   `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_notifications_department,OnUpdate:CASCADE,OnDelete:CASCADE" json:"notifications"`
+	Chats           []*Chat           `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_chats_department,OnUpdate:CASCADE,OnDelete:SET NULL" json:"chats"`
+	CannedResponses []*CannedResponse `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_canned_responses_department,OnUpdate:CASCADE,OnDelete:CASCADE" json:"canned_responses"`
+	Head            *User             `gorm:"foreignKey:HeadID;references:ID;constraint:fk_departments_head,OnUpdate:CASCADE,OnDelete:SET NULL" json:"head"`
+	CreatedBy       *User             `gorm:"foreignKey:CreatedByID;references:ID;constraint:-" json:"created_by"`
+	UpdatedBy       *User             `gorm:"foreignKey:UpdatedByID;references:ID;constraint:-" json:"updated_by"`
+	StaffCount      int64             `gorm:"-" json:"staff_count"`
 }
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type Upload struct {
+	ID          int64      `gorm:"type:bigint;primaryKey" json:"id"`
+	Key         string     `gorm:"type:varchar(150);uniqueIndex:uploads_key_key;not null" json:"key"`
+	Purpose     string     `gorm:"type:varchar(30);not null;index:uploads_owner_purpose_created_at_idx,priority:3" json:"purpose"`
+	OwnerType   string     `gorm:"type:varchar(10);not null;check:owner_type IN ('staff', 'guest');index:uploads_owner_purpose_created_at_idx,priority:1" json:"owner_type"`
+	OwnerID     int64      `gorm:"type:bigint;not null;index:uploads_owner_purpose_created_at_idx,priority:2" json:"owner_id"`
+	ContentType string     `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size        int64      `gorm:"type:bigint;not null" json:"size"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'pending';check:status IN ('pending', 'confirmed');index:uploads_status_created_at_idx,priority:1" json:"status"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime;index:uploads_status_created_at_idx,priority:2;index:uploads_owner_purpose_created_at_idx,priority:4" json:"created_at"`
+	ConfirmedAt *time.Time `json:"confirmed_at"`
+}
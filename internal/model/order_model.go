@@ -23,21 +23,66 @@ type OrderRoom struct {
 }
 
 type OrderService struct {
-	ID           int64     `gorm:"type:bigint;primaryKey" json:"id"`
-	OrderRoomID  int64     `gorm:"type:bigint;not null" json:"order_room_id"`
-	ServiceID    int64     `gorm:"type:bigint;not null" json:"service_id"`
-	Quantity     uint32    `gorm:"type:integer;not null" json:"quantity"`
-	TotalPrice   float64   `gorm:"type:decimal(10,2);not null" json:"total_price"`
-	Status       string    `gorm:"type:varchar(20);check:status IN ('pending', 'accepted', 'rejected', 'cancelled')" json:"status"`
-	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	GuestNote    *string   `gorm:"type:text" json:"guest_note"`
-	StaffNote    *string   `gorm:"type:text" json:"staff_note"`
-	CancelReason *string   `gorm:"type:text" json:"cancel_reason"`
-	RejectReason *string   `gorm:"type:text" json:"reject_reason"`
-	UpdatedByID  *int64    `gorm:"type:bigint" json:"updated_by_id"`
-
-	Service   *Service   `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_order_services_service,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"service"`
-	OrderRoom *OrderRoom `gorm:"foreignKey:OrderRoomID;references:ID;constraint:fk_order_services_order_room,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"order_room"`
-	UpdatedBy *User      `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_order_services_updated_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
+	ID              int64      `gorm:"type:bigint;primaryKey" json:"id"`
+	OrderRoomID     int64      `gorm:"type:bigint;not null" json:"order_room_id"`
+	ServiceID       int64      `gorm:"type:bigint;not null" json:"service_id"`
+	Quantity        uint32     `gorm:"type:integer;not null" json:"quantity"`
+	TotalPrice      float64    `gorm:"type:decimal(10,2);not null" json:"total_price"`
+	Status          string     `gorm:"type:varchar(20);check:status IN ('pending', 'accepted', 'rejected', 'cancelled')" json:"status"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	GuestNote       *string    `gorm:"type:text" json:"guest_note"`
+	StaffNote       *string    `gorm:"type:text" json:"staff_note"`
+	CancelReason    *string    `gorm:"type:text" json:"cancel_reason"`
+	RejectReason    *string    `gorm:"type:text" json:"reject_reason"`
+	UpdatedByID     *int64     `gorm:"type:bigint" json:"updated_by_id"`
+	AssigneeID      *int64     `gorm:"type:bigint;index:order_services_assignee_id_idx" json:"assignee_id"`
+	AssignedAt      *time.Time `json:"assigned_at"`
+	SlotStartAt     *time.Time `json:"slot_start_at"`
+	ServiceOrderID  *int64     `gorm:"type:bigint;index:order_services_service_order_id_idx" json:"service_order_id"`
+	ListPrice       float64    `gorm:"type:decimal(10,2);not null;default:0" json:"list_price"`
+	PricingRuleID   *int64     `gorm:"type:bigint" json:"pricing_rule_id"`
+	PricingRuleName *string    `gorm:"type:varchar(150)" json:"pricing_rule_name"`
+	PromoCode       *string    `gorm:"type:varchar(50)" json:"promo_code"`
+
+	Service      *Service              `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_order_services_service,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"service"`
+	OrderRoom    *OrderRoom            `gorm:"foreignKey:OrderRoomID;references:ID;constraint:fk_order_services_order_room,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"order_room"`
+	UpdatedBy    *User                 `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_order_services_updated_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
+	Assignee     *User                 `gorm:"foreignKey:AssigneeID;references:ID;constraint:fk_order_services_assignee,OnUpdate:CASCADE,OnDelete:SET NULL" json:"assignee"`
+	ServiceOrder *ServiceOrder         `gorm:"foreignKey:ServiceOrderID;references:ID;constraint:fk_order_services_service_order,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"service_order"`
+	Options      []*OrderServiceOption `gorm:"foreignKey:OrderServiceID;references:ID;constraint:fk_order_service_options_order_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"options"`
+	PricingRule  *PricingRule          `gorm:"foreignKey:PricingRuleID;references:ID;constraint:fk_order_services_pricing_rule,OnUpdate:CASCADE,OnDelete:SET NULL" json:"pricing_rule"`
+}
+
+type OrderServiceOption struct {
+	ID             int64   `gorm:"type:bigint;primaryKey" json:"id"`
+	OrderServiceID int64   `gorm:"type:bigint;not null;index:order_service_options_order_service_id_idx" json:"order_service_id"`
+	OptionID       *int64  `gorm:"type:bigint" json:"option_id"`
+	GroupName      string  `gorm:"type:varchar(150);not null" json:"group_name"`
+	OptionName     string  `gorm:"type:varchar(150);not null" json:"option_name"`
+	PriceDelta     float64 `gorm:"type:decimal(10,2);not null" json:"price_delta"`
+
+	OrderService *OrderService  `gorm:"foreignKey:OrderServiceID;references:ID;constraint:fk_order_service_options_order_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"order_service"`
+	Option       *ServiceOption `gorm:"foreignKey:OptionID;references:ID;constraint:fk_order_service_options_option,OnUpdate:CASCADE,OnDelete:SET NULL" json:"option"`
+}
+
+type ServiceOrder struct {
+	ID           int64      `gorm:"type:bigint;primaryKey" json:"id"`
+	OrderRoomID  int64      `gorm:"type:bigint;not null;index:service_orders_order_room_id_idx" json:"order_room_id"`
+	DepartmentID int64      `gorm:"type:bigint;not null;index:service_orders_department_id_idx" json:"department_id"`
+	Status       string     `gorm:"type:varchar(20);not null;check:status IN ('pending', 'accepted', 'rejected', 'cancelled')" json:"status"`
+	TotalPrice   float64    `gorm:"type:decimal(10,2);not null" json:"total_price"`
+	GuestNote    *string    `gorm:"type:text" json:"guest_note"`
+	DeliveryAt   *time.Time `json:"delivery_at"`
+	StaffNote    *string    `gorm:"type:text" json:"staff_note"`
+	CancelReason *string    `gorm:"type:text" json:"cancel_reason"`
+	RejectReason *string    `gorm:"type:text" json:"reject_reason"`
+	UpdatedByID  *int64     `gorm:"type:bigint" json:"updated_by_id"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	OrderRoom  *OrderRoom      `gorm:"foreignKey:OrderRoomID;references:ID;constraint:fk_service_orders_order_room,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"order_room"`
+	Department *Department     `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_service_orders_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"department"`
+	UpdatedBy  *User           `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_service_orders_updated_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
+	Lines      []*OrderService `gorm:"foreignKey:ServiceOrderID;references:ID;constraint:fk_order_services_service_order,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"lines"`
 }
@@ -3,32 +3,102 @@ package model
 import "time"
 
 type RequestType struct {
-	ID           int64     `gorm:"type:bigint;primaryKey" json:"id"`
-	Name         string    `gorm:"type:varchar(150);not null" json:"name"`
-	Slug         string    `gorm:"type:varchar(150);uniqueIndex:request_types_slug_key;not null" json:"slug"`
-	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	CreatedByID  int64     `gorm:"type:bigint;not null" json:"created_by_id"`
-	UpdatedByID  int64     `gorm:"type:bigint;not null" json:"updated_by_id"`
-	DepartmentID int64     `gorm:"type:bigint;not null" json:"department_id"`
-
-	Department *Department `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_request_types_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"department"`
-	CreatedBy  *User       `gorm:"foreignKey:CreatedByID;references:ID;constraint:fk_request_types_created_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"created_by"`
-	UpdatedBy  *User       `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_request_types_updated_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
-	Requests   []*Request  `gorm:"foreignKey:RequestTypeID;references:ID;constraint:fk_requests_request_type,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"requests"`
+	ID                 int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	Name               string    `gorm:"type:varchar(150);not null" json:"name"`
+	Slug               string    `gorm:"type:varchar(150);uniqueIndex:request_types_slug_key;not null" json:"slug"`
+	CreatedAt          time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedByID        int64     `gorm:"type:bigint;not null" json:"created_by_id"`
+	UpdatedByID        int64     `gorm:"type:bigint;not null" json:"updated_by_id"`
+	DepartmentID       int64     `gorm:"type:bigint;not null" json:"department_id"`
+	AcceptSLAMinutes   *uint32   `gorm:"type:integer" json:"accept_sla_minutes"`
+	CompleteSLAMinutes *uint32   `gorm:"type:integer" json:"complete_sla_minutes"`
+
+	Department   *Department               `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_request_types_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"department"`
+	CreatedBy    *User                     `gorm:"foreignKey:CreatedByID;references:ID;constraint:fk_request_types_created_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"created_by"`
+	UpdatedBy    *User                     `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_request_types_updated_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
+	Requests     []*Request                `gorm:"foreignKey:RequestTypeID;references:ID;constraint:fk_requests_request_type,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"requests"`
+	Translations []*RequestTypeTranslation `gorm:"foreignKey:RequestTypeID;references:ID;constraint:fk_request_type_translations_request_type,OnUpdate:CASCADE,OnDelete:CASCADE" json:"translations"`
+}
+
+type RequestTypeTranslation struct {
+	ID            int64  `gorm:"type:bigint;primaryKey" json:"id"`
+	RequestTypeID int64  `gorm:"type:bigint;not null;uniqueIndex:request_type_translations_request_type_id_locale_key,priority:1" json:"request_type_id"`
+	Locale        string `gorm:"type:varchar(20);not null;uniqueIndex:request_type_translations_request_type_id_locale_key,priority:2" json:"locale"`
+	Name          string `gorm:"type:varchar(150);not null" json:"name"`
+
+	RequestType *RequestType `gorm:"foreignKey:RequestTypeID;references:ID;constraint:fk_request_type_translations_request_type,OnUpdate:CASCADE,OnDelete:CASCADE" json:"request_type"`
 }
 
 type Request struct {
-	ID            int64     `gorm:"type:bigint;primaryKey" json:"id"`
-	Content       string    `gorm:"type:text;not null" json:"content"`
-	Status        string    `gorm:"type:varchar(20);check:status IN ('pending', 'accepted', 'cancelled', 'done')" json:"status"`
-	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	UpdatedByID   *int64    `gorm:"type:bigint" json:"updated_by_id"`
-	RequestTypeID int64     `gorm:"type:bigint;not null" json:"request_type_id"`
-	OrderRoomID   int64     `gorm:"type:bigint;not null" json:"order_room_id"`
-
-	OrderRoom   *OrderRoom   `gorm:"foreignKey:OrderRoomID;references:ID;constraint:fk_requests_order_room,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"order_room"`
-	RequestType *RequestType `gorm:"foreignKey:RequestTypeID;references:ID;constraint:fk_requests_request_type,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"request_type"`
-	UpdatedBy   *User        `gorm:"foreignKey:UpdatedByID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
+	ID                 int64      `gorm:"type:bigint;primaryKey" json:"id"`
+	Content            string     `gorm:"type:text;not null" json:"content"`
+	Status             string     `gorm:"type:varchar(20);check:status IN ('pending', 'accepted', 'cancelled', 'done')" json:"status"`
+	Priority           string     `gorm:"type:varchar(20);not null;default:'normal';check:priority IN ('low', 'normal', 'high', 'urgent');index:requests_priority_idx" json:"priority"`
+	CreatedAt          time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt          time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	UpdatedByID        *int64     `gorm:"type:bigint" json:"updated_by_id"`
+	RequestTypeID      int64      `gorm:"type:bigint;not null" json:"request_type_id"`
+	OrderRoomID        int64      `gorm:"type:bigint;not null" json:"order_room_id"`
+	AcceptDueAt        *time.Time `gorm:"index:requests_accept_due_at_idx" json:"accept_due_at"`
+	CompleteDueAt      *time.Time `gorm:"index:requests_complete_due_at_idx" json:"complete_due_at"`
+	AcceptedAt         *time.Time `json:"accepted_at"`
+	DoneAt             *time.Time `json:"done_at"`
+	AcceptBreachedAt   *time.Time `json:"accept_breached_at"`
+	CompleteBreachedAt *time.Time `json:"complete_breached_at"`
+	AssigneeID         *int64     `gorm:"type:bigint;index:requests_assignee_id_idx" json:"assignee_id"`
+	AssignedAt         *time.Time `json:"assigned_at"`
+	ScheduleID         *int64     `gorm:"type:bigint;index:requests_schedule_id_idx" json:"schedule_id"`
+
+	OrderRoom   *OrderRoom           `gorm:"foreignKey:OrderRoomID;references:ID;constraint:fk_requests_order_room,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"order_room"`
+	RequestType *RequestType         `gorm:"foreignKey:RequestTypeID;references:ID;constraint:fk_requests_request_type,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"request_type"`
+	UpdatedBy   *User                `gorm:"foreignKey:UpdatedByID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
+	Assignee    *User                `gorm:"foreignKey:AssigneeID;references:ID;constraint:fk_requests_assignee,OnUpdate:CASCADE,OnDelete:SET NULL" json:"assignee"`
+	Attachments []*RequestAttachment `gorm:"foreignKey:RequestID;references:ID;constraint:fk_request_attachments_request,OnUpdate:CASCADE,OnDelete:CASCADE" json:"attachments"`
+	Notes       []*RequestNote       `gorm:"foreignKey:RequestID;references:ID;constraint:fk_request_notes_request,OnUpdate:CASCADE,OnDelete:CASCADE" json:"notes"`
+	Schedule    *RequestSchedule     `gorm:"foreignKey:ScheduleID;references:ID;constraint:fk_requests_schedule,OnUpdate:CASCADE,OnDelete:SET NULL" json:"schedule"`
+}
+
+type RequestAttachment struct {
+	ID          int64  `gorm:"type:bigint;primaryKey" json:"id"`
+	RequestID   int64  `gorm:"type:bigint;not null;index:request_attachments_request_id_idx" json:"request_id"`
+	Key         string `gorm:"type:varchar(150);uniqueIndex:request_attachments_key_key;not null" json:"key"`
+	FileName    string `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType string `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size        int64  `gorm:"type:bigint;not null" json:"size"`
+	SortOrder   uint32 `gorm:"type:integer;not null" json:"sort_order"`
+
+	Request *Request `gorm:"foreignKey:RequestID;references:ID;constraint:fk_request_attachments_request,OnUpdate:CASCADE,OnDelete:CASCADE" json:"request"`
+	URL     string   `gorm:"-" json:"url"`
+}
+
+type RequestNote struct {
+	ID        int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	RequestID int64     `gorm:"type:bigint;not null;index:request_notes_request_id_idx" json:"request_id"`
+	Type      string    `gorm:"type:varchar(20);not null;check:type IN ('note', 'progress', 'status')" json:"type"`
+	Content   string    `gorm:"type:text;not null" json:"content"`
+	StaffID   *int64    `gorm:"type:bigint" json:"staff_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	Request *Request `gorm:"foreignKey:RequestID;references:ID;constraint:fk_request_notes_request,OnUpdate:CASCADE,OnDelete:CASCADE" json:"request"`
+	Staff   *User    `gorm:"foreignKey:StaffID;references:ID;constraint:fk_request_notes_staff,OnUpdate:CASCADE,OnDelete:SET NULL" json:"staff"`
+}
+
+type RequestSchedule struct {
+	ID            int64      `gorm:"type:bigint;primaryKey" json:"id"`
+	OrderRoomID   int64      `gorm:"type:bigint;not null;index:request_schedules_order_room_id_idx" json:"order_room_id"`
+	RequestTypeID int64      `gorm:"type:bigint;not null" json:"request_type_id"`
+	Content       string     `gorm:"type:text;not null" json:"content"`
+	Priority      string     `gorm:"type:varchar(20);not null;default:'normal';check:priority IN ('low', 'normal', 'high', 'urgent')" json:"priority"`
+	Recurrence    string     `gorm:"type:varchar(20);not null;default:'none';check:recurrence IN ('none', 'daily')" json:"recurrence"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'active';check:status IN ('active', 'completed', 'cancelled');index:request_schedules_status_next_run_at_idx,priority:1" json:"status"`
+	NextRunAt     time.Time  `gorm:"not null;index:request_schedules_status_next_run_at_idx,priority:2" json:"next_run_at"`
+	LastRunAt     *time.Time `json:"last_run_at"`
+	CancelledAt   *time.Time `json:"cancelled_at"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	OrderRoom   *OrderRoom   `gorm:"foreignKey:OrderRoomID;references:ID;constraint:fk_request_schedules_order_room,OnUpdate:CASCADE,OnDelete:CASCADE" json:"order_room"`
+	RequestType *RequestType `gorm:"foreignKey:RequestTypeID;references:ID;constraint:fk_request_schedules_request_type,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"request_type"`
+	Requests    []*Request   `gorm:"foreignKey:ScheduleID;references:ID;constraint:fk_requests_schedule,OnUpdate:CASCADE,OnDelete:SET NULL" json:"requests"`
 }
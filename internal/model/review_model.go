@@ -3,13 +3,23 @@ package model
 import "time"
 
 type Review struct {
-	ID          int64     `gorm:"type:bigint;primaryKey" json:"id"`
-	OrderRoomID int64     `gorm:"type:bigint;not null;uniqueIndex:reviews_order_room_id" json:"order_room_id"`
-	Email       string    `gorm:"type:varchar(150);not null" json:"email"`
-	Star        uint32    `gorm:"type:integer;not null" json:"star"`
-	Content     string    `gorm:"type:text;not null" json:"content"`
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID               int64      `gorm:"type:bigint;primaryKey" json:"id"`
+	OrderRoomID      int64      `gorm:"type:bigint;not null;uniqueIndex:reviews_order_room_id" json:"order_room_id"`
+	Email            string     `gorm:"type:varchar(150);not null" json:"email"`
+	Star             uint32     `gorm:"type:integer;not null" json:"star"`
+	Content          string     `gorm:"type:text;not null" json:"content"`
+	Status           string     `gorm:"type:varchar(20);not null;default:'approved';check:status IN ('approved', 'hidden', 'flagged');index:reviews_status_is_featured_idx,priority:1" json:"status"`
+	ModerationReason *string    `gorm:"type:text" json:"moderation_reason"`
+	ModeratedByID    *int64     `gorm:"type:bigint" json:"moderated_by_id"`
+	ModeratedAt      *time.Time `json:"moderated_at"`
+	IsFeatured       bool       `gorm:"type:boolean;not null;default:false;index:reviews_status_is_featured_idx,priority:2" json:"is_featured"`
+	Response         *string    `gorm:"type:text" json:"response"`
+	RespondedByID    *int64     `gorm:"type:bigint" json:"responded_by_id"`
+	RespondedAt      *time.Time `json:"responded_at"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 
-	OrderRoom *OrderRoom `gorm:"foreignKey:OrderRoomID;references:ID;constraint:fk_reviews_order_room,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"order_room"`
+	OrderRoom   *OrderRoom `gorm:"foreignKey:OrderRoomID;references:ID;constraint:fk_reviews_order_room,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"order_room"`
+	ModeratedBy *User      `gorm:"foreignKey:ModeratedByID;references:ID;constraint:fk_reviews_moderated_by,OnUpdate:CASCADE,OnDelete:SET NULL" json:"moderated_by"`
+	RespondedBy *User      `gorm:"foreignKey:RespondedByID;references:ID;constraint:fk_reviews_responded_by,OnUpdate:CASCADE,OnDelete:SET NULL" json:"responded_by"`
 }
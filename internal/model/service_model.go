@@ -12,39 +12,151 @@ type ServiceType struct {
 	UpdatedByID  int64     `gorm:"type:bigint;not null" json:"updated_by_id"`
 	DepartmentID int64     `gorm:"type:bigint;not null" json:"department_id"`
 
-	Department   *Department `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_service_types_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"department"`
-	CreatedBy    *User       `gorm:"foreignKey:CreatedByID;references:ID;constraint:fk_service_types_created_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"created_by"`
-	UpdatedBy    *User       `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_service_types_updated_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
-	Services     []*Service  `gorm:"foreignKey:ServiceTypeID;references:ID;constraint:fk_services_service_type,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"services"`
-	ServiceCount int64       `gorm:"-" json:"service_count"`
+	Department   *Department               `gorm:"foreignKey:DepartmentID;references:ID;constraint:fk_service_types_department,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"department"`
+	CreatedBy    *User                     `gorm:"foreignKey:CreatedByID;references:ID;constraint:fk_service_types_created_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"created_by"`
+	UpdatedBy    *User                     `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_service_types_updated_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
+	Services     []*Service                `gorm:"foreignKey:ServiceTypeID;references:ID;constraint:fk_services_service_type,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"services"`
+	OpeningHours []*ServiceOpeningHour     `gorm:"foreignKey:ServiceTypeID;references:ID;constraint:fk_service_opening_hours_service_type,OnUpdate:CASCADE,OnDelete:CASCADE" json:"opening_hours"`
+	Translations []*ServiceTypeTranslation `gorm:"foreignKey:ServiceTypeID;references:ID;constraint:fk_service_type_translations_service_type,OnUpdate:CASCADE,OnDelete:CASCADE" json:"translations"`
+	ServiceCount int64                     `gorm:"-" json:"service_count"`
 }
 
 type Service struct {
-	ID            int64     `gorm:"type:bigint;primaryKey" json:"id"`
-	Name          string    `gorm:"type:varchar(150);not null" json:"name"`
-	Slug          string    `gorm:"type:varchar(150);uniqueIndex:services_slug_key;not null" json:"slug"`
-	Price         float64   `gorm:"type:decimal(10,2);not null" json:"price"`
-	IsActive      bool      `gorm:"type:boolean;not null" json:"is_active"`
-	Description   string    `gorm:"type:text;not null" json:"description"`
-	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	CreatedByID   int64     `gorm:"type:bigint;not null" json:"created_by_id"`
-	UpdatedByID   int64     `gorm:"type:bigint;not null" json:"updated_by_id"`
-	ServiceTypeID int64     `gorm:"type:bigint;not null" json:"service_type_id"`
-
-	ServiceType   *ServiceType    `gorm:"foreignKey:ServiceTypeID;references:ID;constraint:fk_services_service_type,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"service_type"`
-	CreatedBy     *User           `gorm:"foreignKey:CreatedByID;references:ID;constraint:fk_services_created_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"created_by"`
-	UpdatedBy     *User           `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_services_created_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
-	ServiceImages []*ServiceImage `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_service_images_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"service_images"`
-	OrderServices []*OrderService `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_order_services_service,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"order_services"`
+	ID                  int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	Name                string    `gorm:"type:varchar(150);not null" json:"name"`
+	Slug                string    `gorm:"type:varchar(150);uniqueIndex:services_slug_key;not null" json:"slug"`
+	Price               float64   `gorm:"type:decimal(10,2);not null" json:"price"`
+	IsActive            bool      `gorm:"type:boolean;not null" json:"is_active"`
+	Description         string    `gorm:"type:text;not null" json:"description"`
+	CreatedAt           time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt           time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedByID         int64     `gorm:"type:bigint;not null" json:"created_by_id"`
+	UpdatedByID         int64     `gorm:"type:bigint;not null" json:"updated_by_id"`
+	ServiceTypeID       int64     `gorm:"type:bigint;not null" json:"service_type_id"`
+	SlotDurationMinutes *uint32   `gorm:"type:integer" json:"slot_duration_minutes"`
+	SlotCapacity        *uint32   `gorm:"type:integer" json:"slot_capacity"`
+	Stock               *uint32   `gorm:"type:integer;check:chk_services_stock,stock >= 0" json:"stock"`
+	LowStockThreshold   *uint32   `gorm:"type:integer" json:"low_stock_threshold"`
+
+	ServiceType    *ServiceType          `gorm:"foreignKey:ServiceTypeID;references:ID;constraint:fk_services_service_type,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"service_type"`
+	CreatedBy      *User                 `gorm:"foreignKey:CreatedByID;references:ID;constraint:fk_services_created_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"created_by"`
+	UpdatedBy      *User                 `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_services_created_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
+	ServiceImages  []*ServiceImage       `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_service_images_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"service_images"`
+	OrderServices  []*OrderService       `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_order_services_service,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"order_services"`
+	OpeningHours   []*ServiceOpeningHour `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_service_opening_hours_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"opening_hours"`
+	OptionGroups   []*ServiceOptionGroup `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_service_option_groups_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"option_groups"`
+	Translations   []*ServiceTranslation `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_service_translations_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"translations"`
+	AvailableSlots []*ServiceSlot        `gorm:"-" json:"available_slots"`
+}
+
+type ServiceTypeTranslation struct {
+	ID            int64  `gorm:"type:bigint;primaryKey" json:"id"`
+	ServiceTypeID int64  `gorm:"type:bigint;not null;uniqueIndex:service_type_translations_service_type_id_locale_key,priority:1" json:"service_type_id"`
+	Locale        string `gorm:"type:varchar(20);not null;uniqueIndex:service_type_translations_service_type_id_locale_key,priority:2" json:"locale"`
+	Name          string `gorm:"type:varchar(150);not null" json:"name"`
+
+	ServiceType *ServiceType `gorm:"foreignKey:ServiceTypeID;references:ID;constraint:fk_service_type_translations_service_type,OnUpdate:CASCADE,OnDelete:CASCADE" json:"service_type"`
+}
+
+type ServiceTranslation struct {
+	ID          int64   `gorm:"type:bigint;primaryKey" json:"id"`
+	ServiceID   int64   `gorm:"type:bigint;not null;uniqueIndex:service_translations_service_id_locale_key,priority:1" json:"service_id"`
+	Locale      string  `gorm:"type:varchar(20);not null;uniqueIndex:service_translations_service_id_locale_key,priority:2" json:"locale"`
+	Name        string  `gorm:"type:varchar(150);not null" json:"name"`
+	Description *string `gorm:"type:text" json:"description"`
+
+	Service *Service `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_service_translations_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"service"`
 }
 
 type ServiceImage struct {
-	ID          int64  `gorm:"type:bigint;primaryKey" json:"id"`
-	ServiceID   int64  `gorm:"type:bigint;not null" json:"service_id"`
-	Key         string `gorm:"type:varchar(150);uniqueIndex:service_images_key_key;not null" json:"key"`
-	IsThumbnail bool   `gorm:"type:boolean;not null" json:"is_thumbnail"`
-	SortOrder   uint32 `gorm:"type:integer;not null" json:"sort_order"`
+	ID          int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	ServiceID   int64     `gorm:"type:bigint;not null" json:"service_id"`
+	Key         string    `gorm:"type:varchar(150);uniqueIndex:service_images_key_key;not null" json:"key"`
+	IsThumbnail bool      `gorm:"type:boolean;not null" json:"is_thumbnail"`
+	SortOrder   uint32    `gorm:"type:integer;not null" json:"sort_order"`
+	Status      string    `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	Width       *uint32   `gorm:"type:integer" json:"width"`
+	Height      *uint32   `gorm:"type:integer" json:"height"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	Service  *Service               `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_service_images_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"service"`
+	Variants []*ServiceImageVariant `gorm:"foreignKey:ServiceImageID;references:ID;constraint:fk_service_image_variants_service_image,OnUpdate:CASCADE,OnDelete:CASCADE" json:"variants"`
+}
+
+type ServiceImageVariant struct {
+	ID             int64  `gorm:"type:bigint;primaryKey" json:"id"`
+	ServiceImageID int64  `gorm:"type:bigint;not null;index:service_image_variants_service_image_id_idx" json:"service_image_id"`
+	Key            string `gorm:"type:varchar(200);uniqueIndex:service_image_variants_key_key;not null" json:"key"`
+	Format         string `gorm:"type:varchar(10);not null" json:"format"`
+	Width          uint32 `gorm:"type:integer;not null" json:"width"`
+	Height         uint32 `gorm:"type:integer;not null" json:"height"`
+	Size           int64  `gorm:"type:bigint;not null" json:"size"`
+
+	ServiceImage *ServiceImage `gorm:"foreignKey:ServiceImageID;references:ID;constraint:fk_service_image_variants_service_image,OnUpdate:CASCADE,OnDelete:CASCADE" json:"service_image"`
+}
+
+type ServiceOpeningHour struct {
+	ID            int64  `gorm:"type:bigint;primaryKey" json:"id"`
+	ServiceTypeID *int64 `gorm:"type:bigint;index:service_opening_hours_service_type_id_idx;check:chk_service_opening_hours_owner,(service_type_id IS NULL) <> (service_id IS NULL)" json:"service_type_id"`
+	ServiceID     *int64 `gorm:"type:bigint;index:service_opening_hours_service_id_idx" json:"service_id"`
+	DayOfWeek     uint8  `gorm:"type:smallint;not null;check:day_of_week BETWEEN 0 AND 6" json:"day_of_week"`
+	OpenTime      string `gorm:"type:varchar(5);not null" json:"open_time"`
+	CloseTime     string `gorm:"type:varchar(5);not null" json:"close_time"`
+}
+
+type ServiceSlot struct {
+	ID        int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	ServiceID int64     `gorm:"type:bigint;not null;uniqueIndex:service_slots_service_id_start_at_key,priority:1" json:"service_id"`
+	StartAt   time.Time `gorm:"not null;uniqueIndex:service_slots_service_id_start_at_key,priority:2" json:"start_at"`
+	Reserved  uint32    `gorm:"type:integer;not null;default:0" json:"reserved"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Service *Service `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_service_slots_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"service"`
+}
+
+type ServiceOptionGroup struct {
+	ID            int64  `gorm:"type:bigint;primaryKey" json:"id"`
+	ServiceID     int64  `gorm:"type:bigint;not null;index:service_option_groups_service_id_idx" json:"service_id"`
+	Name          string `gorm:"type:varchar(150);not null" json:"name"`
+	SelectionType string `gorm:"type:varchar(20);not null;check:selection_type IN ('single', 'multiple')" json:"selection_type"`
+	IsRequired    bool   `gorm:"type:boolean;not null" json:"is_required"`
+	SortOrder     uint32 `gorm:"type:integer;not null" json:"sort_order"`
+
+	Service *Service         `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_service_option_groups_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"service"`
+	Options []*ServiceOption `gorm:"foreignKey:GroupID;references:ID;constraint:fk_service_options_group,OnUpdate:CASCADE,OnDelete:CASCADE" json:"options"`
+}
+
+type ServiceOption struct {
+	ID         int64   `gorm:"type:bigint;primaryKey" json:"id"`
+	GroupID    int64   `gorm:"type:bigint;not null;index:service_options_group_id_idx" json:"group_id"`
+	Name       string  `gorm:"type:varchar(150);not null" json:"name"`
+	PriceDelta float64 `gorm:"type:decimal(10,2);not null;default:0" json:"price_delta"`
+	SortOrder  uint32  `gorm:"type:integer;not null" json:"sort_order"`
+
+	Group *ServiceOptionGroup `gorm:"foreignKey:GroupID;references:ID;constraint:fk_service_options_group,OnUpdate:CASCADE,OnDelete:CASCADE" json:"group"`
+}
+
+type PricingRule struct {
+	ID            int64      `gorm:"type:bigint;primaryKey" json:"id"`
+	Name          string     `gorm:"type:varchar(150);not null" json:"name"`
+	ServiceTypeID *int64     `gorm:"type:bigint;index:pricing_rules_service_type_id_idx;check:chk_pricing_rules_target,service_type_id IS NULL OR service_id IS NULL" json:"service_type_id"`
+	ServiceID     *int64     `gorm:"type:bigint;index:pricing_rules_service_id_idx" json:"service_id"`
+	DayType       string     `gorm:"type:varchar(10);not null;default:'all';check:day_type IN ('all', 'weekday', 'weekend')" json:"day_type"`
+	StartTime     *string    `gorm:"type:varchar(5)" json:"start_time"`
+	EndTime       *string    `gorm:"type:varchar(5)" json:"end_time"`
+	StartsAt      *time.Time `json:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at"`
+	PromoCode     *string    `gorm:"type:varchar(50);uniqueIndex:pricing_rules_promo_code_key" json:"promo_code"`
+	DiscountType  string     `gorm:"type:varchar(20);not null;check:discount_type IN ('percentage', 'fixed_amount', 'fixed_price')" json:"discount_type"`
+	DiscountValue float64    `gorm:"type:decimal(10,2);not null" json:"discount_value"`
+	IsActive      bool       `gorm:"type:boolean;not null" json:"is_active"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedByID   int64      `gorm:"type:bigint;not null" json:"created_by_id"`
+	UpdatedByID   int64      `gorm:"type:bigint;not null" json:"updated_by_id"`
 
-	Service *Service `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_service_images_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"service"`
+	ServiceType *ServiceType `gorm:"foreignKey:ServiceTypeID;references:ID;constraint:fk_pricing_rules_service_type,OnUpdate:CASCADE,OnDelete:CASCADE" json:"service_type"`
+	Service     *Service     `gorm:"foreignKey:ServiceID;references:ID;constraint:fk_pricing_rules_service,OnUpdate:CASCADE,OnDelete:CASCADE" json:"service"`
+	CreatedBy   *User        `gorm:"foreignKey:CreatedByID;references:ID;constraint:fk_pricing_rules_created_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"created_by"`
+	UpdatedBy   *User        `gorm:"foreignKey:UpdatedByID;references:ID;constraint:fk_pricing_rules_updated_by,OnUpdate:CASCADE,OnDelete:RESTRICT" json:"updated_by"`
 }
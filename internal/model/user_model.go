@@ -34,4 +34,5 @@ type User struct {
 	NotificationsRead    []*NotificationStaff `gorm:"foreignKey:StaffID;references:ID;constraint:fk_notification_staffs_staff,OnUpdate:CASCADE,OnDelete:CASCADE" json:"notifications_read"`
 	MessagesSent         []*Message           `gorm:"foreignKey:SenderID;references:ID;constraint:fk_messages_sender,OnUpdate:CASCADE,OnDelete:CASCADE" json:"messages_sent"`
 	MessagesRead         []*MessageStaff      `gorm:"foreignKey:StaffID;references:ID;constraint:fk_messages_staffs_staff,OnUpdate:CASCADE,OnDelete:CASCADE" json:"messages_read"`
+	ChatsAssigned        []*Chat              `gorm:"foreignKey:AssigneeID;references:ID;constraint:fk_chats_assignee,OnUpdate:CASCADE,OnDelete:SET NULL" json:"chats_assigned"`
 }
@@ -0,0 +1,182 @@
+package mq
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	memoryQueueSize      = 1024
+	memoryConsumerCount  = 5
+	memoryPublishTimeout = 5 * time.Second
+)
+
+var (
+	ErrMemoryQueueClosed = errors.New("mq: memory message queue closed")
+	ErrMemoryQueueFull   = errors.New("mq: memory message queue full")
+)
+
+type memoryMessageQueueProviderImpl struct {
+	mu       sync.RWMutex
+	closed   bool
+	queues   map[string]chan []byte
+	bindings map[string]map[string][]string
+	pending  map[string]map[string]chan []byte
+	logger   *zap.Logger
+}
+
+func NewMemoryMessageQueueProvider(logger *zap.Logger) MessageQueueProvider {
+	return &memoryMessageQueueProviderImpl{
+		queues:   make(map[string]chan []byte),
+		bindings: make(map[string]map[string][]string),
+		pending:  make(map[string]map[string]chan []byte),
+		logger:   logger,
+	}
+}
+
+// PublishMessage delivers body to every queue bound to the routing key. Like
+// a durable RabbitMQ queue, messages published before any consumer binds are
+// held and handed to the first queue that does.
+func (m *memoryMessageQueueProviderImpl) PublishMessage(exchange, routingKey string, body []byte) error {
+	msg := make([]byte, len(body))
+	copy(msg, body)
+
+	// The read lock is held while sending so Close cannot close a channel
+	// under a publisher.
+	m.mu.RLock()
+	if m.closed {
+		m.mu.RUnlock()
+		return ErrMemoryQueueClosed
+	}
+
+	queueNames := m.bindings[exchange][routingKey]
+	if len(queueNames) == 0 {
+		m.mu.RUnlock()
+		return m.holdMessage(exchange, routingKey, msg)
+	}
+	defer m.mu.RUnlock()
+
+	for _, name := range queueNames {
+		select {
+		case m.queues[name] <- msg:
+		case <-time.After(memoryPublishTimeout):
+			return fmt.Errorf("publish to exchange %s timed out", exchange)
+		}
+	}
+
+	return nil
+}
+
+func (m *memoryMessageQueueProviderImpl) holdMessage(exchange, routingKey string, msg []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return ErrMemoryQueueClosed
+	}
+
+	// A consumer may have bound between the two locks.
+	if queueNames := m.bindings[exchange][routingKey]; len(queueNames) > 0 {
+		for _, name := range queueNames {
+			select {
+			case m.queues[name] <- msg:
+			default:
+				return ErrMemoryQueueFull
+			}
+		}
+		return nil
+	}
+
+	if _, ok := m.pending[exchange]; !ok {
+		m.pending[exchange] = make(map[string]chan []byte)
+	}
+	held, ok := m.pending[exchange][routingKey]
+	if !ok {
+		held = make(chan []byte, memoryQueueSize)
+		m.pending[exchange][routingKey] = held
+	}
+
+	select {
+	case held <- msg:
+		return nil
+	default:
+		return ErrMemoryQueueFull
+	}
+}
+
+func (m *memoryMessageQueueProviderImpl) ConsumeMessage(queueName, exchange, routingKey string, handler func([]byte) error) error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return ErrMemoryQueueClosed
+	}
+
+	q, ok := m.queues[queueName]
+	if !ok {
+		q = make(chan []byte, memoryQueueSize)
+		m.queues[queueName] = q
+	}
+
+	if _, ok := m.bindings[exchange]; !ok {
+		m.bindings[exchange] = make(map[string][]string)
+	}
+	bound := false
+	for _, name := range m.bindings[exchange][routingKey] {
+		if name == queueName {
+			bound = true
+			break
+		}
+	}
+	if !bound {
+		m.bindings[exchange][routingKey] = append(m.bindings[exchange][routingKey], queueName)
+	}
+
+	var held chan []byte
+	if held, ok = m.pending[exchange][routingKey]; ok {
+		delete(m.pending[exchange], routingKey)
+		close(held)
+	}
+	m.mu.Unlock()
+
+	for i := range memoryConsumerCount {
+		go func(workerID int) {
+			if held != nil {
+				for body := range held {
+					processWithRetry(m.logger, body, handler, workerID)
+				}
+			}
+			for body := range q {
+				processWithRetry(m.logger, body, handler, workerID)
+			}
+		}(i)
+	}
+
+	return nil
+}
+
+// Close stops accepting messages and closes every queue, so consumers exit
+// once they have drained what was already published.
+func (m *memoryMessageQueueProviderImpl) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+
+	for _, q := range m.queues {
+		close(q)
+	}
+	for _, byKey := range m.pending {
+		for _, held := range byKey {
+			close(held)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package mq
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestMemoryMessageQueueDelivery(t *testing.T) {
+	tests := []struct {
+		name           string
+		publishFirst   bool
+		bindRoutingKey string
+		want           int
+	}{
+		{"published after bind", false, "key", 3},
+		{"published before bind", true, "key", 3},
+		{"other routing key", false, "other", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMemoryMessageQueueProvider(zap.NewNop())
+			defer m.Close()
+
+			received := make(chan string, 10)
+			bind := func() {
+				if err := m.ConsumeMessage("queue", "exchange", tt.bindRoutingKey, func(body []byte) error {
+					received <- string(body)
+					return nil
+				}); err != nil {
+					t.Fatalf("ConsumeMessage: %v", err)
+				}
+			}
+
+			if !tt.publishFirst {
+				bind()
+			}
+			for _, body := range []string{"a", "b", "c"} {
+				if err := m.PublishMessage("exchange", "key", []byte(body)); err != nil {
+					t.Fatalf("PublishMessage(%q): %v", body, err)
+				}
+			}
+			if tt.publishFirst {
+				bind()
+			}
+
+			got := 0
+			timeout := time.After(time.Second)
+			for got < tt.want {
+				select {
+				case <-received:
+					got++
+				case <-timeout:
+					t.Fatalf("received %d messages, want %d", got, tt.want)
+				}
+			}
+
+			select {
+			case body := <-received:
+				t.Fatalf("unexpected message %q", body)
+			case <-time.After(50 * time.Millisecond):
+			}
+		})
+	}
+}
+
+func TestMemoryMessageQueueClose(t *testing.T) {
+	m := NewMemoryMessageQueueProvider(zap.NewNop())
+	if err := m.ConsumeMessage("queue", "exchange", "key", func([]byte) error { return nil }); err != nil {
+		t.Fatalf("ConsumeMessage: %v", err)
+	}
+	if err := m.PublishMessage("exchange", "unbound", []byte("held")); err != nil {
+		t.Fatalf("PublishMessage: %v", err)
+	}
+
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := m.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"publish", func() error { return m.PublishMessage("exchange", "key", []byte("late")) }},
+		{"consume", func() error {
+			return m.ConsumeMessage("queue", "exchange", "key", func([]byte) error { return nil })
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, ErrMemoryQueueClosed) {
+				t.Fatalf("got %v, want %v", err, ErrMemoryQueueClosed)
+			}
+		})
+	}
+}
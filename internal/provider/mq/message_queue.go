@@ -13,6 +13,7 @@ import (
 type MessageQueueProvider interface {
 	PublishMessage(exchange, routingKey string, body []byte) error
 	ConsumeMessage(queueName, exchange, routingKey string, handler func([]byte) error) error
+	Close() error
 }
 
 type messageQueueProviderImpl struct {
@@ -81,7 +82,7 @@ func (m *messageQueueProviderImpl) ConsumeMessage(queueName, exchange, routingKe
 	for i := range 5 {
 		go func(workerID int) {
 			for msg := range msgs {
-				m.processWithRetry(msg.Body, handler, workerID)
+				processWithRetry(m.logger, msg.Body, handler, workerID)
 			}
 		}(i)
 	}
@@ -89,7 +90,13 @@ func (m *messageQueueProviderImpl) ConsumeMessage(queueName, exchange, routingKe
 	return nil
 }
 
-func (m *messageQueueProviderImpl) processWithRetry(body []byte, handler func([]byte) error, workerID int) {
+// Close is a no-op: the connection belongs to the server, which closes it on
+// shutdown and so ends every consumer channel.
+func (m *messageQueueProviderImpl) Close() error {
+	return nil
+}
+
+func processWithRetry(logger *zap.Logger, body []byte, handler func([]byte) error, workerID int) {
 	maxAttempts := 5
 	initialInterval := 1000 * time.Millisecond
 	multiplier := 2.0
@@ -100,7 +107,7 @@ func (m *messageQueueProviderImpl) processWithRetry(body []byte, handler func([]
 		if err == nil {
 			return
 		}
-		m.logger.Error(fmt.Sprintf("work %d (%d/%d) failed", workerID, attempt, maxAttempts), zap.Error(err))
+		logger.Error(fmt.Sprintf("work %d (%d/%d) failed", workerID, attempt, maxAttempts), zap.Error(err))
 
 		if attempt < maxAttempts {
 			delay := float64(initialInterval) * math.Pow(multiplier, float64(attempt-1))
@@ -111,5 +118,5 @@ func (m *messageQueueProviderImpl) processWithRetry(body []byte, handler func([]
 		}
 	}
 
-	m.logger.Error(fmt.Sprintf("work %d", workerID), zap.Error(fmt.Errorf("message sending failed after %d attempts", maxAttempts)))
+	logger.Error(fmt.Sprintf("work %d", workerID), zap.Error(fmt.Errorf("message sending failed after %d attempts", maxAttempts)))
 }
@@ -14,10 +14,15 @@ import (
 //go:embed templates/auth.html
 var authTemplate embed.FS
 
+//go:embed templates/transcript.html
+var transcriptTemplate embed.FS
+
 type SMTPProvider interface {
 	Send(to, subject, body string) error
 
 	AuthEmail(to, subject, otp string) error
+
+	TranscriptEmail(to, subject, guestName, roomName string, lines []string) error
 }
 
 type smtpProviderImpl struct {
@@ -56,3 +61,23 @@ func (s *smtpProviderImpl) AuthEmail(to, subject, otp string) error {
 
 	return s.Send(to, subject, body.String())
 }
+
+func (s *smtpProviderImpl) TranscriptEmail(to, subject, guestName, roomName string, lines []string) error {
+	tmpl, err := template.ParseFS(transcriptTemplate, "templates/transcript.html")
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	data := types.TranscriptEmailData{
+		Subject:   subject,
+		GuestName: guestName,
+		RoomName:  roomName,
+		Lines:     lines,
+	}
+	if err := tmpl.Execute(&body, data); err != nil {
+		return err
+	}
+
+	return s.Send(to, subject, body.String())
+}
@@ -0,0 +1,270 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	localObjectsDir = "objects"
+	localMetaDir    = "meta"
+	localMetaExt    = ".json"
+	LocalObjectPath = "/files/objects/"
+)
+
+type localObjectMeta struct {
+	ContentType  string `json:"content_type"`
+	CacheControl string `json:"cache_control,omitempty"`
+}
+
+// localStorageProviderImpl keeps objects on the local disk. It is meant for
+// development and single-node installs: signed URLs point back at the API,
+// which verifies them and streams the file itself.
+type localStorageProviderImpl struct {
+	root      string
+	baseURL   string
+	secretKey []byte
+}
+
+func NewLocalStorageProvider(root, baseURL, secretKey string) (StorageProvider, error) {
+	if root == "" {
+		return nil, errors.New("storage: local root directory is required")
+	}
+	if secretKey == "" {
+		return nil, errors.New("storage: local signing secret is required")
+	}
+
+	for _, dir := range []string{localObjectsDir, localMetaDir} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	return &localStorageProviderImpl{
+		root,
+		strings.TrimRight(baseURL, "/"),
+		[]byte(secretKey),
+	}, nil
+}
+
+func (l *localStorageProviderImpl) Attrs(ctx context.Context, key string) (*ObjectAttrs, error) {
+	objectPath, metaPath, err := l.paths(key)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(objectPath)
+	if err != nil {
+		return nil, localError(err)
+	}
+
+	meta, err := readLocalMeta(metaPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ObjectAttrs{
+		Size:        info.Size(),
+		ContentType: meta.ContentType,
+	}, nil
+}
+
+func (l *localStorageProviderImpl) NewReader(ctx context.Context, key string) (io.ReadCloser, error) {
+	objectPath, _, err := l.paths(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(objectPath)
+	if err != nil {
+		return nil, localError(err)
+	}
+
+	return f, nil
+}
+
+func (l *localStorageProviderImpl) Put(ctx context.Context, key string, data []byte, opts *PutOptions) error {
+	objectPath, metaPath, err := l.paths(key)
+	if err != nil {
+		return err
+	}
+
+	var meta localObjectMeta
+	if opts != nil {
+		meta.ContentType = opts.ContentType
+		meta.CacheControl = opts.CacheControl
+	}
+	metaData, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	if err = writeFileAtomic(objectPath, data); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(metaPath, metaData)
+}
+
+func (l *localStorageProviderImpl) Delete(ctx context.Context, key string) error {
+	objectPath, metaPath, err := l.paths(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(objectPath); err != nil {
+		return localError(err)
+	}
+	if err = os.Remove(metaPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (l *localStorageProviderImpl) SignedURL(key string, opts *SignedURLOptions) (string, error) {
+	if opts.Method != http.MethodGet && opts.Method != http.MethodPut {
+		return "", ErrUnsupportedMethod
+	}
+	if _, _, err := l.paths(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(opts.Expires.Unix(), 10)
+	contentType, contentLength := "", int64(0)
+	if opts.Method == http.MethodPut {
+		contentType, contentLength = opts.ContentType, opts.ContentLength
+	}
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", l.signature(opts.Method, key, contentType, contentLength, expires))
+
+	return fmt.Sprintf("%s%s%s?%s", l.baseURL, LocalObjectPath, escapeKey(key), query.Encode()), nil
+}
+
+func (l *localStorageProviderImpl) UploadHeaders(opts *SignedURLOptions) map[string]string {
+	return contentHeaders(opts)
+}
+
+// VerifySignedURL checks a request against a URL from SignedURL. For PUT,
+// contentLength is the request's declared length; it only has to match when
+// the URL was signed with a length.
+func (l *localStorageProviderImpl) VerifySignedURL(method, key, contentType string, contentLength int64, query url.Values) error {
+	expires := query.Get("expires")
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > unix {
+		return ErrInvalidSignature
+	}
+	if method != http.MethodPut {
+		contentType, contentLength = "", 0
+	}
+
+	signature, err := hex.DecodeString(query.Get("signature"))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	expected, _ := hex.DecodeString(l.signature(method, key, contentType, contentLength, expires))
+	if !hmac.Equal(signature, expected) && method == http.MethodPut {
+		expected, _ = hex.DecodeString(l.signature(method, key, contentType, 0, expires))
+	}
+	if !hmac.Equal(signature, expected) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (l *localStorageProviderImpl) Close() error {
+	return nil
+}
+
+func (l *localStorageProviderImpl) signature(method, key, contentType string, contentLength int64, expires string) string {
+	length := ""
+	if contentLength > 0 {
+		length = strconv.FormatInt(contentLength, 10)
+	}
+
+	h := hmac.New(sha256.New, l.secretKey)
+	h.Write([]byte(strings.Join([]string{method, key, contentType, length, expires}, "\n")))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// paths maps key onto the object and metadata files, refusing keys that
+// would escape the storage root.
+func (l *localStorageProviderImpl) paths(key string) (string, string, error) {
+	name := filepath.FromSlash(key)
+	if key == "" || strings.HasSuffix(key, "/") || !filepath.IsLocal(name) {
+		return "", "", ErrInvalidKey
+	}
+
+	return filepath.Join(l.root, localObjectsDir, name), filepath.Join(l.root, localMetaDir, name+localMetaExt), nil
+}
+
+func readLocalMeta(path string) (*localObjectMeta, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &localObjectMeta{}, nil
+		}
+		return nil, err
+	}
+
+	var meta localObjectMeta
+	if err = json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+
+	return &meta, nil
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func localError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrObjectNotExist
+	}
+	return err
+}
+
+func escapeKey(key string) string {
+	parts := strings.Split(key, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func newTestLocalStorage(t *testing.T) *localStorageProviderImpl {
+	t.Helper()
+
+	provider, err := NewLocalStorageProvider(t.TempDir(), "http://localhost:8080/", "secret")
+	if err != nil {
+		t.Fatalf("NewLocalStorageProvider: %v", err)
+	}
+	return provider.(*localStorageProviderImpl)
+}
+
+func signedQuery(t *testing.T, l *localStorageProviderImpl, key string, opts *SignedURLOptions) url.Values {
+	t.Helper()
+
+	signedURL, err := l.SignedURL(key, opts)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("parse signed url: %v", err)
+	}
+	return u.Query()
+}
+
+func TestLocalSignedURLGet(t *testing.T) {
+	l := newTestLocalStorage(t)
+	key := "services/a b.jpg"
+	query := signedQuery(t, l, key, &SignedURLOptions{Method: http.MethodGet, Expires: time.Now().Add(time.Minute)})
+	expired := signedQuery(t, l, key, &SignedURLOptions{Method: http.MethodGet, Expires: time.Now().Add(-time.Minute)})
+
+	signature := []byte(query.Get("signature"))
+	signature[0] ^= 1
+	tampered := url.Values{"expires": {query.Get("expires")}, "signature": {string(signature)}}
+	extended := url.Values{"expires": {"99999999999"}, "signature": {query.Get("signature")}}
+
+	tests := []struct {
+		name    string
+		method  string
+		key     string
+		query   url.Values
+		wantErr error
+	}{
+		{"valid", http.MethodGet, key, query, nil},
+		{"expired", http.MethodGet, key, expired, ErrInvalidSignature},
+		{"other key", http.MethodGet, "services/other.jpg", query, ErrInvalidSignature},
+		{"other method", http.MethodPut, key, query, ErrInvalidSignature},
+		{"tampered signature", http.MethodGet, key, tampered, ErrInvalidSignature},
+		{"extended expiry", http.MethodGet, key, extended, ErrInvalidSignature},
+		{"missing query", http.MethodGet, key, url.Values{}, ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := l.VerifySignedURL(tt.method, tt.key, "", 0, tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLocalSignedURLRejectsBadKeys(t *testing.T) {
+	l := newTestLocalStorage(t)
+
+	tests := []struct {
+		name    string
+		key     string
+		method  string
+		wantErr error
+	}{
+		{"parent directory", "../secret", http.MethodGet, ErrInvalidKey},
+		{"absolute path", "/etc/passwd", http.MethodGet, ErrInvalidKey},
+		{"directory", "services/", http.MethodGet, ErrInvalidKey},
+		{"empty", "", http.MethodGet, ErrInvalidKey},
+		{"unsupported method", "services/a.jpg", http.MethodDelete, ErrUnsupportedMethod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := l.SignedURL(tt.key, &SignedURLOptions{Method: tt.method, Expires: time.Now().Add(time.Minute)})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLocalSignedURLPutConditions(t *testing.T) {
+	l := newTestLocalStorage(t)
+	key := "uploads/chat/1.jpg"
+	expires := time.Now().Add(time.Minute)
+	sized := signedQuery(t, l, key, &SignedURLOptions{Method: http.MethodPut, Expires: expires, ContentType: "image/jpeg", ContentLength: 1024})
+	unsized := signedQuery(t, l, key, &SignedURLOptions{Method: http.MethodPut, Expires: expires, ContentType: "image/jpeg"})
+
+	tests := []struct {
+		name          string
+		query         url.Values
+		contentType   string
+		contentLength int64
+		wantErr       error
+	}{
+		{"signed type and length", sized, "image/jpeg", 1024, nil},
+		{"larger than signed", sized, "image/jpeg", 4096, ErrInvalidSignature},
+		{"smaller than signed", sized, "image/jpeg", 10, ErrInvalidSignature},
+		{"other content type", sized, "text/html", 1024, ErrInvalidSignature},
+		{"unsized accepts any length", unsized, "image/jpeg", 4096, nil},
+		{"unsized still checks type", unsized, "image/png", 4096, ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := l.VerifySignedURL(http.MethodPut, key, tt.contentType, tt.contentLength, tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,362 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+	s3DateFormat      = "20060102"
+	s3MaxPresignAge   = 7 * 24 * time.Hour
+)
+
+// s3StorageProviderImpl talks to Amazon S3 or any S3-compatible server such
+// as MinIO over plain HTTP, signing requests with AWS Signature Version 4.
+type s3StorageProviderImpl struct {
+	client          *http.Client
+	endpoint        *url.URL
+	publicEndpoint  *url.URL
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	usePathStyle    bool
+}
+
+// NewS3StorageProvider creates an S3 backend. endpoint defaults to the AWS
+// endpoint of region; publicEndpoint, when set, is used for signed URLs so
+// that browsers can reach a server the API talks to on an internal address.
+func NewS3StorageProvider(
+	endpoint, publicEndpoint, region, bucket, accessKeyID, secretAccessKey string,
+	usePathStyle bool,
+	timeout time.Duration,
+) (StorageProvider, error) {
+	if region == "" {
+		region = "us-east-1"
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	if publicEndpoint == "" {
+		publicEndpoint = endpoint
+	}
+
+	internal, err := parseS3Endpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	public, err := parseS3Endpoint(publicEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3StorageProviderImpl{
+		&http.Client{Timeout: timeout},
+		internal,
+		public,
+		region,
+		bucket,
+		accessKeyID,
+		secretAccessKey,
+		usePathStyle,
+	}, nil
+}
+
+func parseS3Endpoint(raw string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimRight(raw, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("storage: invalid S3 endpoint %q", raw)
+	}
+
+	return u, nil
+}
+
+func (s *s3StorageProviderImpl) Attrs(ctx context.Context, key string) (*ObjectAttrs, error) {
+	resp, err := s.do(ctx, http.MethodHead, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return &ObjectAttrs{
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+	}, nil
+}
+
+func (s *s3StorageProviderImpl) NewReader(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (s *s3StorageProviderImpl) Put(ctx context.Context, key string, data []byte, opts *PutOptions) error {
+	header := http.Header{}
+	if opts != nil {
+		if opts.ContentType != "" {
+			header.Set("Content-Type", opts.ContentType)
+		}
+		if opts.CacheControl != "" {
+			header.Set("Cache-Control", opts.CacheControl)
+		}
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, key, header, data)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (s *s3StorageProviderImpl) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (s *s3StorageProviderImpl) SignedURL(key string, opts *SignedURLOptions) (string, error) {
+	return s.presign(time.Now().UTC(), key, opts)
+}
+
+func (s *s3StorageProviderImpl) presign(now time.Time, key string, opts *SignedURLOptions) (string, error) {
+	if opts.Method != http.MethodGet && opts.Method != http.MethodPut {
+		return "", ErrUnsupportedMethod
+	}
+
+	expires := opts.Expires.Sub(now).Round(time.Second)
+	if expires <= 0 || expires > s3MaxPresignAge {
+		return "", fmt.Errorf("storage: signed URL expiry must be within %s", s3MaxPresignAge)
+	}
+
+	u := s.objectURL(s.publicEndpoint, key)
+	header := http.Header{}
+	header.Set("Host", u.Host)
+	if opts.Method == http.MethodPut && opts.ContentType != "" {
+		header.Set("Content-Type", opts.ContentType)
+	}
+	if opts.Method == http.MethodPut && opts.ContentLength > 0 {
+		header.Set("Content-Length", strconv.FormatInt(opts.ContentLength, 10))
+	}
+	signedHeaders, canonicalHeaders := s3CanonicalHeaders(header)
+
+	scope := s3Scope(now, s.region)
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", s.accessKeyID+"/"+scope)
+	query.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires.Seconds())))
+	query.Set("X-Amz-SignedHeaders", signedHeaders)
+
+	canonicalRequest := strings.Join([]string{
+		opts.Method,
+		u.EscapedPath(),
+		s3CanonicalQuery(query),
+		canonicalHeaders,
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	query.Set("X-Amz-Signature", s.sign(now, scope, canonicalRequest))
+	u.RawQuery = s3CanonicalQuery(query)
+
+	return u.String(), nil
+}
+
+func (s *s3StorageProviderImpl) UploadHeaders(opts *SignedURLOptions) map[string]string {
+	return contentHeaders(opts)
+}
+
+func (s *s3StorageProviderImpl) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// do sends a signed request for key and turns S3 error responses into Go
+// errors. The caller owns the returned response body.
+func (s *s3StorageProviderImpl) do(ctx context.Context, method, key string, header http.Header, body []byte) (*http.Response, error) {
+	u := s.objectURL(s.endpoint, key)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	if body == nil {
+		req.Body = http.NoBody
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	now := time.Now().UTC()
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("Host", u.Host)
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	signed := http.Header{}
+	for _, k := range []string{"Host", "X-Amz-Date", "X-Amz-Content-Sha256", "Content-Type"} {
+		if v := req.Header.Get(k); v != "" {
+			signed.Set(k, v)
+		}
+	}
+	signedHeaders, canonicalHeaders := s3CanonicalHeaders(signed)
+
+	scope := s3Scope(now, s.region)
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		"",
+		canonicalHeaders,
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	req.Header.Del("Host")
+	req.Host = u.Host
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKeyID, scope, signedHeaders, s.sign(now, scope, canonicalRequest),
+	))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrObjectNotExist
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, fmt.Errorf("storage: s3 %s %s failed: %s: %s", method, key, resp.Status, bytes.TrimSpace(msg))
+}
+
+func (s *s3StorageProviderImpl) objectURL(endpoint *url.URL, key string) *url.URL {
+	u := *endpoint
+	escapedKey := s3EscapePath(key)
+	if s.usePathStyle {
+		u.Path = endpoint.Path + "/" + s.bucket + "/" + key
+		u.RawPath = endpoint.EscapedPath() + "/" + s3EscapePath(s.bucket) + "/" + escapedKey
+	} else {
+		u.Host = s.bucket + "." + endpoint.Host
+		u.Path = endpoint.Path + "/" + key
+		u.RawPath = endpoint.EscapedPath() + "/" + escapedKey
+	}
+
+	return &u
+}
+
+func (s *s3StorageProviderImpl) sign(t time.Time, scope, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		t.Format(s3TimeFormat),
+		scope,
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), t.Format(s3DateFormat))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func s3Scope(t time.Time, region string) string {
+	return fmt.Sprintf("%s/%s/%s/aws4_request", t.Format(s3DateFormat), region, s3Service)
+}
+
+func s3CanonicalHeaders(header http.Header) (string, string) {
+	names := make([]string, 0, len(header))
+	for k := range header {
+		names = append(names, strings.ToLower(k))
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(strings.TrimSpace(header.Get(name)))
+		b.WriteByte('\n')
+	}
+
+	return strings.Join(names, ";"), b.String()
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		for _, v := range query[k] {
+			parts = append(parts, s3Escape(k, true)+"="+s3Escape(v, true))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+func s3EscapePath(p string) string {
+	return s3Escape(p, false)
+}
+
+// s3Escape percent-encodes everything except the RFC 3986 unreserved
+// characters, which is the encoding SigV4 expects. Slashes are kept in paths.
+func s3Escape(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
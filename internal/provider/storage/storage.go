@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrObjectNotExist    = errors.New("storage: object doesn't exist")
+	ErrInvalidKey        = errors.New("storage: invalid object key")
+	ErrInvalidSignature  = errors.New("storage: invalid or expired signature")
+	ErrUnsupportedMethod = errors.New("storage: unsupported signed URL method")
+)
+
+type ObjectAttrs struct {
+	Size        int64
+	ContentType string
+}
+
+type PutOptions struct {
+	ContentType  string
+	CacheControl string
+}
+
+// SignedURLOptions describes a URL that lets a client read (GET) or write
+// (PUT) a single object without credentials. ContentType and ContentLength
+// are only used for PUT; when set, the upload is rejected unless the client
+// sends exactly that content type and that many bytes.
+type SignedURLOptions struct {
+	Method        string
+	Expires       time.Time
+	ContentType   string
+	ContentLength int64
+}
+
+type StorageProvider interface {
+	Attrs(ctx context.Context, key string) (*ObjectAttrs, error)
+
+	NewReader(ctx context.Context, key string) (io.ReadCloser, error)
+
+	Put(ctx context.Context, key string, data []byte, opts *PutOptions) error
+
+	Delete(ctx context.Context, key string) error
+
+	SignedURL(key string, opts *SignedURLOptions) (string, error)
+
+	// UploadHeaders lists the headers a client must send with a PUT to a URL
+	// signed with opts.
+	UploadHeaders(opts *SignedURLOptions) map[string]string
+
+	Close() error
+}
+
+// SignedURLVerifier is implemented by backends that serve their own signed
+// URLs instead of handing clients off to a cloud provider.
+type SignedURLVerifier interface {
+	VerifySignedURL(method, key, contentType string, contentLength int64, query url.Values) error
+}
+
+// contentHeaders returns the headers covered by a PUT signature on backends
+// that sign Content-Type and Content-Length directly.
+func contentHeaders(opts *SignedURLOptions) map[string]string {
+	headers := map[string]string{}
+	if opts.ContentType != "" {
+		headers["Content-Type"] = opts.ContentType
+	}
+	if opts.ContentLength > 0 {
+		headers["Content-Length"] = strconv.FormatInt(opts.ContentLength, 10)
+	}
+
+	return headers
+}
@@ -0,0 +1,45 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const vietnameseLetters = "ăâđêôơưàảãạáằẳẵặắầẩẫậấèẻẽẹéềểễệếìỉĩịíòỏõọóồổỗộốờởỡợớùủũụúừửữựứỳỷỹỵý"
+
+type fakeTranslationProviderImpl struct{}
+
+func NewFakeTranslationProvider() TranslationProvider {
+	return &fakeTranslationProviderImpl{}
+}
+
+func (f *fakeTranslationProviderImpl) Detect(ctx context.Context, text string) (string, error) {
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case strings.ContainsRune(vietnameseLetters, r):
+			return "vi", nil
+		case unicode.Is(unicode.Han, r):
+			return "zh", nil
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			return "ja", nil
+		case unicode.Is(unicode.Hangul, r):
+			return "ko", nil
+		case unicode.Is(unicode.Cyrillic, r):
+			return "ru", nil
+		case unicode.Is(unicode.Thai, r):
+			return "th", nil
+		}
+	}
+
+	return "en", nil
+}
+
+func (f *fakeTranslationProviderImpl) Translate(ctx context.Context, text, source, target string) (string, error) {
+	if source == target {
+		return text, nil
+	}
+
+	return fmt.Sprintf("[%s] %s", target, text), nil
+}
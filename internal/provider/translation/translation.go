@@ -0,0 +1,117 @@
+package translation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const defaultGoogleEndpoint = "https://translation.googleapis.com/language/translate/v2"
+
+type TranslationProvider interface {
+	Detect(ctx context.Context, text string) (string, error)
+
+	Translate(ctx context.Context, text, source, target string) (string, error)
+}
+
+type googleTranslationProviderImpl struct {
+	client   *http.Client
+	endpoint string
+	apiKey   string
+}
+
+type googleTranslateResponse struct {
+	Data struct {
+		Translations []struct {
+			TranslatedText string `json:"translatedText"`
+		} `json:"translations"`
+		Detections [][]struct {
+			Language string `json:"language"`
+		} `json:"detections"`
+	} `json:"data"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func NewGoogleTranslationProvider(endpoint, apiKey string, timeout time.Duration) TranslationProvider {
+	if endpoint == "" {
+		endpoint = defaultGoogleEndpoint
+	}
+	return &googleTranslationProviderImpl{
+		&http.Client{Timeout: timeout},
+		endpoint,
+		apiKey,
+	}
+}
+
+func (g *googleTranslationProviderImpl) Detect(ctx context.Context, text string) (string, error) {
+	res, err := g.call(ctx, "/detect", map[string]any{"q": text})
+	if err != nil {
+		return "", err
+	}
+	if len(res.Data.Detections) == 0 || len(res.Data.Detections[0]) == 0 {
+		return "", fmt.Errorf("translation: empty detection result")
+	}
+
+	return res.Data.Detections[0][0].Language, nil
+}
+
+func (g *googleTranslationProviderImpl) Translate(ctx context.Context, text, source, target string) (string, error) {
+	payload := map[string]any{
+		"q":      text,
+		"target": target,
+		"format": "text",
+	}
+	if source != "" {
+		payload["source"] = source
+	}
+
+	res, err := g.call(ctx, "", payload)
+	if err != nil {
+		return "", err
+	}
+	if len(res.Data.Translations) == 0 {
+		return "", fmt.Errorf("translation: empty translation result")
+	}
+
+	return res.Data.Translations[0].TranslatedText, nil
+}
+
+func (g *googleTranslationProviderImpl) call(ctx context.Context, path string, payload map[string]any) (*googleTranslateResponse, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s%s?key=%s", g.endpoint, path, url.QueryEscape(g.apiKey))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var res googleTranslateResponse
+	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("translation: %d %s", res.Error.Code, res.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("translation: unexpected status %d", resp.StatusCode)
+	}
+
+	return &res, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/model"
 	"github.com/InstaySystem/is_v1-be/internal/types"
@@ -13,11 +14,17 @@ type ChatRepository interface {
 
 	CreateMessageTx(tx *gorm.DB, message *model.Message) error
 
+	CreateMessageAttachmentsTx(tx *gorm.DB, attachments []*model.MessageAttachment) error
+
 	FindChatByIDTx(tx *gorm.DB, chatID int64) (*model.Chat, error)
 
+	FindChatByIDForUpdateTx(tx *gorm.DB, chatID int64) (*model.Chat, error)
+
+	CreateChatTransferTx(tx *gorm.DB, transfer *model.ChatTransfer) error
+
 	UpdateChatTx(tx *gorm.DB, chatID int64, updateData map[string]any) error
 
-	FindAllChatsWithDetailsPaginated(ctx context.Context, query types.ChatPaginationQuery, staffID int64) ([]*model.Chat, int64, error)
+	FindAllChatsWithDetailsPaginated(ctx context.Context, query types.ChatPaginationQuery, staffID int64, departmentID *int64) ([]*model.Chat, int64, error)
 
 	FindAllUnreadMessageIDsByChatIDAndSenderTypeTx(tx *gorm.DB, chatID, staffID int64, senderType string) ([]int64, error)
 
@@ -25,7 +32,39 @@ type ChatRepository interface {
 
 	UpdateMessagesByChatIDAndSenderTypeTx(tx *gorm.DB, chatID int64, senderType string, updateData map[string]any) error
 
+	UpdateUndeliveredMessagesByChatIDAndSenderTypeTx(tx *gorm.DB, chatID int64, senderType string, deliveredAt time.Time) error
+
 	FindChatByIDWithDetails(ctx context.Context, chatID, staffID int64) (*model.Chat, error)
 
 	FindChatByOrderRoomIDWithDetails(ctx context.Context, orderRoomID int64) (*model.Chat, error)
+
+	FindAllChatIDsForTranscriptEmail(ctx context.Context, expiredBefore time.Time, limit int) ([]int64, error)
+
+	FindAllChatIDsForRetention(ctx context.Context, expiredBefore time.Time, limit int) ([]int64, error)
+
+	DeleteMessagesByChatIDTx(tx *gorm.DB, chatID int64) error
+
+	SearchMessagesPaginated(ctx context.Context, query types.MessageSearchQuery, departmentID *int64) ([]*types.MessageSearchResponse, int64, error)
+
+	CreateCannedResponse(ctx context.Context, cannedResponse *model.CannedResponse) error
+
+	FindAllCannedResponsesWithDetails(ctx context.Context, query types.CannedResponseQuery) ([]*model.CannedResponse, error)
+
+	FindCannedResponseByID(ctx context.Context, cannedResponseID int64) (*model.CannedResponse, error)
+
+	UpdateCannedResponse(ctx context.Context, cannedResponseID int64, updateData map[string]any) error
+
+	DeleteCannedResponse(ctx context.Context, cannedResponseID int64) error
+
+	CreateFAQ(ctx context.Context, faq *model.FAQ) error
+
+	FindAllFAQsWithDetails(ctx context.Context, query types.FAQQuery) ([]*model.FAQ, error)
+
+	FindAllActiveFAQs(ctx context.Context) ([]*model.FAQ, error)
+
+	FindFAQByID(ctx context.Context, faqID int64) (*model.FAQ, error)
+
+	UpdateFAQ(ctx context.Context, faqID int64, updateData map[string]any) error
+
+	DeleteFAQ(ctx context.Context, faqID int64) error
 }
@@ -17,6 +17,8 @@ type DepartmentRepository interface {
 
 	FindAllWithDetails(ctx context.Context) ([]*model.Department, error)
 
+	FindByNameWithStaffs(ctx context.Context, name string) (*model.Department, error)
+
 	FindAll(ctx context.Context) ([]*model.Department, error)
 
 	CountStaffByID(ctx context.Context, ids []int64) (map[int64]int64, error)
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v1-be/internal/model"
+	"gorm.io/gorm"
+)
+
+type FileRepository interface {
+	CreateUploads(ctx context.Context, uploads []*model.Upload) error
+
+	CountUploadsByOwnerSince(ctx context.Context, ownerType string, ownerID int64, purpose string, since time.Time) (int64, error)
+
+	CountUploadsOwnedBy(ctx context.Context, keys []string, ownerType string, ownerID int64) (int64, error)
+
+	FindKeysVisibleToGuest(ctx context.Context, orderRoomID int64, keys []string) ([]string, error)
+
+	ConfirmUploadsTx(tx *gorm.DB, keys []string) error
+
+	DeletePendingUploadsCreatedBefore(ctx context.Context, createdBefore time.Time, limit int) ([]string, error)
+}
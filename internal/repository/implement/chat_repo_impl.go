@@ -5,11 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"strings"
+	"time"
 
+	"github.com/InstaySystem/is_v1-be/internal/common"
 	"github.com/InstaySystem/is_v1-be/internal/model"
 	"github.com/InstaySystem/is_v1-be/internal/repository"
 	"github.com/InstaySystem/is_v1-be/internal/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type chatRepoImpl struct {
@@ -28,6 +31,10 @@ func (r *chatRepoImpl) CreateMessageTx(tx *gorm.DB, message *model.Message) erro
 	return tx.Create(message).Error
 }
 
+func (r *chatRepoImpl) CreateMessageAttachmentsTx(tx *gorm.DB, attachments []*model.MessageAttachment) error {
+	return tx.Create(attachments).Error
+}
+
 func (r *chatRepoImpl) FindChatByIDTx(tx *gorm.DB, chatID int64) (*model.Chat, error) {
 	var chat model.Chat
 	if err := tx.Where("id = ?", chatID).First(&chat).Error; err != nil {
@@ -40,6 +47,25 @@ func (r *chatRepoImpl) FindChatByIDTx(tx *gorm.DB, chatID int64) (*model.Chat, e
 	return &chat, nil
 }
 
+func (r *chatRepoImpl) FindChatByIDForUpdateTx(tx *gorm.DB, chatID int64) (*model.Chat, error) {
+	var chat model.Chat
+	if err := tx.Clauses(clause.Locking{
+		Strength: clause.LockingStrengthUpdate,
+		Options:  clause.LockingOptionsNoWait,
+	}).Where("id = ?", chatID).First(&chat).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &chat, nil
+}
+
+func (r *chatRepoImpl) CreateChatTransferTx(tx *gorm.DB, transfer *model.ChatTransfer) error {
+	return tx.Create(transfer).Error
+}
+
 func (r *chatRepoImpl) FindAllUnreadMessageIDsByChatIDAndSenderTypeTx(tx *gorm.DB, chatID, staffID int64, senderType string) ([]int64, error) {
 	var ids []int64
 	if err := tx.Where("chat_id = ? AND sender_type = ?", chatID, senderType).Where(
@@ -65,7 +91,11 @@ func (r *chatRepoImpl) UpdateMessagesByChatIDAndSenderTypeTx(tx *gorm.DB, chatID
 	return tx.Model(&model.Message{}).Where("chat_id = ? AND sender_type = ? AND is_read = false", chatID, senderType).Updates(updateData).Error
 }
 
-func (r *chatRepoImpl) FindAllChatsWithDetailsPaginated(ctx context.Context, query types.ChatPaginationQuery, staffID int64) ([]*model.Chat, int64, error) {
+func (r *chatRepoImpl) UpdateUndeliveredMessagesByChatIDAndSenderTypeTx(tx *gorm.DB, chatID int64, senderType string, deliveredAt time.Time) error {
+	return tx.Model(&model.Message{}).Where("chat_id = ? AND sender_type = ? AND delivered_at IS NULL", chatID, senderType).Update("delivered_at", deliveredAt).Error
+}
+
+func (r *chatRepoImpl) FindAllChatsWithDetailsPaginated(ctx context.Context, query types.ChatPaginationQuery, staffID int64, departmentID *int64) ([]*model.Chat, int64, error) {
 	var chats []*model.Chat
 	var total int64
 
@@ -78,6 +108,17 @@ func (r *chatRepoImpl) FindAllChatsWithDetailsPaginated(ctx context.Context, que
 			Where("LOWER(bookings.booking_number) LIKE @q", sql.Named("q", searchTerm))
 	}
 
+	switch query.Assignee {
+	case "mine":
+		db = db.Where("chats.assignee_id = ?", staffID)
+	case "unassigned":
+		db = db.Where("chats.assignee_id IS NULL")
+	}
+
+	if departmentID != nil {
+		db = db.Where("chats.department_id = ?", *departmentID)
+	}
+
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
@@ -88,12 +129,17 @@ func (r *chatRepoImpl) FindAllChatsWithDetailsPaginated(ctx context.Context, que
 		Offset(int(offset)).
 		Preload("OrderRoom.Room.Floor").
 		Preload("OrderRoom.Booking.Source").
+		Preload("Assignee").
+		Preload("Department").
 		Preload("Messages", func(db *gorm.DB) *gorm.DB {
 			return db.Select("messages.*").
 				Joins("JOIN chats ON chats.id = messages.chat_id AND chats.last_message_at = messages.created_at")
 		}).
 		Preload("Messages.Sender").
 		Preload("Messages.StaffsRead", "staff_id = ?", staffID).
+		Preload("Messages.Attachments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
 		Find(&chats).Error; err != nil {
 		return nil, 0, err
 	}
@@ -106,11 +152,23 @@ func (r *chatRepoImpl) FindChatByIDWithDetails(ctx context.Context, chatID, staf
 	if err := r.db.WithContext(ctx).
 		Preload("OrderRoom.Room.Floor").
 		Preload("OrderRoom.Booking.Source").
+		Preload("Assignee").
+		Preload("Department").
+		Preload("Transfers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Transfers.FromStaff").
+		Preload("Transfers.ToStaff").
+		Preload("Transfers.ToDepartment").
+		Preload("Transfers.CreatedBy").
 		Preload("Messages", func(db *gorm.DB) *gorm.DB {
 			return db.Order("created_at ASC")
 		}).
 		Preload("Messages.Sender").
 		Preload("Messages.StaffsRead", "staff_id = ?", staffID).
+		Preload("Messages.Attachments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
 		Where("id = ?", chatID).First(&chat).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -125,6 +183,8 @@ func (r *chatRepoImpl) FindChatByOrderRoomIDWithDetails(ctx context.Context, ord
 	var chat model.Chat
 	if err := r.db.WithContext(ctx).Preload("Messages", func(db *gorm.DB) *gorm.DB {
 		return db.Order("created_at ASC")
+	}).Preload("Messages.Attachments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("sort_order ASC")
 	}).Where("order_room_id = ?", orderRoomID).First(&chat).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -134,3 +194,218 @@ func (r *chatRepoImpl) FindChatByOrderRoomIDWithDetails(ctx context.Context, ord
 
 	return &chat, nil
 }
+
+func (r *chatRepoImpl) FindAllChatIDsForTranscriptEmail(ctx context.Context, expiredBefore time.Time, limit int) ([]int64, error) {
+	var chatIDs []int64
+	if err := r.db.WithContext(ctx).Model(&model.Chat{}).
+		Joins("JOIN order_rooms ON order_rooms.id = chats.order_room_id").
+		Joins("JOIN bookings ON bookings.id = order_rooms.booking_id").
+		Where("chats.expired_at < ? AND chats.transcript_sent_at IS NULL AND chats.archived_at IS NULL", expiredBefore).
+		Where("COALESCE(bookings.guest_email, '') <> ''").
+		Order("chats.expired_at ASC").
+		Limit(limit).
+		Pluck("chats.id", &chatIDs).Error; err != nil {
+		return nil, err
+	}
+
+	return chatIDs, nil
+}
+
+func (r *chatRepoImpl) FindAllChatIDsForRetention(ctx context.Context, expiredBefore time.Time, limit int) ([]int64, error) {
+	var chatIDs []int64
+	if err := r.db.WithContext(ctx).Model(&model.Chat{}).
+		Where("expired_at < ? AND archived_at IS NULL", expiredBefore).
+		Order("expired_at ASC").
+		Limit(limit).
+		Pluck("id", &chatIDs).Error; err != nil {
+		return nil, err
+	}
+
+	return chatIDs, nil
+}
+
+func (r *chatRepoImpl) DeleteMessagesByChatIDTx(tx *gorm.DB, chatID int64) error {
+	return tx.Where("chat_id = ?", chatID).Delete(&model.Message{}).Error
+}
+
+// messageContentHTML is messages.content with HTML metacharacters escaped,
+// so the <mark> tags added by ts_headline are the only markup in a highlight.
+const messageContentHTML = `replace(replace(replace(replace(replace(messages.content, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+
+func (r *chatRepoImpl) SearchMessagesPaginated(ctx context.Context, query types.MessageSearchQuery, departmentID *int64) ([]*types.MessageSearchResponse, int64, error) {
+	var results []*types.MessageSearchResponse
+	var total int64
+
+	tsQuery := "(websearch_to_tsquery('vietnamese', @q) || websearch_to_tsquery('english', @q))"
+
+	db := r.db.WithContext(ctx).Table("messages").
+		Joins("JOIN chats ON chats.id = messages.chat_id").
+		Joins("JOIN order_rooms ON order_rooms.id = chats.order_room_id").
+		Joins("JOIN rooms ON rooms.id = order_rooms.room_id").
+		Joins("JOIN bookings ON bookings.id = order_rooms.booking_id").
+		Where("messages.search_vector @@ "+tsQuery, sql.Named("q", query.Q))
+
+	if query.ChatID != 0 {
+		db = db.Where("messages.chat_id = ?", query.ChatID)
+	}
+
+	if departmentID != nil {
+		db = db.Where("chats.department_id = ?", *departmentID)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (query.Page - 1) * query.Limit
+	if err := db.Select(
+		"messages.id, messages.chat_id, messages.sender_type, messages.content, messages.created_at, "+
+			"ts_headline('vietnamese', "+messageContentHTML+", "+tsQuery+", 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2') AS highlight, "+
+			"ts_rank(messages.search_vector, "+tsQuery+") AS rank, "+
+			"chats.order_room_id, rooms.name AS room_name, bookings.booking_number, bookings.guest_full_name",
+		sql.Named("q", query.Q),
+	).
+		Order("rank DESC, messages.created_at DESC").
+		Limit(int(query.Limit)).
+		Offset(int(offset)).
+		Scan(&results).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+func (r *chatRepoImpl) CreateCannedResponse(ctx context.Context, cannedResponse *model.CannedResponse) error {
+	return r.db.WithContext(ctx).Create(cannedResponse).Error
+}
+
+func (r *chatRepoImpl) FindAllCannedResponsesWithDetails(ctx context.Context, query types.CannedResponseQuery) ([]*model.CannedResponse, error) {
+	var cannedResponses []*model.CannedResponse
+
+	db := r.db.WithContext(ctx).Preload("Department").Preload("CreatedBy").Preload("UpdatedBy")
+
+	if query.DepartmentID != 0 {
+		db = db.Where("department_id = ?", query.DepartmentID)
+	}
+
+	if query.Search != "" {
+		searchTerm := "%" + strings.ToLower(query.Search) + "%"
+		db = db.Where("LOWER(title) LIKE @q OR LOWER(shortcut) LIKE @q", sql.Named("q", searchTerm))
+	}
+
+	if err := db.Order("title ASC").Find(&cannedResponses).Error; err != nil {
+		return nil, err
+	}
+
+	return cannedResponses, nil
+}
+
+func (r *chatRepoImpl) FindCannedResponseByID(ctx context.Context, cannedResponseID int64) (*model.CannedResponse, error) {
+	var cannedResponse model.CannedResponse
+	if err := r.db.WithContext(ctx).Where("id = ?", cannedResponseID).First(&cannedResponse).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &cannedResponse, nil
+}
+
+func (r *chatRepoImpl) UpdateCannedResponse(ctx context.Context, cannedResponseID int64, updateData map[string]any) error {
+	result := r.db.WithContext(ctx).Model(&model.CannedResponse{}).Where("id = ?", cannedResponseID).Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return common.ErrCannedResponseNotFound
+	}
+
+	return nil
+}
+
+func (r *chatRepoImpl) DeleteCannedResponse(ctx context.Context, cannedResponseID int64) error {
+	result := r.db.WithContext(ctx).Where("id = ?", cannedResponseID).Delete(&model.CannedResponse{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return common.ErrCannedResponseNotFound
+	}
+
+	return nil
+}
+
+func (r *chatRepoImpl) CreateFAQ(ctx context.Context, faq *model.FAQ) error {
+	return r.db.WithContext(ctx).Create(faq).Error
+}
+
+func (r *chatRepoImpl) FindAllFAQsWithDetails(ctx context.Context, query types.FAQQuery) ([]*model.FAQ, error) {
+	var faqs []*model.FAQ
+
+	db := r.db.WithContext(ctx).Preload("CreatedBy").Preload("UpdatedBy")
+
+	if query.IsActive != nil {
+		db = db.Where("is_active = ?", *query.IsActive)
+	}
+
+	if query.Search != "" {
+		searchTerm := "%" + strings.ToLower(query.Search) + "%"
+		db = db.Where("LOWER(question) LIKE @q OR LOWER(keywords) LIKE @q", sql.Named("q", searchTerm))
+	}
+
+	if err := db.Order("question ASC").Find(&faqs).Error; err != nil {
+		return nil, err
+	}
+
+	return faqs, nil
+}
+
+func (r *chatRepoImpl) FindAllActiveFAQs(ctx context.Context) ([]*model.FAQ, error) {
+	var faqs []*model.FAQ
+	if err := r.db.WithContext(ctx).Where("is_active = true").Order("created_at ASC").Find(&faqs).Error; err != nil {
+		return nil, err
+	}
+
+	return faqs, nil
+}
+
+func (r *chatRepoImpl) FindFAQByID(ctx context.Context, faqID int64) (*model.FAQ, error) {
+	var faq model.FAQ
+	if err := r.db.WithContext(ctx).Where("id = ?", faqID).First(&faq).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &faq, nil
+}
+
+func (r *chatRepoImpl) UpdateFAQ(ctx context.Context, faqID int64, updateData map[string]any) error {
+	result := r.db.WithContext(ctx).Model(&model.FAQ{}).Where("id = ?", faqID).Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return common.ErrFAQNotFound
+	}
+
+	return nil
+}
+
+func (r *chatRepoImpl) DeleteFAQ(ctx context.Context, faqID int64) error {
+	result := r.db.WithContext(ctx).Where("id = ?", faqID).Delete(&model.FAQ{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return common.ErrFAQNotFound
+	}
+
+	return nil
+}
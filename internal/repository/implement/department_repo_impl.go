@@ -61,9 +61,21 @@ func (r *departmentRepoImpl) FindByID(ctx context.Context, id int64) (*model.Dep
 	return &department, nil
 }
 
+func (r *departmentRepoImpl) FindByNameWithStaffs(ctx context.Context, name string) (*model.Department, error) {
+	var department model.Department
+	if err := r.db.WithContext(ctx).Preload("Staffs").Where("name = ?", name).First(&department).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &department, nil
+}
+
 func (r *departmentRepoImpl) FindAllWithDetails(ctx context.Context) ([]*model.Department, error) {
 	var departments []*model.Department
-	if err := r.db.WithContext(ctx).Preload("CreatedBy").Preload("UpdatedBy").Order("name ASC").Find(&departments).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Head").Preload("CreatedBy").Preload("UpdatedBy").Order("name ASC").Find(&departments).Error; err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,155 @@
+package implement
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v1-be/internal/common"
+	"github.com/InstaySystem/is_v1-be/internal/model"
+	"github.com/InstaySystem/is_v1-be/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type fileRepoImpl struct {
+	db *gorm.DB
+}
+
+func NewFileRepository(db *gorm.DB) repository.FileRepository {
+	return &fileRepoImpl{db}
+}
+
+func (r *fileRepoImpl) CreateUploads(ctx context.Context, uploads []*model.Upload) error {
+	return r.db.WithContext(ctx).Create(&uploads).Error
+}
+
+func (r *fileRepoImpl) CountUploadsByOwnerSince(ctx context.Context, ownerType string, ownerID int64, purpose string, since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.Upload{}).
+		Where("owner_type = ? AND owner_id = ? AND purpose = ? AND created_at >= ?", ownerType, ownerID, purpose, since).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *fileRepoImpl) CountUploadsOwnedBy(ctx context.Context, keys []string, ownerType string, ownerID int64) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.Upload{}).
+		Where("key IN ? AND owner_type = ? AND owner_id = ?", keys, ownerType, ownerID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// FindKeysVisibleToGuest returns the subset of keys that the guest of
+// orderRoomID uploaded or that are attached to their own chat or requests,
+// plus service images and their variants, which every guest may see.
+func (r *fileRepoImpl) FindKeysVisibleToGuest(ctx context.Context, orderRoomID int64, keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	db := r.db.WithContext(ctx)
+
+	uploads := db.Model(&model.Upload{}).Select("key").
+		Where("key IN ? AND owner_type = ? AND owner_id = ?", keys, "guest", orderRoomID)
+
+	messageFiles := db.Model(&model.MessageAttachment{}).Select("UNNEST(ARRAY[message_attachments.key, message_attachments.thumbnail_key])").
+		Joins("JOIN messages ON messages.id = message_attachments.message_id").
+		Joins("JOIN chats ON chats.id = messages.chat_id").
+		Where("chats.order_room_id = ? AND (message_attachments.key IN ? OR message_attachments.thumbnail_key IN ?)", orderRoomID, keys, keys)
+
+	requestFiles := db.Model(&model.RequestAttachment{}).Select("request_attachments.key").
+		Joins("JOIN requests ON requests.id = request_attachments.request_id").
+		Where("requests.order_room_id = ? AND request_attachments.key IN ?", orderRoomID, keys)
+
+	serviceImages := db.Model(&model.ServiceImage{}).Select("key").Where("key IN ?", keys)
+
+	serviceImageVariants := db.Model(&model.ServiceImageVariant{}).Select("key").Where("key IN ?", keys)
+
+	var visible []string
+	if err := db.Raw("SELECT key FROM (? UNION ? UNION ? UNION ? UNION ?) AS visible WHERE key IN ?",
+		uploads, messageFiles, requestFiles, serviceImages, serviceImageVariants, keys).
+		Scan(&visible).Error; err != nil {
+		return nil, err
+	}
+
+	return visible, nil
+}
+
+func (r *fileRepoImpl) ConfirmUploadsTx(tx *gorm.DB, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	// Locking the rows keeps the sweeper, which skips locked rows, away from
+	// them until the transaction that claims them commits.
+	var uploads []*model.Upload
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("key", "status").
+		Where("key IN ?", keys).Find(&uploads).Error; err != nil {
+		return err
+	}
+
+	statuses := make(map[string]string, len(uploads))
+	pending := make([]string, 0, len(uploads))
+	for _, upload := range uploads {
+		statuses[upload.Key] = upload.Status
+		if upload.Status == common.UploadStatusPending {
+			pending = append(pending, upload.Key)
+		}
+	}
+	// A tracked key without a row was swept and its object queued for
+	// deletion; keys from before tracking never had one.
+	for _, key := range keys {
+		if _, ok := statuses[key]; !ok && common.IsTrackedUploadKey(key) {
+			return common.ErrUploadExpired
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	result := tx.Model(&model.Upload{}).Where("key IN ? AND status = ?", pending, common.UploadStatusPending).Updates(map[string]any{
+		"status":       common.UploadStatusConfirmed,
+		"confirmed_at": time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != int64(len(pending)) {
+		return common.ErrUploadExpired
+	}
+
+	return nil
+}
+
+func (r *fileRepoImpl) DeletePendingUploadsCreatedBefore(ctx context.Context, createdBefore time.Time, limit int) ([]string, error) {
+	var uploads []*model.Upload
+
+	// Rows being confirmed concurrently are locked and skipped, so a key is
+	// never both claimed by an entity and handed to the sweeper.
+	batch := r.db.Model(&model.Upload{}).Select("id").
+		Where("status = ? AND created_at < ?", common.UploadStatusPending, createdBefore).
+		Order("created_at").Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+
+	if err := r.db.WithContext(ctx).Clauses(clause.Returning{Columns: []clause.Column{{Name: "key"}}}).
+		Where("id IN (?)", batch).Delete(&uploads).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(uploads))
+	for _, upload := range uploads {
+		keys = append(keys, upload.Key)
+	}
+
+	return keys, nil
+}
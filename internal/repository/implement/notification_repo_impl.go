@@ -33,9 +33,23 @@ func (r *notificationRepoImpl) UpdateReadNotificationsByOrderRoomTx(tx *gorm.DB,
 	return tx.Model(&model.Notification{}).Where("order_room_id = ? AND receiver = ? AND is_read = false", orderRoomID, "guest").Updates(updateData).Error
 }
 
+// staffNotificationScope limits notifications to the ones a staff member
+// sees: those shared with their department plus those addressed to them.
+// Staff without a department, such as admins, only get the latter.
+func staffNotificationScope(staffID int64, departmentID *int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("receiver = ?", "staff")
+		if departmentID == nil {
+			return db.Where("staff_id = ?", staffID)
+		}
+		return db.Where("((department_id = ? AND staff_id IS NULL) OR staff_id = ?)", *departmentID, staffID)
+	}
+}
+
 func (r *notificationRepoImpl) FindAllUnreadNotificationsByContentIDAndType(ctx context.Context, staffID, contentID int64, contentType string) ([]*model.Notification, error) {
 	var notifications []*model.Notification
-	if err := r.db.WithContext(ctx).Where("content_id = ? AND type = ? AND receiver = ?", contentID, contentType, "staff").Where("id NOT IN (?)",
+	if err := r.db.WithContext(ctx).Where("content_id = ? AND type = ? AND receiver = ?", contentID, contentType, "staff").
+		Where("(staff_id IS NULL OR staff_id = ?)", staffID).Where("id NOT IN (?)",
 		r.db.Model(&model.NotificationStaff{}).
 			Select("notification_id").
 			Where("staff_id = ?", staffID),
@@ -64,9 +78,9 @@ func (r *notificationRepoImpl) FindAllUnreadNotificationIDsByOrderRoomIDTx(tx *g
 	return ids, nil
 }
 
-func (r *notificationRepoImpl) FindAllUnreadNotificationIDsByDepartmentIDTx(tx *gorm.DB, staffID, departmentID int64) ([]int64, error) {
+func (r *notificationRepoImpl) FindAllUnreadNotificationIDsByDepartmentIDTx(tx *gorm.DB, staffID int64, departmentID *int64) ([]int64, error) {
 	var ids []int64
-	if err := tx.Where("department_id = ? AND receiver = ?", departmentID, "staff").Where("id NOT IN (?)",
+	if err := tx.Scopes(staffNotificationScope(staffID, departmentID)).Where("id NOT IN (?)",
 		tx.Model(&model.NotificationStaff{}).
 			Select("notification_id").
 			Where("staff_id = ?", staffID),
@@ -77,9 +91,9 @@ func (r *notificationRepoImpl) FindAllUnreadNotificationIDsByDepartmentIDTx(tx *
 	return ids, nil
 }
 
-func (r *notificationRepoImpl) FindAllUnreadNotificationsByDepartmentID(ctx context.Context, staffID, departmentID int64) ([]*model.Notification, error) {
+func (r *notificationRepoImpl) FindAllUnreadNotificationsByDepartmentID(ctx context.Context, staffID int64, departmentID *int64) ([]*model.Notification, error) {
 	var notifications []*model.Notification
-	if err := r.db.WithContext(ctx).Where("department_id = ? AND receiver = ?", departmentID, "staff").Where("id NOT IN (?)",
+	if err := r.db.WithContext(ctx).Scopes(staffNotificationScope(staffID, departmentID)).Where("id NOT IN (?)",
 		r.db.Model(&model.NotificationStaff{}).
 			Select("notification_id").
 			Where("staff_id = ?", staffID),
@@ -90,9 +104,9 @@ func (r *notificationRepoImpl) FindAllUnreadNotificationsByDepartmentID(ctx cont
 	return notifications, nil
 }
 
-func (r *notificationRepoImpl) CountUnreadNotificationsByDepartmentID(ctx context.Context, userID, departmentID int64) (int64, error) {
+func (r *notificationRepoImpl) CountUnreadNotificationsByDepartmentID(ctx context.Context, userID int64, departmentID *int64) (int64, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&model.Notification{}).Where("department_id = ? AND receiver = ?", departmentID, "staff").
+	if err := r.db.WithContext(ctx).Model(&model.Notification{}).Scopes(staffNotificationScope(userID, departmentID)).
 		Where("id NOT IN (?)",
 			r.db.Model(&model.NotificationStaff{}).
 				Select("notification_id").
@@ -117,11 +131,11 @@ func (r *notificationRepoImpl) UpdateNotificationsByOrderRoomIDAndType(ctx conte
 	return r.db.WithContext(ctx).Model(&model.Notification{}).Where("order_room_id = ? AND type = ? AND receiver = ?", orderRoomID, contentType, "guest").Updates(updateData).Error
 }
 
-func (r *notificationRepoImpl) FindAllNotificationsByDepartmentIDWithStaffsReadPaginatedTx(tx *gorm.DB, query types.NotificationPaginationQuery, staffID, departmentID int64) ([]*model.Notification, int64, error) {
+func (r *notificationRepoImpl) FindAllNotificationsByDepartmentIDWithStaffsReadPaginatedTx(tx *gorm.DB, query types.NotificationPaginationQuery, staffID int64, departmentID *int64) ([]*model.Notification, int64, error) {
 	var notifications []*model.Notification
 	var total int64
 
-	db := tx.Where("department_id = ? AND receiver = ?", departmentID, "staff").Model(&model.Notification{})
+	db := tx.Scopes(staffNotificationScope(staffID, departmentID)).Model(&model.Notification{})
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
@@ -29,6 +29,10 @@ func (r *orderRepoImpl) CreateOrderServiceTx(tx *gorm.DB, orderService *model.Or
 	return tx.Create(orderService).Error
 }
 
+func (r *orderRepoImpl) CreateOrderServiceOptionsTx(tx *gorm.DB, options []*model.OrderServiceOption) error {
+	return tx.Create(&options).Error
+}
+
 func (r *orderRepoImpl) FindOrderRoomByIDWithRoom(ctx context.Context, orderRoomID int64) (*model.OrderRoom, error) {
 	var orderRoom model.OrderRoom
 	if err := r.db.WithContext(ctx).Preload("Room").Where("id = ?", orderRoomID).First(&orderRoom).Error; err != nil {
@@ -63,6 +67,16 @@ func (r *orderRepoImpl) OrderServiceStatusDistribution(ctx context.Context) ([]*
 	return results, nil
 }
 
+func (r *orderRepoImpl) GetServiceRevenueByPricingRule(ctx context.Context) ([]*types.PricingRuleRevenueChartData, error) {
+	results := make([]*types.PricingRuleRevenueChartData, 0)
+	err := r.db.WithContext(ctx).Model(&model.OrderService{}).
+		Select("COALESCE(pricing_rule_name, '') as pricing_rule_name, COUNT(*) as count, COALESCE(SUM(list_price), 0) as list_revenue, COALESCE(SUM(total_price), 0) as revenue").
+		Where("status = ?", "accepted").
+		Group("COALESCE(pricing_rule_name, '')").
+		Scan(&results).Error
+	return results, err
+}
+
 func (r *orderRepoImpl) FindOrderRoomByIDWithBookingTx(tx *gorm.DB, orderRoomID int64) (*model.OrderRoom, error) {
 	var orderRoom model.OrderRoom
 	if err := tx.Preload("Booking").Where("id = ?", orderRoomID).First(&orderRoom).Error; err != nil {
@@ -92,7 +106,7 @@ func (r *orderRepoImpl) FindOrderServiceByIDWithServiceDetailsAndOrderRoomDetail
 	if err := tx.Clauses(clause.Locking{
 		Strength: clause.LockingStrengthUpdate,
 		Options:  clause.LockingOptionsNoWait,
-	}).Preload("Service.ServiceType.Department.Staffs").Preload("OrderRoom.Booking").Where("id = ?", orderServiceID).First(&orderService).Error; err != nil {
+	}).Preload("Service.ServiceType.Department.Staffs").Preload("OrderRoom.Booking").Preload("OrderRoom.Room").Where("id = ?", orderServiceID).First(&orderService).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -108,7 +122,7 @@ func (r *orderRepoImpl) UpdateOrderServiceTx(tx *gorm.DB, orderServiceID int64,
 
 func (r *orderRepoImpl) FindOrderServiceByIDWithDetails(ctx context.Context, orderServiceID int64) (*model.OrderService, error) {
 	var orderService model.OrderService
-	if err := r.db.WithContext(ctx).Preload("Service.ServiceType").Preload("Service.ServiceImages", "is_thumbnail = true").Preload("OrderRoom.Room.RoomType").Preload("OrderRoom.Room.Floor").Preload("UpdatedBy").Where("id = ?", orderServiceID).First(&orderService).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Service.ServiceType").Preload("Service.ServiceImages", "is_thumbnail = true").Preload("OrderRoom.Room.RoomType").Preload("OrderRoom.Room.Floor").Preload("UpdatedBy").Preload("Assignee").Preload("Options").Where("id = ?", orderServiceID).First(&orderService).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -120,20 +134,30 @@ func (r *orderRepoImpl) FindOrderServiceByIDWithDetails(ctx context.Context, ord
 
 func (r *orderRepoImpl) FindAllOrderServicesByOrderRoomIDWithDetails(ctx context.Context, orderRoomID int64) ([]*model.OrderService, error) {
 	var orderServices []*model.OrderService
-	if err := r.db.WithContext(ctx).Preload("Service.ServiceType").Preload("Service.ServiceImages", "is_thumbnail = true").Where("order_room_id = ?", orderRoomID).Find(&orderServices).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Service.ServiceType").Preload("Service.ServiceImages", "is_thumbnail = true").Preload("Options").Where("order_room_id = ?", orderRoomID).Find(&orderServices).Error; err != nil {
 		return nil, err
 	}
 
 	return orderServices, nil
 }
 
-func (r *orderRepoImpl) FindAllOrderServicesWithDetailsPaginated(ctx context.Context, query types.OrderServicePaginationQuery, departmentID *int64) ([]*model.OrderService, int64, error) {
+func (r *orderRepoImpl) FindAllOrderServicesWithDetailsPaginated(ctx context.Context, query types.OrderServicePaginationQuery, staffID int64, departmentID *int64) ([]*model.OrderService, int64, error) {
 	var orderServices []*model.OrderService
 	var total int64
 
-	db := r.db.WithContext(ctx).Preload("OrderRoom.Room").Preload("Service.ServiceType").Model(&model.OrderService{})
+	db := r.db.WithContext(ctx).Preload("OrderRoom.Room").Preload("Service.ServiceType").Preload("Assignee").Model(&model.OrderService{})
 	db = applyOrderServiceFilters(db, query)
 
+	switch query.Assignee {
+	case "mine":
+		db = db.Where("order_services.assignee_id = ?", staffID)
+	case "unassigned":
+		db = db.Where("order_services.assignee_id IS NULL")
+	}
+	if query.AssigneeID != 0 {
+		db = db.Where("order_services.assignee_id = ?", query.AssigneeID)
+	}
+
 	if departmentID != nil {
 		db = db.Joins("JOIN services s ON s.id = order_services.service_id").
 			Joins("JOIN service_types st ON st.id = s.service_type_id").
@@ -153,6 +177,96 @@ func (r *orderRepoImpl) FindAllOrderServicesWithDetailsPaginated(ctx context.Con
 	return orderServices, total, nil
 }
 
+func (r *orderRepoImpl) CreateServiceOrderTx(tx *gorm.DB, serviceOrder *model.ServiceOrder) error {
+	return tx.Omit("Lines").Create(serviceOrder).Error
+}
+
+func (r *orderRepoImpl) FindServiceOrderByIDWithLinesAndOrderRoomDetailsTx(tx *gorm.DB, serviceOrderID int64) (*model.ServiceOrder, error) {
+	var serviceOrder model.ServiceOrder
+	if err := tx.Clauses(clause.Locking{
+		Strength: clause.LockingStrengthUpdate,
+		Options:  clause.LockingOptionsNoWait,
+	}).Preload("Lines.Service").Preload("Department.Staffs").Preload("OrderRoom.Booking").Preload("OrderRoom.Room").Where("id = ?", serviceOrderID).First(&serviceOrder).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &serviceOrder, nil
+}
+
+func (r *orderRepoImpl) UpdateServiceOrderTx(tx *gorm.DB, serviceOrderID int64, updateData map[string]any) error {
+	return tx.Model(&model.ServiceOrder{}).Where("id = ?", serviceOrderID).Updates(updateData).Error
+}
+
+func (r *orderRepoImpl) UpdateOrderServicesByServiceOrderIDTx(tx *gorm.DB, serviceOrderID int64, excludeIDs []int64, updateData map[string]any) error {
+	db := tx.Model(&model.OrderService{}).Where("service_order_id = ?", serviceOrderID)
+	if len(excludeIDs) > 0 {
+		db = db.Where("id NOT IN ?", excludeIDs)
+	}
+
+	return db.Updates(updateData).Error
+}
+
+func (r *orderRepoImpl) FindServiceOrderByIDWithDetails(ctx context.Context, serviceOrderID int64) (*model.ServiceOrder, error) {
+	var serviceOrder model.ServiceOrder
+	if err := r.db.WithContext(ctx).Preload("Lines.Service.ServiceImages", "is_thumbnail = true").Preload("Lines.Options").Preload("OrderRoom.Room.RoomType").Preload("OrderRoom.Room.Floor").Preload("UpdatedBy").Where("id = ?", serviceOrderID).First(&serviceOrder).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &serviceOrder, nil
+}
+
+func (r *orderRepoImpl) FindAllServiceOrdersByOrderRoomIDWithDetails(ctx context.Context, orderRoomID int64) ([]*model.ServiceOrder, error) {
+	var serviceOrders []*model.ServiceOrder
+	if err := r.db.WithContext(ctx).Preload("Lines.Service.ServiceImages", "is_thumbnail = true").Preload("Lines.Options").Where("order_room_id = ?", orderRoomID).Order("created_at DESC").Find(&serviceOrders).Error; err != nil {
+		return nil, err
+	}
+
+	return serviceOrders, nil
+}
+
+func (r *orderRepoImpl) FindAllServiceOrdersWithDetailsPaginated(ctx context.Context, query types.ServiceOrderPaginationQuery, departmentID *int64) ([]*model.ServiceOrder, int64, error) {
+	var serviceOrders []*model.ServiceOrder
+	var total int64
+
+	db := r.db.WithContext(ctx).Preload("OrderRoom.Room").Preload("Lines").Model(&model.ServiceOrder{})
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if departmentID != nil {
+		db = db.Where("department_id = ?", *departmentID)
+	}
+
+	const layout = "2006-01-02"
+	if parsedFrom, err := time.Parse(layout, query.From); err == nil {
+		db = db.Where("created_at >= ?", parsedFrom)
+	}
+	if parsedTo, err := time.Parse(layout, query.To); err == nil {
+		db = db.Where("created_at < ?", parsedTo.AddDate(0, 0, 1))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "DESC"
+	if query.Order == "asc" {
+		order = "ASC"
+	}
+
+	offset := (query.Page - 1) * query.Limit
+	if err := db.Order("created_at " + order).Offset(int(offset)).Limit(int(query.Limit)).Find(&serviceOrders).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return serviceOrders, total, nil
+}
+
 func applyOrderServiceSorting(db *gorm.DB, query types.OrderServicePaginationQuery) *gorm.DB {
 	if query.Sort == "" {
 		query.Sort = "created_at"
@@ -11,6 +11,7 @@ import (
 	"github.com/InstaySystem/is_v1-be/internal/repository"
 	"github.com/InstaySystem/is_v1-be/internal/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type requestRepoImpl struct {
@@ -27,7 +28,7 @@ func (r *requestRepoImpl) CreateRequestType(ctx context.Context, requestType *mo
 
 func (r *requestRepoImpl) FindAllRequestTypesWithDetails(ctx context.Context) ([]*model.RequestType, error) {
 	var requestTypes []*model.RequestType
-	if err := r.db.WithContext(ctx).Preload("Department").Preload("CreatedBy").Preload("UpdatedBy").Order("name ASC").Find(&requestTypes).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Department").Preload("CreatedBy").Preload("UpdatedBy").Preload("Translations").Order("name ASC").Find(&requestTypes).Error; err != nil {
 		return nil, err
 	}
 
@@ -46,7 +47,7 @@ func (r *requestRepoImpl) RequestStatusDistribution(ctx context.Context) ([]*typ
 
 func (r *requestRepoImpl) FindAllRequestTypes(ctx context.Context) ([]*model.RequestType, error) {
 	var requestTypes []*model.RequestType
-	if err := r.db.WithContext(ctx).Order("name ASC").Find(&requestTypes).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Translations").Order("name ASC").Find(&requestTypes).Error; err != nil {
 		return nil, err
 	}
 
@@ -103,13 +104,60 @@ func (r *requestRepoImpl) DeleteRequestType(ctx context.Context, requestTypeID i
 	return nil
 }
 
-func (r *requestRepoImpl) CreateRequest(ctx context.Context, request *model.Request) error {
-	return r.db.WithContext(ctx).Create(request).Error
+func (r *requestRepoImpl) UpsertRequestTypeTranslation(ctx context.Context, translation *model.RequestTypeTranslation) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "request_type_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+	}).Create(translation).Error
+}
+
+func (r *requestRepoImpl) DeleteRequestTypeTranslation(ctx context.Context, requestTypeID int64, locale string) error {
+	result := r.db.WithContext(ctx).Where("request_type_id = ? AND locale = ?", requestTypeID, locale).Delete(&model.RequestTypeTranslation{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return common.ErrTranslationNotFound
+	}
+
+	return nil
+}
+
+func (r *requestRepoImpl) CreateRequestTx(tx *gorm.DB, request *model.Request) error {
+	return tx.Create(request).Error
+}
+
+func (r *requestRepoImpl) CreateRequestAttachmentsTx(tx *gorm.DB, attachments []*model.RequestAttachment) error {
+	return tx.Create(attachments).Error
+}
+
+func (r *requestRepoImpl) CreateRequestNoteTx(tx *gorm.DB, note *model.RequestNote) error {
+	return tx.Create(note).Error
+}
+
+func (r *requestRepoImpl) CreateRequestNote(ctx context.Context, note *model.RequestNote) error {
+	return r.db.WithContext(ctx).Create(note).Error
+}
+
+func (r *requestRepoImpl) FindRequestByIDWithRequestType(ctx context.Context, requestID int64) (*model.Request, error) {
+	var request model.Request
+	if err := r.db.WithContext(ctx).Preload("RequestType").Where("id = ?", requestID).First(&request).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &request, nil
 }
 
 func (r *requestRepoImpl) FindRequestByIDWithRequestTypeDetailsAndOrderRoomDetailsTx(tx *gorm.DB, requestID int64) (*model.Request, error) {
 	var request model.Request
-	if err := tx.Preload("RequestType.Department.Staffs").Preload("OrderRoom.Booking").Where("id = ?", requestID).First(&request).Error; err != nil {
+	if err := tx.Clauses(clause.Locking{
+		Strength: clause.LockingStrengthUpdate,
+		Options:  clause.LockingOptionsNoWait,
+	}).Preload("RequestType.Department.Staffs").Preload("OrderRoom.Booking").Preload("OrderRoom.Room").Where("id = ?", requestID).First(&request).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -134,7 +182,14 @@ func (r *requestRepoImpl) FindAllRequestsByOrderRoomIDWithDetails(ctx context.Co
 
 func (r *requestRepoImpl) FindRequestByIDWithDetails(ctx context.Context, requestID int64) (*model.Request, error) {
 	var request model.Request
-	if err := r.db.WithContext(ctx).Preload("OrderRoom.Room.RoomType").Preload("OrderRoom.Room.Floor").Preload("UpdatedBy").Preload("RequestType.Department.Staffs").Where("id = ?", requestID).First(&request).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("OrderRoom.Room.RoomType").Preload("OrderRoom.Room.Floor").Preload("UpdatedBy").Preload("Assignee").Preload("RequestType.Department.Staffs").
+		Preload("Attachments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
+		Preload("Notes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Notes.Staff").Where("id = ?", requestID).First(&request).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -144,13 +199,23 @@ func (r *requestRepoImpl) FindRequestByIDWithDetails(ctx context.Context, reques
 	return &request, nil
 }
 
-func (r *requestRepoImpl) FindAllRequestsWithDetailsPaginated(ctx context.Context, query types.RequestPaginationQuery, departmentID *int64) ([]*model.Request, int64, error) {
+func (r *requestRepoImpl) FindAllRequestsWithDetailsPaginated(ctx context.Context, query types.RequestPaginationQuery, staffID int64, departmentID *int64) ([]*model.Request, int64, error) {
 	var requests []*model.Request
 	var total int64
 
-	db := r.db.WithContext(ctx).Preload("OrderRoom.Room").Preload("RequestType").Model(&model.Request{})
+	db := r.db.WithContext(ctx).Preload("OrderRoom.Room").Preload("RequestType").Preload("Assignee").Model(&model.Request{})
 	db = applyRequestFilters(db, query)
 
+	switch query.Assignee {
+	case "mine":
+		db = db.Where("requests.assignee_id = ?", staffID)
+	case "unassigned":
+		db = db.Where("requests.assignee_id IS NULL")
+	}
+	if query.AssigneeID != 0 {
+		db = db.Where("requests.assignee_id = ?", query.AssigneeID)
+	}
+
 	if departmentID != nil {
 		db = db.Joins("JOIN request_types rt ON rt.id = requests.request_type_id").
 			Where("rt.department_id = ?", *departmentID)
@@ -169,11 +234,116 @@ func (r *requestRepoImpl) FindAllRequestsWithDetailsPaginated(ctx context.Contex
 	return requests, total, nil
 }
 
+func (r *requestRepoImpl) FindAllRequestsBreachingSLA(ctx context.Context, now time.Time, limit int) ([]*model.Request, error) {
+	var requests []*model.Request
+	if err := r.db.WithContext(ctx).Preload("OrderRoom.Room").Preload("RequestType.Department.Head").
+		Where("(status = 'pending' AND accept_breached_at IS NULL AND accept_due_at < ?) OR (status IN ('pending', 'accepted') AND complete_breached_at IS NULL AND complete_due_at < ?)", now, now).
+		Order("created_at ASC").Limit(limit).Find(&requests).Error; err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+func (r *requestRepoImpl) MarkRequestSLABreachedTx(tx *gorm.DB, requestID int64, column string, breachedAt time.Time) (bool, error) {
+	result := tx.Model(&model.Request{}).Where("id = ? AND "+column+" IS NULL", requestID).Update(column, breachedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *requestRepoImpl) SLAComplianceByDepartment(ctx context.Context) ([]*types.SLAComplianceResponse, error) {
+	breached := "(requests.accept_breached_at IS NOT NULL OR requests.complete_breached_at IS NOT NULL OR requests.accepted_at > requests.accept_due_at OR requests.done_at > requests.complete_due_at)"
+
+	results := make([]*types.SLAComplianceResponse, 0)
+	err := r.db.WithContext(ctx).Table("requests").
+		Select("departments.id as department_id, departments.display_name as department_name, COUNT(requests.id) as total, COUNT(requests.id) FILTER (WHERE "+breached+") as breached").
+		Joins("JOIN request_types ON request_types.id = requests.request_type_id").
+		Joins("JOIN departments ON departments.id = request_types.department_id").
+		Where("requests.accept_due_at IS NOT NULL OR requests.complete_due_at IS NOT NULL").
+		Where("requests.status = 'done' OR "+breached).
+		Group("departments.id, departments.display_name").
+		Order("departments.display_name ASC").
+		Scan(&results).Error
+	return results, err
+}
+
+func (r *requestRepoImpl) CreateRequestSchedule(ctx context.Context, schedule *model.RequestSchedule) error {
+	return r.db.WithContext(ctx).Create(schedule).Error
+}
+
+func (r *requestRepoImpl) FindAllRequestSchedulesByOrderRoomIDWithDetails(ctx context.Context, orderRoomID int64) ([]*model.RequestSchedule, error) {
+	var schedules []*model.RequestSchedule
+	if err := r.db.WithContext(ctx).Preload("RequestType").Where("order_room_id = ?", orderRoomID).Order("next_run_at ASC").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func (r *requestRepoImpl) CancelRequestSchedule(ctx context.Context, scheduleID, orderRoomID int64, cancelledAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.RequestSchedule{}).
+		Where("id = ? AND order_room_id = ? AND status = 'active'", scheduleID, orderRoomID).
+		Updates(map[string]any{"status": "cancelled", "cancelled_at": cancelledAt})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *requestRepoImpl) CancelRequestSchedulesAfterCheckOut(ctx context.Context, now time.Time) (int64, error) {
+	checkedOut := r.db.Table("order_rooms").Select("order_rooms.id").
+		Joins("JOIN bookings ON bookings.id = order_rooms.booking_id").
+		Where("bookings.check_out <= ?", now)
+
+	result := r.db.WithContext(ctx).Model(&model.RequestSchedule{}).
+		Where("status = 'active' AND order_room_id IN (?)", checkedOut).
+		Updates(map[string]any{"status": "cancelled", "cancelled_at": now})
+	return result.RowsAffected, result.Error
+}
+
+func (r *requestRepoImpl) FindAllDueRequestScheduleIDs(ctx context.Context, now time.Time, limit int) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Model(&model.RequestSchedule{}).Where("status = 'active' AND next_run_at <= ?", now).
+		Order("next_run_at ASC").Limit(limit).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *requestRepoImpl) FindDueRequestScheduleByIDWithDetailsTx(tx *gorm.DB, scheduleID int64, now time.Time) (*model.RequestSchedule, error) {
+	var schedule model.RequestSchedule
+	if err := tx.Clauses(clause.Locking{
+		Strength: clause.LockingStrengthUpdate,
+		Options:  clause.LockingOptionsSkipLocked,
+	}).Preload("RequestType.Department.Staffs").Preload("OrderRoom.Room").Preload("OrderRoom.Booking").
+		Where("id = ? AND status = 'active' AND next_run_at <= ?", scheduleID, now).First(&schedule).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (r *requestRepoImpl) UpdateRequestScheduleTx(tx *gorm.DB, scheduleID int64, updateData map[string]any) error {
+	return tx.Model(&model.RequestSchedule{}).Where("id = ?", scheduleID).Updates(updateData).Error
+}
+
 func applyRequestFilters(db *gorm.DB, query types.RequestPaginationQuery) *gorm.DB {
 	if query.Status != "" {
 		db = db.Where("status = ?", query.Status)
 	}
 
+	if query.Priority != "" {
+		db = db.Where("requests.priority = ?", query.Priority)
+	}
+
 	if query.From != "" || query.To != "" {
 		const layout = "2006-01-02"
 
@@ -37,6 +37,18 @@ func (r *reviewRepoImpl) FindByOrderRoomID(ctx context.Context, orderRoomID int6
 	return &review, nil
 }
 
+func (r *reviewRepoImpl) FindByID(ctx context.Context, id int64) (*model.Review, error) {
+	var review model.Review
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&review).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &review, nil
+}
+
 func (r *reviewRepoImpl) AverageRating(ctx context.Context) (float64, error) {
 	var avg float64
 
@@ -70,7 +82,26 @@ func (r *reviewRepoImpl) FindAllPaginated(ctx context.Context, query types.Revie
 
 	db = applyReviewSorting(db, query)
 	offset := (query.Page - 1) * query.Limit
-	if err := db.Offset(int(offset)).Limit(int(query.Limit)).Find(&reviews).Error; err != nil {
+	if err := db.Preload("ModeratedBy").Preload("RespondedBy").Offset(int(offset)).Limit(int(query.Limit)).Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+func (r *reviewRepoImpl) FindAllApprovedPaginated(ctx context.Context, query types.PublicReviewPaginationQuery) ([]*model.Review, int64, error) {
+	var reviews []*model.Review
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&model.Review{}).Where("status = ?", common.ReviewStatusApproved)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (query.Page - 1) * query.Limit
+	if err := db.Order("is_featured DESC").Order("created_at DESC").
+		Offset(int(offset)).Limit(int(query.Limit)).Find(&reviews).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -95,6 +126,14 @@ func applyReviewFilters(db *gorm.DB, query types.ReviewPaginationQuery) *gorm.DB
 		}
 	}
 
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	if query.IsFeatured != nil {
+		db = db.Where("is_featured = ?", *query.IsFeatured)
+	}
+
 	return db
 }
 
@@ -5,12 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/common"
 	"github.com/InstaySystem/is_v1-be/internal/model"
 	"github.com/InstaySystem/is_v1-be/internal/repository"
 	"github.com/InstaySystem/is_v1-be/internal/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type serviceRepoImpl struct {
@@ -27,7 +29,7 @@ func (r *serviceRepoImpl) CreateServiceType(ctx context.Context, serviceType *mo
 
 func (r *serviceRepoImpl) FindAllServiceTypesWithDetails(ctx context.Context) ([]*model.ServiceType, error) {
 	var serviceTypes []*model.ServiceType
-	if err := r.db.WithContext(ctx).Preload("Department").Preload("CreatedBy").Preload("UpdatedBy").Order("name ASC").Find(&serviceTypes).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Department").Preload("CreatedBy").Preload("UpdatedBy").Preload("OpeningHours").Preload("Translations").Order("name ASC").Find(&serviceTypes).Error; err != nil {
 		return nil, err
 	}
 
@@ -66,7 +68,7 @@ func (r *serviceRepoImpl) CountServiceByServiceTypeID(ctx context.Context, servi
 
 func (r *serviceRepoImpl) FindAllServiceType(ctx context.Context) ([]*model.ServiceType, error) {
 	var serviceTypes []*model.ServiceType
-	if err := r.db.WithContext(ctx).Find(&serviceTypes).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Translations").Find(&serviceTypes).Error; err != nil {
 		return nil, err
 	}
 
@@ -87,7 +89,7 @@ func (r *serviceRepoImpl) FindServiceTypeByID(ctx context.Context, serviceTypeID
 
 func (r *serviceRepoImpl) FindServiceByIDWithServiceImages(ctx context.Context, serviceID int64) (*model.Service, error) {
 	var service model.Service
-	if err := r.db.WithContext(ctx).Preload("ServiceImages").Where("id = ?", serviceID).First(&service).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("ServiceImages.Variants").Where("id = ?", serviceID).First(&service).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -112,7 +114,7 @@ func (r *serviceRepoImpl) UpdateServiceType(ctx context.Context, serviceTypeID i
 
 func (r *serviceRepoImpl) FindServiceByIDWithDetails(ctx context.Context, serviceID int64) (*model.Service, error) {
 	var service model.Service
-	if err := r.db.WithContext(ctx).Preload("ServiceImages").Preload("ServiceType").Preload("CreatedBy").Preload("UpdatedBy").Where("id = ?", serviceID).First(&service).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("ServiceImages.Variants").Preload("ServiceType").Preload("CreatedBy").Preload("UpdatedBy").Preload("OpeningHours").Preload("Translations").Scopes(preloadServiceOptionGroups).Where("id = ?", serviceID).First(&service).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -124,7 +126,7 @@ func (r *serviceRepoImpl) FindServiceByIDWithDetails(ctx context.Context, servic
 
 func (r *serviceRepoImpl) FindServiceByIDWithServiceTypeDetails(ctx context.Context, serviceID int64) (*model.Service, error) {
 	var service model.Service
-	if err := r.db.WithContext(ctx).Preload("ServiceType.Department.Staffs").Where("id = ?", serviceID).First(&service).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("ServiceType.Department.Staffs").Preload("ServiceType.OpeningHours").Preload("OpeningHours").Scopes(preloadServiceOptionGroups).Where("id = ?", serviceID).First(&service).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -149,7 +151,7 @@ func (r *serviceRepoImpl) DeleteServiceType(ctx context.Context, serviceTypeID i
 
 func (r *serviceRepoImpl) FindServiceBySlugWithServiceTypeAndServiceImages(ctx context.Context, serviceSlug string) (*model.Service, error) {
 	var service model.Service
-	if err := r.db.WithContext(ctx).Preload("ServiceType").Preload("ServiceImages").Where("slug = ? AND is_active = true", serviceSlug).First(&service).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("ServiceType.OpeningHours").Preload("ServiceType.Translations").Preload("ServiceImages.Variants").Preload("OpeningHours").Preload("Translations").Scopes(preloadServiceOptionGroups).Where("slug = ? AND is_active = true", serviceSlug).First(&service).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -163,9 +165,214 @@ func (r *serviceRepoImpl) CreateService(ctx context.Context, service *model.Serv
 	return r.db.WithContext(ctx).Create(service).Error
 }
 
+func (r *serviceRepoImpl) ReplaceServiceTypeOpeningHoursTx(tx *gorm.DB, serviceTypeID int64, hours []*model.ServiceOpeningHour) error {
+	if err := tx.Where("service_type_id = ?", serviceTypeID).Delete(&model.ServiceOpeningHour{}).Error; err != nil {
+		return err
+	}
+	if len(hours) == 0 {
+		return nil
+	}
+
+	return tx.Create(&hours).Error
+}
+
+func (r *serviceRepoImpl) ReplaceServiceOpeningHoursTx(tx *gorm.DB, serviceID int64, hours []*model.ServiceOpeningHour) error {
+	if err := tx.Where("service_id = ?", serviceID).Delete(&model.ServiceOpeningHour{}).Error; err != nil {
+		return err
+	}
+	if len(hours) == 0 {
+		return nil
+	}
+
+	return tx.Create(&hours).Error
+}
+
+func (r *serviceRepoImpl) ReplaceServiceOptionGroupsTx(tx *gorm.DB, serviceID int64, groups []*model.ServiceOptionGroup) error {
+	if err := tx.Where("service_id = ?", serviceID).Delete(&model.ServiceOptionGroup{}).Error; err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+
+	return tx.Create(&groups).Error
+}
+
+func (r *serviceRepoImpl) FindAllServiceSlotsByServiceIDBetween(ctx context.Context, serviceID int64, from, to time.Time) ([]*model.ServiceSlot, error) {
+	var slots []*model.ServiceSlot
+	if err := r.db.WithContext(ctx).Where("service_id = ? AND start_at >= ? AND start_at < ?", serviceID, from, to).Find(&slots).Error; err != nil {
+		return nil, err
+	}
+
+	return slots, nil
+}
+
+func (r *serviceRepoImpl) FindMaxUpcomingSlotReservedTx(tx *gorm.DB, serviceID int64, since time.Time) (uint32, error) {
+	var reserved uint32
+	if err := tx.Model(&model.ServiceSlot{}).Select("COALESCE(MAX(reserved), 0)").
+		Where("service_id = ? AND start_at >= ?", serviceID, since).
+		Scan(&reserved).Error; err != nil {
+		return 0, err
+	}
+
+	return reserved, nil
+}
+
+func (r *serviceRepoImpl) ReserveServiceSlotTx(tx *gorm.DB, slot *model.ServiceSlot, capacity uint32) (bool, error) {
+	result := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "service_id"}, {Name: "start_at"}},
+		DoUpdates: clause.Assignments(map[string]any{"reserved": gorm.Expr("service_slots.reserved + EXCLUDED.reserved"), "updated_at": gorm.Expr("EXCLUDED.updated_at")}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			gorm.Expr("service_slots.reserved + EXCLUDED.reserved <= ?", capacity),
+		}},
+	}).Create(slot)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *serviceRepoImpl) ReleaseServiceSlotTx(tx *gorm.DB, serviceID int64, startAt time.Time, quantity uint32) error {
+	return tx.Model(&model.ServiceSlot{}).Where("service_id = ? AND start_at = ?", serviceID, startAt).
+		Update("reserved", gorm.Expr("GREATEST(reserved - ?, 0)", quantity)).Error
+}
+
+func (r *serviceRepoImpl) DecrementServiceStockTx(tx *gorm.DB, serviceID int64, quantity uint32) (*model.Service, error) {
+	var service model.Service
+	result := tx.Model(&service).Clauses(clause.Returning{Columns: []clause.Column{{Name: "stock"}, {Name: "low_stock_threshold"}}}).
+		Where("id = ? AND stock >= ?", serviceID, quantity).
+		Update("stock", gorm.Expr("stock - ?", quantity))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &service, nil
+}
+
+func (r *serviceRepoImpl) IncrementServiceStockTx(tx *gorm.DB, serviceID int64, quantity uint32) error {
+	return tx.Model(&model.Service{}).Where("id = ? AND stock IS NOT NULL", serviceID).
+		Update("stock", gorm.Expr("stock + ?", quantity)).Error
+}
+
+func (r *serviceRepoImpl) AdjustServiceStock(ctx context.Context, serviceID, userID int64, delta int32) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.Service{}).Where("id = ? AND stock IS NOT NULL AND stock + ? >= 0", serviceID, delta).
+		Updates(map[string]any{
+			"stock":         gorm.Expr("stock + ?", delta),
+			"updated_by_id": userID,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *serviceRepoImpl) CreatePricingRule(ctx context.Context, rule *model.PricingRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+func (r *serviceRepoImpl) FindAllPricingRulesWithDetails(ctx context.Context) ([]*model.PricingRule, error) {
+	var rules []*model.PricingRule
+	if err := r.db.WithContext(ctx).Preload("CreatedBy").Preload("UpdatedBy").Order("created_at DESC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *serviceRepoImpl) UpdatePricingRule(ctx context.Context, ruleID int64, updateData map[string]any) error {
+	result := r.db.WithContext(ctx).Model(&model.PricingRule{}).Where("id = ?", ruleID).Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return common.ErrPricingRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *serviceRepoImpl) DeletePricingRule(ctx context.Context, ruleID int64) error {
+	result := r.db.WithContext(ctx).Where("id = ?", ruleID).Delete(&model.PricingRule{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return common.ErrPricingRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *serviceRepoImpl) FindAllApplicablePricingRules(ctx context.Context, serviceID, serviceTypeID int64, promoCode *string, at time.Time) ([]*model.PricingRule, error) {
+	db := r.db.WithContext(ctx).
+		Where("is_active = true").
+		Where("service_id = ? OR service_type_id = ? OR (service_id IS NULL AND service_type_id IS NULL)", serviceID, serviceTypeID).
+		Where("starts_at IS NULL OR starts_at <= ?", at).
+		Where("ends_at IS NULL OR ends_at > ?", at)
+	if promoCode != nil {
+		db = db.Where("promo_code IS NULL OR promo_code = ?", *promoCode)
+	} else {
+		db = db.Where("promo_code IS NULL")
+	}
+
+	var rules []*model.PricingRule
+	if err := db.Order("created_at ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *serviceRepoImpl) UpsertServiceTypeTranslation(ctx context.Context, translation *model.ServiceTypeTranslation) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "service_type_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+	}).Create(translation).Error
+}
+
+func (r *serviceRepoImpl) DeleteServiceTypeTranslation(ctx context.Context, serviceTypeID int64, locale string) error {
+	result := r.db.WithContext(ctx).Where("service_type_id = ? AND locale = ?", serviceTypeID, locale).Delete(&model.ServiceTypeTranslation{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return common.ErrTranslationNotFound
+	}
+
+	return nil
+}
+
+func (r *serviceRepoImpl) UpsertServiceTranslation(ctx context.Context, translation *model.ServiceTranslation) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "service_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "description"}),
+	}).Create(translation).Error
+}
+
+func (r *serviceRepoImpl) DeleteServiceTranslation(ctx context.Context, serviceID int64, locale string) error {
+	result := r.db.WithContext(ctx).Where("service_id = ? AND locale = ?", serviceID, locale).Delete(&model.ServiceTranslation{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return common.ErrTranslationNotFound
+	}
+
+	return nil
+}
+
 func (r *serviceRepoImpl) FindServiceTypeBySlugWithActiveServiceDetails(ctx context.Context, serviceTypeSlug string) (*model.ServiceType, error) {
 	var serviceType model.ServiceType
-	if err := r.db.WithContext(ctx).Preload("Services", "is_active = true").Preload("Services.ServiceImages", "is_thumbnail = true").Where("slug = ?", serviceTypeSlug).First(&serviceType).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Services", "is_active = true").Preload("Services.ServiceImages", "is_thumbnail = true").Preload("Services.ServiceImages.Variants").Preload("Services.Translations").Preload("Translations").Where("slug = ?", serviceTypeSlug).First(&serviceType).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -199,7 +406,7 @@ func (r *serviceRepoImpl) DeleteService(ctx context.Context, serviceID int64) er
 
 func (r *serviceRepoImpl) FindAllServiceImagesByIDTx(tx *gorm.DB, ids []int64) ([]*model.ServiceImage, error) {
 	var images []*model.ServiceImage
-	if err := tx.Where("id IN ?", ids).Find(&images).Error; err != nil {
+	if err := tx.Preload("Variants").Where("id IN ?", ids).Find(&images).Error; err != nil {
 		return nil, err
 	}
 
@@ -218,10 +425,69 @@ func (r *serviceRepoImpl) CreateServiceImagesTx(tx *gorm.DB, serviceImages []*mo
 	return tx.Create(serviceImages).Error
 }
 
+func (r *serviceRepoImpl) FindServiceImageByIDWithVariants(ctx context.Context, serviceImageID int64) (*model.ServiceImage, error) {
+	var image model.ServiceImage
+	if err := r.db.WithContext(ctx).Preload("Variants").Where("id = ?", serviceImageID).First(&image).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &image, nil
+}
+
+// FindAllServiceImagesByStatusCreatedBefore pages by id. Rows stored before
+// created_at existed have it NULL and count as old enough.
+func (r *serviceRepoImpl) FindAllServiceImagesByStatusCreatedBefore(ctx context.Context, status string, createdBefore time.Time, afterID int64, limit int) ([]*model.ServiceImage, error) {
+	var images []*model.ServiceImage
+	if err := r.db.WithContext(ctx).Select("id").
+		Where("status = ? AND (created_at IS NULL OR created_at < ?) AND id > ?", status, createdBefore, afterID).
+		Order("id").Limit(limit).Find(&images).Error; err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (r *serviceRepoImpl) ReplaceServiceImageVariantsTx(tx *gorm.DB, serviceImageID int64, variants []*model.ServiceImageVariant) error {
+	if err := tx.Where("service_image_id = ?", serviceImageID).Delete(&model.ServiceImageVariant{}).Error; err != nil {
+		return err
+	}
+	if len(variants) == 0 {
+		return nil
+	}
+
+	return tx.Create(&variants).Error
+}
+
 func (r *serviceRepoImpl) UpdateServiceTx(tx *gorm.DB, serviceID int64, updateData map[string]any) error {
 	return tx.Model(&model.Service{}).Where("id = ?", serviceID).Updates(updateData).Error
 }
 
+func (r *serviceRepoImpl) FindAllServicesWithServiceImages(ctx context.Context) ([]*model.Service, error) {
+	var services []*model.Service
+	if err := r.db.WithContext(ctx).Preload("ServiceImages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("is_thumbnail DESC, sort_order ASC")
+	}).Preload("ServiceImages.Variants").Order("name ASC").Find(&services).Error; err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
+
+func (r *serviceRepoImpl) CreateServiceTypeTx(tx *gorm.DB, serviceType *model.ServiceType) error {
+	return tx.Create(serviceType).Error
+}
+
+func (r *serviceRepoImpl) UpdateServiceTypeTx(tx *gorm.DB, serviceTypeID int64, updateData map[string]any) error {
+	return tx.Model(&model.ServiceType{}).Where("id = ?", serviceTypeID).Updates(updateData).Error
+}
+
+func (r *serviceRepoImpl) CreateServiceTx(tx *gorm.DB, service *model.Service) error {
+	return tx.Create(service).Error
+}
+
 func (r *serviceRepoImpl) FindAllServicesWithServiceTypeAndThumbnailPaginated(ctx context.Context, query types.ServicePaginationQuery) ([]*model.Service, int64, error) {
 	var services []*model.Service
 	var total int64
@@ -284,3 +550,11 @@ func applyServiceSorting(db *gorm.DB, query types.ServicePaginationQuery) *gorm.
 
 	return db
 }
+
+func preloadServiceOptionGroups(db *gorm.DB) *gorm.DB {
+	return db.Preload("OptionGroups", func(db *gorm.DB) *gorm.DB {
+		return db.Order("sort_order ASC")
+	}).Preload("OptionGroups.Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("sort_order ASC")
+	})
+}
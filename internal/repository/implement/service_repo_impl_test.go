@@ -0,0 +1,135 @@
+package implement
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/InstaySystem/is_v1-be/internal/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newDryRunDB returns a postgres handle that builds statements without a
+// server and records the SQL of every create and update.
+func newDryRunDB(t *testing.T) (*gorm.DB, *[]string) {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("open dry run db: %v", err)
+	}
+
+	var statements []string
+	record := func(db *gorm.DB) {
+		statements = append(statements, db.Statement.SQL.String())
+	}
+	db.Callback().Create().After("gorm:create").Register("test:record_create", record)
+	db.Callback().Update().After("gorm:update").Register("test:record_update", record)
+
+	return db, &statements
+}
+
+func assertSQLContains(t *testing.T, statements []string, want []string) {
+	t.Helper()
+
+	if len(statements) != 1 {
+		t.Fatalf("got %d statements, want 1: %q", len(statements), statements)
+	}
+	for _, fragment := range want {
+		if !strings.Contains(statements[0], fragment) {
+			t.Errorf("sql %q does not contain %q", statements[0], fragment)
+		}
+	}
+}
+
+func TestServiceSlotSQL(t *testing.T) {
+	startAt := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		run  func(r *serviceRepoImpl, tx *gorm.DB) error
+		want []string
+	}{
+		{
+			name: "reserve adds to an existing slot only within capacity",
+			run: func(r *serviceRepoImpl, tx *gorm.DB) error {
+				_, err := r.ReserveServiceSlotTx(tx, &model.ServiceSlot{ID: 1, ServiceID: 2, StartAt: startAt, Reserved: 3}, 5)
+				return err
+			},
+			want: []string{
+				`INSERT INTO "service_slots"`,
+				`ON CONFLICT ("service_id","start_at") DO UPDATE SET "reserved"=service_slots.reserved + EXCLUDED.reserved`,
+				`WHERE service_slots.reserved + EXCLUDED.reserved <= $`,
+			},
+		},
+		{
+			name: "release never drops below zero",
+			run: func(r *serviceRepoImpl, tx *gorm.DB) error {
+				return r.ReleaseServiceSlotTx(tx, 2, startAt, 3)
+			},
+			want: []string{
+				`UPDATE "service_slots" SET "reserved"=GREATEST(reserved - $1, 0)`,
+				`WHERE service_id = $`,
+				`AND start_at = $`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := newDryRunDB(t)
+			if err := tt.run(&serviceRepoImpl{db}, db); err != nil {
+				t.Fatalf("run: %v", err)
+			}
+			assertSQLContains(t, *statements, tt.want)
+		})
+	}
+}
+
+func TestServiceStockSQL(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(r *serviceRepoImpl, tx *gorm.DB) error
+		want []string
+	}{
+		{
+			name: "decrement only when enough is in stock",
+			run: func(r *serviceRepoImpl, tx *gorm.DB) error {
+				_, err := r.DecrementServiceStockTx(tx, 2, 3)
+				return err
+			},
+			want: []string{
+				`UPDATE "services" SET "stock"=stock - $1`,
+				`WHERE id = $`,
+				`AND stock >= $`,
+				`RETURNING "stock","low_stock_threshold"`,
+			},
+		},
+		{
+			name: "increment skips untracked stock",
+			run: func(r *serviceRepoImpl, tx *gorm.DB) error {
+				return r.IncrementServiceStockTx(tx, 2, 3)
+			},
+			want: []string{
+				`UPDATE "services" SET "stock"=stock + $1`,
+				`WHERE id = $`,
+				`AND stock IS NOT NULL`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, statements := newDryRunDB(t)
+			if err := tt.run(&serviceRepoImpl{db}, db); err != nil {
+				t.Fatalf("run: %v", err)
+			}
+			assertSQLContains(t, *statements, tt.want)
+		})
+	}
+}
@@ -92,6 +92,15 @@ func (r *userRepoImpl) ExistsActiveAdmin(ctx context.Context) (bool, error) {
 	return count > 0, nil
 }
 
+func (r *userRepoImpl) FindAllActiveAdminIDs(ctx context.Context) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Model(&model.User{}).Where("role = 'admin' AND is_active = true").Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 func (r *userRepoImpl) Count(ctx context.Context) (int64, error) {
 	var count int64
 	if err := r.db.WithContext(ctx).Model(&model.User{}).Count(&count).Error; err != nil {
@@ -17,15 +17,15 @@ type Notification interface {
 
 	CreateNotificationStaffs(ctx context.Context, notificationStaffs []*model.NotificationStaff) error
 
-	FindAllUnreadNotificationsByDepartmentID(ctx context.Context, staffID, departmentID int64) ([]*model.Notification, error)
+	FindAllUnreadNotificationsByDepartmentID(ctx context.Context, staffID int64, departmentID *int64) ([]*model.Notification, error)
 
-	FindAllUnreadNotificationIDsByDepartmentIDTx(tx *gorm.DB, staffID, departmentID int64) ([]int64, error)
+	FindAllUnreadNotificationIDsByDepartmentIDTx(tx *gorm.DB, staffID int64, departmentID *int64) ([]int64, error)
 
 	FindAllUnreadNotificationIDsByOrderRoomIDTx(tx *gorm.DB, orderRoomID int64) ([]int64, error)
 
 	FindAllNotificationsByOrderRoomIDTx(tx *gorm.DB, orderRoomID int64) ([]*model.Notification, error)
 
-	CountUnreadNotificationsByDepartmentID(ctx context.Context, userID, departmentID int64) (int64, error)
+	CountUnreadNotificationsByDepartmentID(ctx context.Context, userID int64, departmentID *int64) (int64, error)
 
 	CountUnreadNotificationsByOrderRoomID(ctx context.Context, orderRoomID int64) (int64, error)
 
@@ -33,5 +33,5 @@ type Notification interface {
 
 	UpdateNotificationsByOrderRoomIDAndType(ctx context.Context, orderRoomID int64, contentType string, updateData map[string]any) error
 
-	FindAllNotificationsByDepartmentIDWithStaffsReadPaginatedTx(tx *gorm.DB, query types.NotificationPaginationQuery, staffID, departmentID int64) ([]*model.Notification, int64, error)
+	FindAllNotificationsByDepartmentIDWithStaffsReadPaginatedTx(tx *gorm.DB, query types.NotificationPaginationQuery, staffID int64, departmentID *int64) ([]*model.Notification, int64, error)
 }
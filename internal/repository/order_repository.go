@@ -13,6 +13,22 @@ type OrderRepository interface {
 
 	CreateOrderServiceTx(tx *gorm.DB, orderService *model.OrderService) error
 
+	CreateOrderServiceOptionsTx(tx *gorm.DB, options []*model.OrderServiceOption) error
+
+	CreateServiceOrderTx(tx *gorm.DB, serviceOrder *model.ServiceOrder) error
+
+	FindServiceOrderByIDWithLinesAndOrderRoomDetailsTx(tx *gorm.DB, serviceOrderID int64) (*model.ServiceOrder, error)
+
+	UpdateServiceOrderTx(tx *gorm.DB, serviceOrderID int64, updateData map[string]any) error
+
+	UpdateOrderServicesByServiceOrderIDTx(tx *gorm.DB, serviceOrderID int64, excludeIDs []int64, updateData map[string]any) error
+
+	FindServiceOrderByIDWithDetails(ctx context.Context, serviceOrderID int64) (*model.ServiceOrder, error)
+
+	FindAllServiceOrdersByOrderRoomIDWithDetails(ctx context.Context, orderRoomID int64) ([]*model.ServiceOrder, error)
+
+	FindAllServiceOrdersWithDetailsPaginated(ctx context.Context, query types.ServiceOrderPaginationQuery, departmentID *int64) ([]*model.ServiceOrder, int64, error)
+
 	GetPopularRoomTypeStats(ctx context.Context) ([]*types.PopularRoomTypeChartData, error)
 
 	FindOrderRoomByIDWithRoom(ctx context.Context, orderRoomID int64) (*model.OrderRoom, error)
@@ -27,9 +43,11 @@ type OrderRepository interface {
 
 	OrderServiceStatusDistribution(ctx context.Context) ([]*types.StatusChartResponse, error)
 
+	GetServiceRevenueByPricingRule(ctx context.Context) ([]*types.PricingRuleRevenueChartData, error)
+
 	FindOrderServiceByIDWithDetails(ctx context.Context, orderServiceID int64) (*model.OrderService, error)
 
 	FindAllOrderServicesByOrderRoomIDWithDetails(ctx context.Context, orderRoomID int64) ([]*model.OrderService, error)
 
-	FindAllOrderServicesWithDetailsPaginated(ctx context.Context, query types.OrderServicePaginationQuery, departmentID *int64) ([]*model.OrderService, int64, error)
+	FindAllOrderServicesWithDetailsPaginated(ctx context.Context, query types.OrderServicePaginationQuery, staffID int64, departmentID *int64) ([]*model.OrderService, int64, error)
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/model"
 	"github.com/InstaySystem/is_v1-be/internal/types"
@@ -23,7 +24,19 @@ type RequestRepository interface {
 
 	DeleteRequestType(ctx context.Context, requestTypeID int64) error
 
-	CreateRequest(ctx context.Context, request *model.Request) error
+	UpsertRequestTypeTranslation(ctx context.Context, translation *model.RequestTypeTranslation) error
+
+	DeleteRequestTypeTranslation(ctx context.Context, requestTypeID int64, locale string) error
+
+	CreateRequestTx(tx *gorm.DB, request *model.Request) error
+
+	CreateRequestAttachmentsTx(tx *gorm.DB, attachments []*model.RequestAttachment) error
+
+	CreateRequestNoteTx(tx *gorm.DB, note *model.RequestNote) error
+
+	CreateRequestNote(ctx context.Context, note *model.RequestNote) error
+
+	FindRequestByIDWithRequestType(ctx context.Context, requestID int64) (*model.Request, error)
 
 	RequestStatusDistribution(ctx context.Context) ([]*types.StatusChartResponse, error)
 
@@ -35,5 +48,25 @@ type RequestRepository interface {
 
 	FindRequestByIDWithDetails(ctx context.Context, requestID int64) (*model.Request, error)
 
-	FindAllRequestsWithDetailsPaginated(ctx context.Context, query types.RequestPaginationQuery, departmentID *int64) ([]*model.Request, int64, error)
+	FindAllRequestsWithDetailsPaginated(ctx context.Context, query types.RequestPaginationQuery, staffID int64, departmentID *int64) ([]*model.Request, int64, error)
+
+	FindAllRequestsBreachingSLA(ctx context.Context, now time.Time, limit int) ([]*model.Request, error)
+
+	MarkRequestSLABreachedTx(tx *gorm.DB, requestID int64, column string, breachedAt time.Time) (bool, error)
+
+	SLAComplianceByDepartment(ctx context.Context) ([]*types.SLAComplianceResponse, error)
+
+	CreateRequestSchedule(ctx context.Context, schedule *model.RequestSchedule) error
+
+	FindAllRequestSchedulesByOrderRoomIDWithDetails(ctx context.Context, orderRoomID int64) ([]*model.RequestSchedule, error)
+
+	CancelRequestSchedule(ctx context.Context, scheduleID, orderRoomID int64, cancelledAt time.Time) (bool, error)
+
+	CancelRequestSchedulesAfterCheckOut(ctx context.Context, now time.Time) (int64, error)
+
+	FindAllDueRequestScheduleIDs(ctx context.Context, now time.Time, limit int) ([]int64, error)
+
+	FindDueRequestScheduleByIDWithDetailsTx(tx *gorm.DB, scheduleID int64, now time.Time) (*model.RequestSchedule, error)
+
+	UpdateRequestScheduleTx(tx *gorm.DB, scheduleID int64, updateData map[string]any) error
 }
@@ -12,8 +12,12 @@ type ReviewRepository interface {
 
 	FindByOrderRoomID(ctx context.Context, orderRoomID int64) (*model.Review, error)
 
+	FindByID(ctx context.Context, id int64) (*model.Review, error)
+
 	FindAllPaginated(ctx context.Context, query types.ReviewPaginationQuery) ([]*model.Review, int64, error)
 
+	FindAllApprovedPaginated(ctx context.Context, query types.PublicReviewPaginationQuery) ([]*model.Review, int64, error)
+
 	Update(ctx context.Context, id int64, updateData map[string]any) error
 
 	AverageRating(ctx context.Context) (float64, error)
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/model"
 	"github.com/InstaySystem/is_v1-be/internal/types"
@@ -35,6 +36,12 @@ type ServiceRepository interface {
 
 	CreateServiceImagesTx(tx *gorm.DB, serviceImages []*model.ServiceImage) error
 
+	FindServiceImageByIDWithVariants(ctx context.Context, serviceImageID int64) (*model.ServiceImage, error)
+
+	FindAllServiceImagesByStatusCreatedBefore(ctx context.Context, status string, createdBefore time.Time, afterID int64, limit int) ([]*model.ServiceImage, error)
+
+	ReplaceServiceImageVariantsTx(tx *gorm.DB, serviceImageID int64, variants []*model.ServiceImageVariant) error
+
 	UpdateServiceTx(tx *gorm.DB, serviceID int64, updateData map[string]any) error
 
 	FindAllServiceType(ctx context.Context) ([]*model.ServiceType, error)
@@ -52,4 +59,50 @@ type ServiceRepository interface {
 	CountService(ctx context.Context) (int64, error)
 
 	FindServiceBySlugWithServiceTypeAndServiceImages(ctx context.Context, serviceSlug string) (*model.Service, error)
+
+	ReplaceServiceTypeOpeningHoursTx(tx *gorm.DB, serviceTypeID int64, hours []*model.ServiceOpeningHour) error
+
+	ReplaceServiceOpeningHoursTx(tx *gorm.DB, serviceID int64, hours []*model.ServiceOpeningHour) error
+
+	ReplaceServiceOptionGroupsTx(tx *gorm.DB, serviceID int64, groups []*model.ServiceOptionGroup) error
+
+	FindAllServiceSlotsByServiceIDBetween(ctx context.Context, serviceID int64, from, to time.Time) ([]*model.ServiceSlot, error)
+
+	FindMaxUpcomingSlotReservedTx(tx *gorm.DB, serviceID int64, since time.Time) (uint32, error)
+
+	ReserveServiceSlotTx(tx *gorm.DB, slot *model.ServiceSlot, capacity uint32) (bool, error)
+
+	ReleaseServiceSlotTx(tx *gorm.DB, serviceID int64, startAt time.Time, quantity uint32) error
+
+	DecrementServiceStockTx(tx *gorm.DB, serviceID int64, quantity uint32) (*model.Service, error)
+
+	IncrementServiceStockTx(tx *gorm.DB, serviceID int64, quantity uint32) error
+
+	AdjustServiceStock(ctx context.Context, serviceID, userID int64, delta int32) (bool, error)
+
+	UpsertServiceTypeTranslation(ctx context.Context, translation *model.ServiceTypeTranslation) error
+
+	DeleteServiceTypeTranslation(ctx context.Context, serviceTypeID int64, locale string) error
+
+	UpsertServiceTranslation(ctx context.Context, translation *model.ServiceTranslation) error
+
+	DeleteServiceTranslation(ctx context.Context, serviceID int64, locale string) error
+
+	CreatePricingRule(ctx context.Context, rule *model.PricingRule) error
+
+	FindAllPricingRulesWithDetails(ctx context.Context) ([]*model.PricingRule, error)
+
+	UpdatePricingRule(ctx context.Context, ruleID int64, updateData map[string]any) error
+
+	DeletePricingRule(ctx context.Context, ruleID int64) error
+
+	FindAllServicesWithServiceImages(ctx context.Context) ([]*model.Service, error)
+
+	CreateServiceTypeTx(tx *gorm.DB, serviceType *model.ServiceType) error
+
+	UpdateServiceTypeTx(tx *gorm.DB, serviceTypeID int64, updateData map[string]any) error
+
+	CreateServiceTx(tx *gorm.DB, service *model.Service) error
+
+	FindAllApplicablePricingRules(ctx context.Context, serviceID, serviceTypeID int64, promoCode *string, at time.Time) ([]*model.PricingRule, error)
 }
@@ -29,4 +29,6 @@ type UserRepository interface {
 	Count(ctx context.Context) (int64, error)
 
 	ExistsActiveAdmin(ctx context.Context) (bool, error)
+
+	FindAllActiveAdminIDs(ctx context.Context) ([]int64, error)
 }
@@ -7,11 +7,43 @@ import (
 )
 
 func ChatRouter(rg *gin.RouterGroup, hdl *handler.ChatHandler, authMid *middleware.AuthMiddleware) {
-	admin := rg.Group("/admin/chats", authMid.IsAuthentication(), authMid.HasDepartment("customer-care"))
+	admin := rg.Group("/admin/chats", authMid.IsAuthentication())
 	{
 		admin.GET("", hdl.GetChatsForAdmin)
 
+		admin.GET("/search", hdl.SearchMessages)
+
 		admin.GET("/:id", hdl.GetChatByID)
+
+		admin.GET("/:id/transcript", hdl.ExportTranscript)
+
+		admin.POST("/:id/claim", hdl.ClaimChat)
+
+		admin.PATCH("/:id/assign", hdl.AssignChat)
+
+		admin.POST("/:id/transfer", hdl.TransferChat)
+	}
+
+	rg.GET("/admin/canned-responses", authMid.IsAuthentication(), hdl.GetCannedResponses)
+
+	admin = rg.Group("/admin/canned-responses", authMid.IsAuthentication(), authMid.HasAnyRole([]string{"admin"}))
+	{
+		admin.POST("", hdl.CreateCannedResponse)
+
+		admin.PATCH("/:id", hdl.UpdateCannedResponse)
+
+		admin.DELETE("/:id", hdl.DeleteCannedResponse)
+	}
+
+	admin = rg.Group("/admin/faqs", authMid.IsAuthentication(), authMid.HasAnyRole([]string{"admin"}))
+	{
+		admin.GET("", hdl.GetFAQs)
+
+		admin.POST("", hdl.CreateFAQ)
+
+		admin.PATCH("/:id", hdl.UpdateFAQ)
+
+		admin.DELETE("/:id", hdl.DeleteFAQ)
 	}
 
 	guest := rg.Group("/chats", authMid.HasGuestToken())
@@ -2,14 +2,22 @@ package router
 
 import (
 	"github.com/InstaySystem/is_v1-be/internal/handler"
+	"github.com/InstaySystem/is_v1-be/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-func FileRouter(rg *gin.RouterGroup, hdl *handler.FileHandler) {
+func FileRouter(rg *gin.RouterGroup, hdl *handler.FileHandler, authMid *middleware.AuthMiddleware) {
+	presigned := rg.Group("/files/presigned-urls", authMid.IsGuestOrStaffHasDepartment(nil))
+	{
+		presigned.POST("/uploads", hdl.UploadPresignedURLs)
+
+		presigned.POST("/views", hdl.ViewPresignedURLs)
+	}
+
 	file := rg.Group("/files")
 	{
-		file.POST("/presigned-urls/uploads", hdl.UploadPresignedURLs)
+		file.GET("/objects/*key", hdl.GetObject)
 
-		file.POST("/presigned-urls/views", hdl.ViewPresignedURLs)
+		file.PUT("/objects/*key", hdl.PutObject)
 	}
 }
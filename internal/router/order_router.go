@@ -21,6 +21,19 @@ func OrderRouter(rg *gin.RouterGroup, hdl *handler.OrderHandler, authMid *middle
 		admin.GET("/:id", hdl.GetOrderServiceByID)
 
 		admin.PUT("/:id", hdl.UpdateOrderServiceForAdmin)
+
+		admin.POST("/:id/claim", hdl.ClaimOrderService)
+
+		admin.PATCH("/:id/assign", hdl.AssignOrderService)
+	}
+
+	admin = rg.Group("/admin/orders/service-orders", authMid.IsAuthentication())
+	{
+		admin.GET("", hdl.GetServiceOrdersForAdmin)
+
+		admin.GET("/:id", hdl.GetServiceOrderByID)
+
+		admin.PUT("/:id", hdl.UpdateServiceOrderForAdmin)
 	}
 
 	rg.POST("/orders/rooms/verify", hdl.VerifyOrderRoom)
@@ -33,4 +46,13 @@ func OrderRouter(rg *gin.RouterGroup, hdl *handler.OrderHandler, authMid *middle
 
 		guest.GET("", hdl.GetOrderServicesForGuest)
 	}
+
+	guest = rg.Group("/orders/service-orders", authMid.HasGuestToken())
+	{
+		guest.POST("", hdl.CreateServiceOrder)
+
+		guest.PUT("/:id", hdl.UpdateServiceOrderForGuest)
+
+		guest.GET("", hdl.GetServiceOrdersForGuest)
+	}
 }
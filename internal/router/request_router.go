@@ -16,6 +16,10 @@ func RequestRouter(rg *gin.RouterGroup, hdl *handler.RequestHandler, authMid *mi
 		admin.PATCH("/request-types/:id", hdl.UpdateRequestType)
 
 		admin.DELETE("/request-types/:id", hdl.DeleteRequestType)
+
+		admin.PUT("/request-types/:id/translations/:locale", hdl.UpsertRequestTypeTranslation)
+
+		admin.DELETE("/request-types/:id/translations/:locale", hdl.DeleteRequestTypeTranslation)
 	}
 
 	rg.GET("/request-types", hdl.GetRequestTypesForGuest)
@@ -26,6 +30,12 @@ func RequestRouter(rg *gin.RouterGroup, hdl *handler.RequestHandler, authMid *mi
 
 		admin.GET("/:id", hdl.GetRequestByID)
 
+		admin.POST("/:id/claim", hdl.ClaimRequest)
+
+		admin.PATCH("/:id/assign", hdl.AssignRequest)
+
+		admin.POST("/:id/notes", hdl.CreateRequestNote)
+
 		admin.GET("", hdl.GetRequestsForAdmin)
 	}
 
@@ -36,5 +46,11 @@ func RequestRouter(rg *gin.RouterGroup, hdl *handler.RequestHandler, authMid *mi
 		guest.PUT("/:id", hdl.UpdateRequestForGuest)
 
 		guest.GET("", hdl.GetRequestsForGuest)
+
+		guest.POST("/schedules", hdl.CreateRequestSchedule)
+
+		guest.GET("/schedules", hdl.GetRequestSchedulesForGuest)
+
+		guest.DELETE("/schedules/:id", hdl.CancelRequestSchedule)
 	}
 }
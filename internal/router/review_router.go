@@ -10,7 +10,18 @@ func ReviewRouter(rg *gin.RouterGroup, hdl *handler.ReviewHandler, authMid *midd
 	admin := rg.Group("/admin/reviews", authMid.IsAuthentication(), authMid.HasDepartment("customer-care"))
 	{
 		admin.GET("", hdl.GetReviews)
+
+		admin.PUT("/:id/response", hdl.RespondReview)
+
+		admin.DELETE("/:id/response", hdl.DeleteReviewResponse)
+
+		admin.PATCH("/:id/moderation", hdl.ModerateReview)
+
+		admin.PATCH("/:id/featured", hdl.FeatureReview)
 	}
+
+	rg.GET("/reviews/public", hdl.GetPublicReviews)
+
 	guest := rg.Group("/reviews", authMid.HasGuestToken())
 	{
 		guest.POST("", hdl.CreateReview)
@@ -15,11 +15,33 @@ func ServiceRouter(rg *gin.RouterGroup, hdl *handler.ServiceHandler, authMid *mi
 
 		admin.DELETE("/service-types/:id", hdl.DeleteServiceType)
 
+		admin.PUT("/service-types/:id/translations/:locale", hdl.UpsertServiceTypeTranslation)
+
+		admin.DELETE("/service-types/:id/translations/:locale", hdl.DeleteServiceTypeTranslation)
+
 		admin.POST("/services", hdl.CreateService)
 
+		admin.POST("/services/import", hdl.ImportServiceCatalogue)
+
+		admin.GET("/services/export", hdl.ExportServiceCatalogue)
+
 		admin.PATCH("/services/:id", hdl.UpdateService)
 
+		admin.PATCH("/services/:id/stock", hdl.AdjustServiceStock)
+
 		admin.DELETE("/services/:id", hdl.DeleteService)
+
+		admin.PUT("/services/:id/translations/:locale", hdl.UpsertServiceTranslation)
+
+		admin.DELETE("/services/:id/translations/:locale", hdl.DeleteServiceTranslation)
+
+		admin.POST("/pricing-rules", hdl.CreatePricingRule)
+
+		admin.GET("/pricing-rules", hdl.GetPricingRules)
+
+		admin.PUT("/pricing-rules/:id", hdl.UpdatePricingRule)
+
+		admin.DELETE("/pricing-rules/:id", hdl.DeletePricingRule)
 	}
 
 	admin = rg.Group("/admin", authMid.IsAuthentication(), authMid.HasDepartment("reception"))
@@ -7,6 +7,5 @@ import (
 )
 
 func WSRouter(rg *gin.RouterGroup, hdl *handler.WSHandler, authMid *middleware.AuthMiddleware) {
-	allowDept := "customer-care"
-	rg.GET("/ws", authMid.IsGuestOrStaffHasDepartment(&allowDept), hdl.ServeWS)
+	rg.GET("/ws", authMid.IsGuestOrStaffHasDepartment(nil), hdl.ServeWS)
 }
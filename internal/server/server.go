@@ -9,10 +9,11 @@ import (
 	"syscall"
 	"time"
 
-	"cloud.google.com/go/storage"
 	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/container"
 	"github.com/InstaySystem/is_v1-be/internal/initialization"
+	"github.com/InstaySystem/is_v1-be/internal/provider/mq"
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
 	"github.com/InstaySystem/is_v1-be/internal/router"
 	"github.com/InstaySystem/is_v1-be/internal/seed"
 	"github.com/InstaySystem/is_v1-be/internal/worker"
@@ -26,14 +27,18 @@ import (
 )
 
 type Server struct {
-	cfg          *config.Config
-	http         *http.Server
-	db           *initialization.DB
-	rdb          *redis.Client
-	rmq          *amqp091.Connection
-	gcs          *storage.Client
-	listenWorker *worker.ListenWorker
-	logger       *zap.Logger
+	cfg           *config.Config
+	http          *http.Server
+	db            *initialization.DB
+	rdb           *redis.Client
+	rmq           *amqp091.Connection
+	mq            mq.MessageQueueProvider
+	storage       storage.StorageProvider
+	listenWorker  *worker.ListenWorker
+	chatWorker    *worker.ChatWorker
+	requestWorker *worker.RequestWorker
+	fileWorker    *worker.FileWorker
+	logger        *zap.Logger
 }
 
 func NewServer(cfg *config.Config) (*Server, error) {
@@ -47,12 +52,27 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		return nil, err
 	}
 
-	rmq, err := initialization.InitRabbitMQ(cfg)
-	if err != nil {
-		return nil, err
+	var rmq *amqp091.Connection
+	switch cfg.MQ.Driver {
+	case "", "rabbitmq":
+		rmq, err = initialization.InitRabbitMQ(cfg)
+		if err != nil {
+			return nil, err
+		}
+	case "memory":
+	default:
+		return nil, fmt.Errorf("unsupported mq driver: %s", cfg.MQ.Driver)
+	}
+
+	// An empty translation driver disables translation; anything else must
+	// name a provider so a typo does not switch it off silently.
+	switch cfg.Translation.Driver {
+	case "", "google", "fake":
+	default:
+		return nil, fmt.Errorf("unsupported translation driver: %s", cfg.Translation.Driver)
 	}
 
-	gcs, err := initialization.InitGCS(cfg)
+	storageProvider, err := initialization.InitStorage(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -67,19 +87,28 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		return nil, err
 	}
 
-	ctn := container.NewContainer(cfg, db.Gorm, rdb, gcs, sf, logger, rmq)
+	ctn := container.NewContainer(cfg, db.Gorm, rdb, storageProvider, sf, logger, rmq)
 
 	seed := seed.NewSeed(cfg, ctn.UserRepo, logger, ctn.BHash, ctn.SfGen)
 	if err = seed.AdminSeed(); err != nil {
 		return nil, err
 	}
 
-	mqWorker := worker.NewMQWorker(cfg, ctn.MQProvider, ctn.SMTPProvider, gcs, logger, ctn.SSEHub)
+	mqWorker := worker.NewMQWorker(cfg, ctn.MQProvider, ctn.SMTPProvider, storageProvider, logger, ctn.SSEHub, ctn.ServiceCtn.Svc)
 	mqWorker.Start()
 
 	listenWorker := worker.NewListenWorker(cfg, ctn.BookingRepo, ctn.SfGen, logger)
 	listenWorker.Start()
 
+	chatWorker := worker.NewChatWorker(cfg, ctn.ChatCtn.Svc, logger)
+	chatWorker.Start()
+
+	requestWorker := worker.NewRequestWorker(cfg, ctn.RequestCtn.Svc, logger)
+	requestWorker.Start()
+
+	fileWorker := worker.NewFileWorker(cfg, ctn.FileCtn.Svc, ctn.ServiceCtn.Svc, logger)
+	fileWorker.Start()
+
 	go ctn.SSEHub.Run()
 	go ctn.WSHub.Run()
 
@@ -101,7 +130,7 @@ func NewServer(cfg *config.Config) (*Server, error) {
 
 	api := r.Group(cfg.Server.APIPrefix)
 
-	router.FileRouter(api, ctn.FileCtn.Hdl)
+	router.FileRouter(api, ctn.FileCtn.Hdl, ctn.AuthMid)
 	router.UserRouter(api, ctn.UserCtn.Hdl, ctn.AuthMid)
 	router.AuthRouter(api, ctn.AuthCtn.Hdl, ctn.AuthMid)
 	router.DepartmentRouter(api, ctn.DepartmentCtn.Hdl, ctn.AuthMid)
@@ -139,8 +168,12 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		db,
 		rdb,
 		rmq,
-		gcs,
+		ctn.MQProvider,
+		storageProvider,
 		listenWorker,
+		chatWorker,
+		requestWorker,
+		fileWorker,
 		logger,
 	}, nil
 }
@@ -154,6 +187,18 @@ func (s *Server) Shutdown(ctx context.Context) {
 		s.listenWorker.Stop()
 	}
 
+	if s.chatWorker != nil {
+		s.chatWorker.Stop()
+	}
+
+	if s.requestWorker != nil {
+		s.requestWorker.Stop()
+	}
+
+	if s.fileWorker != nil {
+		s.fileWorker.Stop()
+	}
+
 	if s.db != nil {
 		s.db.Close()
 	}
@@ -162,12 +207,16 @@ func (s *Server) Shutdown(ctx context.Context) {
 		s.rdb.Close()
 	}
 
+	if s.mq != nil {
+		s.mq.Close()
+	}
+
 	if s.rmq != nil {
 		s.rmq.Close()
 	}
 
-	if s.gcs != nil {
-		s.gcs.Close()
+	if s.storage != nil {
+		s.storage.Close()
 	}
 
 	if s.logger != nil {
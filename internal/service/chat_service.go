@@ -2,19 +2,54 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/model"
 	"github.com/InstaySystem/is_v1-be/internal/types"
 )
 
 type ChatService interface {
-	CreateMessage(ctx context.Context, chatID, clientID int64, senderType string, req types.CreateMessageRequest) (*model.Message, error)
+	CreateMessage(ctx context.Context, chatID, clientID int64, senderType string, departmentID *int64, req types.CreateMessageRequest) (*model.Message, error)
 
-	GetChatsForAdmin(ctx context.Context, query types.ChatPaginationQuery, userID int64) ([]*model.Chat, *types.MetaResponse, error)
+	GetChatsForAdmin(ctx context.Context, query types.ChatPaginationQuery, userID int64, departmentID *int64) ([]*model.Chat, *types.MetaResponse, error)
 
-	GetChatByID(ctx context.Context, chatID, userID int64) (*model.Chat, error)
+	GetChatByID(ctx context.Context, chatID, userID int64, departmentID *int64) (*model.Chat, error)
+
+	ClaimChat(ctx context.Context, chatID, userID int64, departmentID *int64) error
+
+	AssignChat(ctx context.Context, chatID, userID int64, departmentID *int64, req types.AssignChatRequest) error
+
+	TransferChat(ctx context.Context, chatID, userID int64, departmentID *int64, req types.TransferChatRequest) error
+
+	ExportTranscript(ctx context.Context, chatID, userID int64, departmentID *int64, format string) ([]byte, string, string, error)
+
+	SendCheckoutTranscripts(ctx context.Context) error
+
+	ApplyRetention(ctx context.Context) error
+
+	SearchMessages(ctx context.Context, query types.MessageSearchQuery, departmentID *int64) ([]*types.MessageSearchResponse, *types.MetaResponse, error)
+
+	CreateCannedResponse(ctx context.Context, userID int64, req types.CreateCannedResponseRequest) error
+
+	GetCannedResponses(ctx context.Context, query types.CannedResponseQuery, departmentID *int64) ([]*model.CannedResponse, error)
+
+	UpdateCannedResponse(ctx context.Context, cannedResponseID, userID int64, req types.UpdateCannedResponseRequest) error
+
+	DeleteCannedResponse(ctx context.Context, cannedResponseID int64) error
+
+	CreateFAQ(ctx context.Context, userID int64, req types.CreateFAQRequest) error
+
+	GetFAQs(ctx context.Context, query types.FAQQuery) ([]*model.FAQ, error)
+
+	UpdateFAQ(ctx context.Context, faqID, userID int64, req types.UpdateFAQRequest) error
+
+	DeleteFAQ(ctx context.Context, faqID int64) error
 
 	GetMyChat(ctx context.Context, orderRoomID int64) (*model.Chat, error)
 
-	UpdateReadMessages(ctx context.Context, chatID, clientID int64, readerType string) (*model.Chat, error)
+	UpdateReadMessages(ctx context.Context, chatID, clientID int64, readerType string, departmentID *int64) (*model.Chat, error)
+
+	UpdateDeliveredMessages(ctx context.Context, chatID, clientID int64, receiverType string, departmentID *int64) (*model.Chat, time.Time, error)
+
+	GetChatForClient(ctx context.Context, chatID, clientID int64, clientType string, departmentID *int64) (*model.Chat, error)
 }
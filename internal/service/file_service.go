@@ -2,12 +2,21 @@ package service
 
 import (
 	"context"
+	"io"
+	"net/url"
 
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
 	"github.com/InstaySystem/is_v1-be/internal/types"
 )
 
 type FileService interface {
-	CreateUploadURLs(ctx context.Context, req types.UploadPresignedURLsRequest) ([]*types.UploadPresignedURLResponse, error)
+	CreateUploadURLs(ctx context.Context, clientID int64, clientType string, req types.UploadPresignedURLsRequest) ([]*types.UploadPresignedURLResponse, error)
 
-	CreateViewURLs(ctx context.Context, req types.ViewPresignedURLsRequest) ([]*types.ViewPresignedURLResponse, error)
+	CreateViewURLs(ctx context.Context, clientID int64, clientType string, req types.ViewPresignedURLsRequest) ([]*types.ViewPresignedURLResponse, error)
+
+	GetObject(ctx context.Context, key string, query url.Values) (io.ReadCloser, *storage.ObjectAttrs, error)
+
+	PutObject(ctx context.Context, key, contentType string, contentLength int64, query url.Values, body io.Reader) error
+
+	SweepOrphanedUploads(ctx context.Context) error
 }
@@ -2,25 +2,48 @@ package implement
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/common"
+	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/model"
+	"github.com/InstaySystem/is_v1-be/internal/provider/mq"
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
+	"github.com/InstaySystem/is_v1-be/internal/provider/translation"
 	"github.com/InstaySystem/is_v1-be/internal/repository"
 	"github.com/InstaySystem/is_v1-be/internal/service"
 	"github.com/InstaySystem/is_v1-be/internal/types"
+	"github.com/InstaySystem/is_v1-be/pkg/imaging"
+	"github.com/InstaySystem/is_v1-be/pkg/pdf"
 	"github.com/InstaySystem/is_v1-be/pkg/snowflake"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 type chatSvcImpl struct {
-	db        *gorm.DB
-	chatRepo  repository.ChatRepository
-	orderRepo repository.OrderRepository
-	userRepo  repository.UserRepository
-	sfGen     snowflake.Generator
-	logger    *zap.Logger
+	db               *gorm.DB
+	chatRepo         repository.ChatRepository
+	orderRepo        repository.OrderRepository
+	userRepo         repository.UserRepository
+	departmentRepo   repository.DepartmentRepository
+	notificationRepo repository.Notification
+	fileRepo         repository.FileRepository
+	sfGen            snowflake.Generator
+	logger           *zap.Logger
+	storage          storage.StorageProvider
+	cfg              *config.Config
+	imgProcessor     imaging.Processor
+	mqProvider       mq.MessageQueueProvider
+	pdfGen           pdf.Generator
+	translator       translation.TranslationProvider
 }
 
 func NewChatService(
@@ -28,23 +51,74 @@ func NewChatService(
 	chatRepo repository.ChatRepository,
 	orderRepo repository.OrderRepository,
 	userRepo repository.UserRepository,
+	departmentRepo repository.DepartmentRepository,
+	notificationRepo repository.Notification,
+	fileRepo repository.FileRepository,
 	sfGen snowflake.Generator,
 	logger *zap.Logger,
+	storage storage.StorageProvider,
+	cfg *config.Config,
+	imgProcessor imaging.Processor,
+	mqProvider mq.MessageQueueProvider,
+	pdfGen pdf.Generator,
+	translator translation.TranslationProvider,
 ) service.ChatService {
 	return &chatSvcImpl{
 		db,
 		chatRepo,
 		orderRepo,
 		userRepo,
+		departmentRepo,
+		notificationRepo,
+		fileRepo,
 		sfGen,
 		logger,
+		storage,
+		cfg,
+		imgProcessor,
+		mqProvider,
+		pdfGen,
+		translator,
 	}
 }
 
-func (s *chatSvcImpl) CreateMessage(ctx context.Context, chatID, clientID int64, senderType string, req types.CreateMessageRequest) (*model.Message, error) {
+func (s *chatSvcImpl) CreateMessage(ctx context.Context, chatID, clientID int64, senderType string, departmentID *int64, req types.CreateMessageRequest) (*model.Message, error) {
+	if req.TemplateID != nil {
+		content, err := s.renderCannedResponse(ctx, chatID, clientID, senderType, *req.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		req.Content = &content
+	}
+
+	if (req.Content == nil || strings.TrimSpace(*req.Content) == "") && len(req.Attachments) == 0 {
+		return nil, common.ErrEmptyMessage
+	}
+	if len(req.Attachments) > common.MaxMessageAttachments {
+		return nil, common.ErrTooManyAttachments
+	}
+
+	// Check access before preparing attachments, which reads the objects and
+	// writes thumbnails. The transaction checks again against the locked row.
+	chat, err := s.chatRepo.FindChatByIDTx(s.db.WithContext(ctx), chatID)
+	if err != nil {
+		s.logger.Error("find chat by id failed", zap.Int64("id", chatID), zap.Error(err))
+		return nil, err
+	}
+	if err = checkChatWritable(chat, clientID, senderType, departmentID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.prepareAttachments(ctx, clientID, senderType, req.Attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	language, translatedContent, translatedLanguage := s.translateMessage(ctx, chatID, senderType, req.Content)
+
 	var message *model.Message
 
-	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	if err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		now := time.Now()
 
 		chat, err := s.chatRepo.FindChatByIDTx(tx, chatID)
@@ -52,8 +126,8 @@ func (s *chatSvcImpl) CreateMessage(ctx context.Context, chatID, clientID int64,
 			s.logger.Error("find chat by id failed", zap.Error(err))
 			return err
 		}
-		if chat == nil {
-			return common.ErrChatNotFound
+		if err = checkChatWritable(chat, clientID, senderType, departmentID); err != nil {
+			return err
 		}
 
 		messageID, err := s.sfGen.NextID()
@@ -68,13 +142,15 @@ func (s *chatSvcImpl) CreateMessage(ctx context.Context, chatID, clientID int64,
 		}
 
 		message = &model.Message{
-			ID:         messageID,
-			ChatID:     chatID,
-			SenderType: senderType,
-			SenderID:   senderID,
-			ImageKey:   req.ImageKey,
-			Content:    req.Content,
-			CreatedAt:  now,
+			ID:                 messageID,
+			ChatID:             chatID,
+			SenderType:         senderType,
+			SenderID:           senderID,
+			Content:            req.Content,
+			Language:           language,
+			TranslatedContent:  translatedContent,
+			TranslatedLanguage: translatedLanguage,
+			CreatedAt:          now,
 		}
 
 		if err = s.chatRepo.CreateMessageTx(tx, message); err != nil {
@@ -82,7 +158,44 @@ func (s *chatSvcImpl) CreateMessage(ctx context.Context, chatID, clientID int64,
 			return err
 		}
 
-		if err = s.chatRepo.UpdateChatTx(tx, chatID, map[string]any{"last_message_at": now}); err != nil {
+		if len(attachments) > 0 {
+			for _, attachment := range attachments {
+				attachment.MessageID = messageID
+			}
+
+			if err = s.chatRepo.CreateMessageAttachmentsTx(tx, attachments); err != nil {
+				if ok, _ := common.IsUniqueViolation(err); ok {
+					return common.ErrAttachmentNotFound
+				}
+				s.logger.Error("create message attachments failed", zap.Error(err))
+				return err
+			}
+
+			keys := make([]string, 0, len(attachments))
+			for _, attachment := range attachments {
+				keys = append(keys, attachment.Key)
+			}
+			if err = s.fileRepo.ConfirmUploadsTx(tx, keys); err != nil {
+				if errors.Is(err, common.ErrUploadExpired) {
+					return err
+				}
+				s.logger.Error("confirm uploads failed", zap.Error(err))
+				return err
+			}
+		}
+		message.Attachments = attachments
+
+		updateData := map[string]any{"last_message_at": now}
+		if senderType == "guest" && language != nil {
+			updateData["guest_language"] = *language
+			chat.GuestLanguage = language
+		}
+		if senderType == "staff" && chat.EscalatedAt != nil {
+			updateData["escalated_at"] = nil
+			chat.EscalatedAt = nil
+		}
+
+		if err = s.chatRepo.UpdateChatTx(tx, chatID, updateData); err != nil {
 			s.logger.Error("update chat failed", zap.Error(err))
 			return err
 		}
@@ -92,74 +205,142 @@ func (s *chatSvcImpl) CreateMessage(ctx context.Context, chatID, clientID int64,
 
 		return nil
 	}); err != nil {
+		s.publishDeleteFiles(attachmentThumbnailKeys(attachments))
+		return nil, err
+	}
+
+	if err = s.signAttachments(message.Attachments); err != nil {
 		return nil, err
 	}
 
+	if senderType == "guest" {
+		message.AutoReply = s.autoRespond(ctx, message)
+	}
+
 	return message, nil
 }
 
-func (s *chatSvcImpl) UpdateReadMessages(ctx context.Context, chatID, clientID int64, readerType string) (*model.Chat, error) {
-	var chat *model.Chat
-	var err error
+func (s *chatSvcImpl) autoRespond(ctx context.Context, message *model.Message) *model.Message {
+	if !s.cfg.Chatbot.Enabled || message.Content == nil || s.isStaffedHours(message.CreatedAt) {
+		return nil
+	}
 
-	if err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	faqs, err := s.chatRepo.FindAllActiveFAQs(ctx)
+	if err != nil {
+		s.logger.Error("find all active faqs failed", zap.Error(err))
+		return nil
+	}
+
+	chat := message.Chat
+	if faq := matchFAQ(faqs, message.Content, message.TranslatedContent); faq != nil {
+		reply, err := s.createSystemMessage(ctx, chat, faq.Answer, false)
+		if err != nil {
+			return nil
+		}
+		return reply
+	}
+
+	if chat.EscalatedAt != nil || strings.TrimSpace(s.cfg.Chatbot.FallbackMessage) == "" {
+		return nil
+	}
+
+	reply, err := s.createSystemMessage(ctx, chat, s.cfg.Chatbot.FallbackMessage, true)
+	if err != nil {
+		return nil
+	}
+
+	return reply
+}
+
+func (s *chatSvcImpl) createSystemMessage(ctx context.Context, chat *model.Chat, content string, escalate bool) (*model.Message, error) {
+	language, translatedContent, translatedLanguage := s.translateMessage(ctx, chat.ID, "system", &content)
+
+	var department *model.Department
+	if escalate {
+		var err error
+		department, err = s.departmentRepo.FindByNameWithStaffs(ctx, common.ChatDepartment)
+		if err != nil {
+			s.logger.Error("find department by name failed", zap.String("name", common.ChatDepartment), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	var message *model.Message
+	var notificationMsg *types.NotificationMessage
+
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		now := time.Now()
 
-		if readerType == "staff" {
-			unreadIDs, err := s.chatRepo.FindAllUnreadMessageIDsByChatIDAndSenderTypeTx(tx, chatID, clientID, "guest")
-			if err != nil {
-				return err
-			}
+		messageID, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate message id failed", zap.Error(err))
+			return err
+		}
 
-			if len(unreadIDs) > 0 {
-				newRecords := make([]*model.MessageStaff, 0, len(unreadIDs))
-				for _, msgID := range unreadIDs {
-					id, err := s.sfGen.NextID()
-					if err != nil {
-						return err
-					}
+		message = &model.Message{
+			ID:                 messageID,
+			ChatID:             chat.ID,
+			SenderType:         "system",
+			Content:            &content,
+			Language:           language,
+			TranslatedContent:  translatedContent,
+			TranslatedLanguage: translatedLanguage,
+			CreatedAt:          now,
+			Chat:               chat,
+		}
 
-					newRecords = append(newRecords, &model.MessageStaff{
-						ID:        id,
-						MessageID: msgID,
-						StaffID:   clientID,
-						ReadAt:    now,
-					})
-				}
-				if err := s.chatRepo.CreateMessageStaffsTx(tx, newRecords); err != nil {
-					s.logger.Error("create message staffs failed", zap.Error(err))
-					return err
-				}
-			}
+		if err = s.chatRepo.CreateMessageTx(tx, message); err != nil {
+			s.logger.Error("create message failed", zap.Error(err))
+			return err
+		}
 
-			updateData := map[string]any{
-				"is_read": true,
-				"read_at": now,
-			}
+		updateData := map[string]any{"last_message_at": now}
+		if escalate {
+			updateData["escalated_at"] = now
+		}
 
-			if err := s.chatRepo.UpdateMessagesByChatIDAndSenderTypeTx(tx, chatID, "guest", updateData); err != nil {
-				s.logger.Error("update messages by chat id failed", zap.Error(err))
-				return err
-			}
+		if err = s.chatRepo.UpdateChatTx(tx, chat.ID, updateData); err != nil {
+			s.logger.Error("update chat failed", zap.Error(err))
+			return err
 		}
-		if readerType == "guest" {
-			updateData := map[string]any{
-				"is_read": true,
-				"read_at": now,
-			}
-			if err := s.chatRepo.UpdateMessagesByChatIDAndSenderTypeTx(tx, chatID, "staff", updateData); err != nil {
-				s.logger.Error("update messages by chat id failed", zap.Error(err))
-				return err
-			}
+
+		if department == nil {
+			return nil
 		}
 
-		chat, err = s.chatRepo.FindChatByIDTx(tx, chatID)
+		notificationID, err := s.sfGen.NextID()
 		if err != nil {
-			s.logger.Error("find chat by id failed", zap.Error(err))
+			s.logger.Error("generate notification id failed", zap.Error(err))
 			return err
 		}
-		if chat == nil {
-			return common.ErrChatNotFound
+
+		notification := &model.Notification{
+			ID:           notificationID,
+			DepartmentID: department.ID,
+			OrderRoomID:  chat.OrderRoomID,
+			Type:         "chat",
+			Receiver:     "staff",
+			Content:      "Khách hàng cần hỗ trợ ngoài giờ làm việc",
+			ContentID:    chat.ID,
+		}
+
+		if err = s.notificationRepo.CreateNotificationTx(tx, notification); err != nil {
+			s.logger.Error("create notification failed", zap.Error(err))
+			return err
+		}
+
+		staffIDs := make([]int64, 0, len(department.Staffs))
+		for _, staff := range department.Staffs {
+			staffIDs = append(staffIDs, staff.ID)
+		}
+
+		notificationMsg = &types.NotificationMessage{
+			Content:      notification.Content,
+			Type:         notification.Type,
+			ContentID:    notification.ContentID,
+			Receiver:     notification.Receiver,
+			DepartmentID: &department.ID,
+			ReceiverIDs:  staffIDs,
 		}
 
 		return nil
@@ -167,63 +348,1210 @@ func (s *chatSvcImpl) UpdateReadMessages(ctx context.Context, chatID, clientID i
 		return nil, err
 	}
 
-	return chat, nil
+	if notificationMsg != nil {
+		go func(msg types.NotificationMessage) {
+			body, _ := json.Marshal(msg)
+			if err := s.mqProvider.PublishMessage(common.ExchangeNotification, common.RoutingKeyChatNotification, body); err != nil {
+				s.logger.Error("publish chat notification message failed", zap.Error(err))
+			}
+		}(*notificationMsg)
+	}
+
+	return message, nil
 }
 
-func (s *chatSvcImpl) GetChatsForAdmin(ctx context.Context, query types.ChatPaginationQuery, userID int64) ([]*model.Chat, *types.MetaResponse, error) {
-	if query.Page == 0 {
-		query.Page = 1
+// isStaffedHours reports whether staff are expected to answer at t. Without
+// usable staffed hours the desk counts as always staffed, so the bot stays
+// quiet instead of answering around the clock.
+func (s *chatSvcImpl) isStaffedHours(t time.Time) bool {
+	if s.cfg.Chatbot.StaffedFrom == "" || s.cfg.Chatbot.StaffedTo == "" {
+		return true
 	}
-	if query.Limit == 0 {
-		query.Limit = 10
+
+	loc, err := time.LoadLocation(s.cfg.Chatbot.Timezone)
+	if err != nil {
+		loc = time.Local
 	}
 
-	chats, total, err := s.chatRepo.FindAllChatsWithDetailsPaginated(ctx, query, userID)
+	from, err := time.Parse(common.ChatbotTimeLayout, s.cfg.Chatbot.StaffedFrom)
 	if err != nil {
-		s.logger.Error("find all chats paginated failed", zap.Error(err))
-		return nil, nil, err
+		s.logger.Warn("invalid chatbot staffed_from", zap.String("value", s.cfg.Chatbot.StaffedFrom))
+		return true
+	}
+	to, err := time.Parse(common.ChatbotTimeLayout, s.cfg.Chatbot.StaffedTo)
+	if err != nil {
+		s.logger.Warn("invalid chatbot staffed_to", zap.String("value", s.cfg.Chatbot.StaffedTo))
+		return true
 	}
 
-	totalPages := uint32(total) / query.Limit
-	if uint32(total)%query.Limit != 0 {
-		totalPages++
+	local := t.In(loc)
+	minutes := local.Hour()*60 + local.Minute()
+	fromMinutes := from.Hour()*60 + from.Minute()
+	toMinutes := to.Hour()*60 + to.Minute()
+
+	if fromMinutes <= toMinutes {
+		return minutes >= fromMinutes && minutes < toMinutes
 	}
 
-	meta := &types.MetaResponse{
-		Total:      uint64(total),
-		Page:       query.Page,
-		Limit:      query.Limit,
-		TotalPages: uint16(totalPages),
-		HasPrev:    query.Page > 1,
-		HasNext:    query.Page < totalPages,
+	return minutes >= fromMinutes || minutes < toMinutes
+}
+
+func matchFAQ(faqs []*model.FAQ, contents ...*string) *model.FAQ {
+	var texts []string
+	for _, content := range contents {
+		if content != nil {
+			texts = append(texts, "-"+common.GenerateSlug(*content)+"-")
+		}
 	}
 
-	return chats, meta, nil
+	var best *model.FAQ
+	bestScore := 0
+	for _, faq := range faqs {
+		score := 0
+		for keyword := range strings.SplitSeq(faq.Keywords, ",") {
+			slug := common.GenerateSlug(keyword)
+			if slug == "" {
+				continue
+			}
+
+			for _, text := range texts {
+				if strings.Contains(text, "-"+slug+"-") {
+					score++
+					break
+				}
+			}
+		}
+
+		if score > bestScore {
+			best = faq
+			bestScore = score
+		}
+	}
+
+	return best
 }
 
-func (s *chatSvcImpl) GetChatByID(ctx context.Context, chatID, userID int64) (*model.Chat, error) {
-	chat, err := s.chatRepo.FindChatByIDWithDetails(ctx, chatID, userID)
+func (s *chatSvcImpl) translateMessage(ctx context.Context, chatID int64, senderType string, content *string) (*string, *string, *string) {
+	if s.translator == nil || content == nil || strings.TrimSpace(*content) == "" {
+		return nil, nil, nil
+	}
+
+	language, err := s.translator.Detect(ctx, *content)
 	if err != nil {
-		s.logger.Error("find chat by id failed", zap.Error(err))
-		return nil, err
+		s.logger.Warn("detect message language failed", zap.Int64("chat_id", chatID), zap.Error(err))
+		return nil, nil, nil
 	}
 
-	if chat == nil {
-		return nil, common.ErrChatNotFound
+	target := s.cfg.Translation.StaffLanguage
+	if senderType != "guest" {
+		if !s.cfg.Translation.TranslateReplies {
+			return &language, nil, nil
+		}
+
+		chat, err := s.chatRepo.FindChatByIDTx(s.db.WithContext(ctx), chatID)
+		if err != nil {
+			s.logger.Warn("find chat by id failed", zap.Int64("id", chatID), zap.Error(err))
+			return &language, nil, nil
+		}
+		if chat == nil || chat.GuestLanguage == nil {
+			return &language, nil, nil
+		}
+		target = *chat.GuestLanguage
 	}
 
-	return chat, nil
+	if target == "" || target == language {
+		return &language, nil, nil
+	}
+
+	translated, err := s.translator.Translate(ctx, *content, language, target)
+	if err != nil {
+		s.logger.Warn("translate message failed", zap.Int64("chat_id", chatID), zap.String("target", target), zap.Error(err))
+		return &language, nil, nil
+	}
+
+	return &language, &translated, &target
 }
 
-func (s *chatSvcImpl) GetMyChat(ctx context.Context, orderRoomID int64) (*model.Chat, error) {
-	chat, err := s.chatRepo.FindChatByOrderRoomIDWithDetails(ctx, orderRoomID)
+// prepareAttachments validates the uploaded files and renders thumbnails for
+// the images. If it fails, the thumbnails it already stored are deleted.
+func checkChatWritable(chat *model.Chat, clientID int64, senderType string, departmentID *int64) error {
+	if chat == nil || !canClientAccessChat(chat, clientID, senderType, departmentID) {
+		return common.ErrChatNotFound
+	}
+	if senderType == "staff" && chat.AssigneeID != nil && *chat.AssigneeID != clientID {
+		return common.ErrChatAssignedToOther
+	}
+
+	return nil
+}
+
+func (s *chatSvcImpl) prepareAttachments(ctx context.Context, clientID int64, clientType string, reqs []types.CreateMessageAttachmentRequest) ([]*model.MessageAttachment, error) {
+	keys := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		if !common.IsUploadKeyFor(req.Key, common.UploadPurposeChatImage) {
+			return nil, common.ErrInvalidUploadKey
+		}
+		if slices.Contains(keys, req.Key) {
+			return nil, common.ErrAttachmentNotFound
+		}
+		keys = append(keys, req.Key)
+	}
+
+	// Every key must have an upload row owned by the sender, so a key that
+	// was never uploaded through the tracked flow is rejected as well.
+	owned, err := s.fileRepo.CountUploadsOwnedBy(ctx, keys, clientType, clientID)
 	if err != nil {
-		s.logger.Error("find chat by order room id failed", zap.Error(err))
+		s.logger.Error("count uploads owned by client failed", zap.Int64("client_id", clientID), zap.Error(err))
 		return nil, err
 	}
-	if chat == nil {
-		return nil, common.ErrChatNotFound
+	if owned != int64(len(keys)) {
+		return nil, common.ErrAttachmentNotFound
 	}
 
-	return chat, nil
+	attachments := make([]*model.MessageAttachment, 0, len(reqs))
+	prepared := false
+	defer func() {
+		if !prepared {
+			s.publishDeleteFiles(attachmentThumbnailKeys(attachments))
+		}
+	}()
+
+	for i, req := range reqs {
+		attrs, err := s.storage.Attrs(ctx, req.Key)
+		if err != nil {
+			if errors.Is(err, storage.ErrObjectNotExist) {
+				return nil, common.ErrAttachmentNotFound
+			}
+			s.logger.Error("get attachment attrs failed", zap.String("key", req.Key), zap.Error(err))
+			return nil, err
+		}
+
+		if !slices.Contains(common.AllowedAttachmentTypes, attrs.ContentType) {
+			return nil, common.ErrUnsupportedAttachmentType
+		}
+		if attrs.Size > common.MaxAttachmentSize {
+			return nil, common.ErrAttachmentTooLarge
+		}
+
+		id, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate attachment id failed", zap.Error(err))
+			return nil, err
+		}
+
+		attachment := &model.MessageAttachment{
+			ID:          id,
+			Key:         req.Key,
+			FileName:    req.FileName,
+			ContentType: attrs.ContentType,
+			Size:        attrs.Size,
+			SortOrder:   uint32(i + 1),
+		}
+
+		if strings.HasPrefix(attrs.ContentType, "image/") {
+			thumbnailKey, err := s.createThumbnail(ctx, req.Key)
+			if err != nil {
+				return nil, err
+			}
+			attachment.ThumbnailKey = &thumbnailKey
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	prepared = true
+	return attachments, nil
+}
+
+func (s *chatSvcImpl) publishDeleteFiles(keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	go func() {
+		for _, key := range keys {
+			if err := s.mqProvider.PublishMessage(common.ExchangeFile, common.RoutingKeyDeleteFile, []byte(key)); err != nil {
+				s.logger.Error("publish delete file message failed", zap.Error(err))
+			}
+		}
+	}()
+}
+
+func attachmentThumbnailKeys(attachments []*model.MessageAttachment) []string {
+	keys := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		if attachment.ThumbnailKey != nil {
+			keys = append(keys, *attachment.ThumbnailKey)
+		}
+	}
+
+	return keys
+}
+
+func (s *chatSvcImpl) createThumbnail(ctx context.Context, key string) (string, error) {
+	r, err := s.storage.NewReader(ctx, key)
+	if err != nil {
+		s.logger.Error("open attachment failed", zap.String("key", key), zap.Error(err))
+		return "", err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(io.LimitReader(r, common.MaxAttachmentSize))
+	if err != nil {
+		s.logger.Error("read attachment failed", zap.String("key", key), zap.Error(err))
+		return "", err
+	}
+
+	thumbnail, err := s.imgProcessor.Thumbnail(data, common.ThumbnailSize)
+	if err != nil {
+		return "", common.ErrUnsupportedAttachmentType
+	}
+
+	thumbnailKey := fmt.Sprintf("%s%s.jpg", common.ThumbnailPrefix, strings.TrimSuffix(key, filepath.Ext(key)))
+
+	if err = s.storage.Put(ctx, thumbnailKey, thumbnail, &storage.PutOptions{ContentType: "image/jpeg"}); err != nil {
+		s.logger.Error("upload thumbnail failed", zap.String("key", thumbnailKey), zap.Error(err))
+		return "", err
+	}
+
+	return thumbnailKey, nil
+}
+
+func (s *chatSvcImpl) signAttachments(attachments []*model.MessageAttachment) error {
+	for _, attachment := range attachments {
+		url, err := s.signViewURL(attachment.Key)
+		if err != nil {
+			return err
+		}
+		attachment.URL = url
+
+		if attachment.ThumbnailKey != nil {
+			thumbnailURL, err := s.signViewURL(*attachment.ThumbnailKey)
+			if err != nil {
+				return err
+			}
+			attachment.ThumbnailURL = thumbnailURL
+		}
+	}
+
+	return nil
+}
+
+func (s *chatSvcImpl) signViewURL(key string) (string, error) {
+	url, err := s.storage.SignedURL(key, &storage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(15 * time.Minute),
+	})
+	if err != nil {
+		s.logger.Error("generate view signed URL failed", zap.String("key", key), zap.Error(err))
+		return "", err
+	}
+
+	return url, nil
+}
+
+func (s *chatSvcImpl) UpdateReadMessages(ctx context.Context, chatID, clientID int64, readerType string, departmentID *int64) (*model.Chat, error) {
+	var chat *model.Chat
+	var err error
+
+	if err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		chat, err = s.chatRepo.FindChatByIDTx(tx, chatID)
+		if err != nil {
+			s.logger.Error("find chat by id failed", zap.Error(err))
+			return err
+		}
+		if chat == nil || !canClientAccessChat(chat, clientID, readerType, departmentID) {
+			return common.ErrChatNotFound
+		}
+
+		if readerType == "staff" {
+			unreadIDs, err := s.chatRepo.FindAllUnreadMessageIDsByChatIDAndSenderTypeTx(tx, chatID, clientID, "guest")
+			if err != nil {
+				return err
+			}
+
+			if len(unreadIDs) > 0 {
+				newRecords := make([]*model.MessageStaff, 0, len(unreadIDs))
+				for _, msgID := range unreadIDs {
+					id, err := s.sfGen.NextID()
+					if err != nil {
+						return err
+					}
+
+					newRecords = append(newRecords, &model.MessageStaff{
+						ID:        id,
+						MessageID: msgID,
+						StaffID:   clientID,
+						ReadAt:    now,
+					})
+				}
+				if err := s.chatRepo.CreateMessageStaffsTx(tx, newRecords); err != nil {
+					s.logger.Error("create message staffs failed", zap.Error(err))
+					return err
+				}
+			}
+
+			updateData := map[string]any{
+				"is_read":      true,
+				"read_at":      now,
+				"delivered_at": gorm.Expr("COALESCE(delivered_at, ?)", now),
+			}
+
+			if err := s.chatRepo.UpdateMessagesByChatIDAndSenderTypeTx(tx, chatID, "guest", updateData); err != nil {
+				s.logger.Error("update messages by chat id failed", zap.Error(err))
+				return err
+			}
+		}
+		if readerType == "guest" {
+			updateData := map[string]any{
+				"is_read":      true,
+				"read_at":      now,
+				"delivered_at": gorm.Expr("COALESCE(delivered_at, ?)", now),
+			}
+			for _, senderType := range []string{"staff", "system"} {
+				if err := s.chatRepo.UpdateMessagesByChatIDAndSenderTypeTx(tx, chatID, senderType, updateData); err != nil {
+					s.logger.Error("update messages by chat id failed", zap.Error(err))
+					return err
+				}
+			}
+		}
+
+		chat, err = s.chatRepo.FindChatByIDTx(tx, chatID)
+		if err != nil {
+			s.logger.Error("find chat by id failed", zap.Error(err))
+			return err
+		}
+		if chat == nil {
+			return common.ErrChatNotFound
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+func (s *chatSvcImpl) UpdateDeliveredMessages(ctx context.Context, chatID, clientID int64, receiverType string, departmentID *int64) (*model.Chat, time.Time, error) {
+	var chat *model.Chat
+	now := time.Now()
+
+	senderTypes := []string{"staff", "system"}
+	if receiverType == "staff" {
+		senderTypes = []string{"guest"}
+	}
+
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		chat, err = s.chatRepo.FindChatByIDTx(tx, chatID)
+		if err != nil {
+			s.logger.Error("find chat by id failed", zap.Error(err))
+			return err
+		}
+		if chat == nil || !canClientAccessChat(chat, clientID, receiverType, departmentID) {
+			return common.ErrChatNotFound
+		}
+
+		for _, senderType := range senderTypes {
+			if err = s.chatRepo.UpdateUndeliveredMessagesByChatIDAndSenderTypeTx(tx, chatID, senderType, now); err != nil {
+				s.logger.Error("update undelivered messages failed", zap.Error(err))
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return chat, now, nil
+}
+
+func (s *chatSvcImpl) GetChatForClient(ctx context.Context, chatID, clientID int64, clientType string, departmentID *int64) (*model.Chat, error) {
+	chat, err := s.chatRepo.FindChatByIDTx(s.db.WithContext(ctx), chatID)
+	if err != nil {
+		s.logger.Error("find chat by id failed", zap.Error(err))
+		return nil, err
+	}
+	if chat == nil || !canClientAccessChat(chat, clientID, clientType, departmentID) {
+		return nil, common.ErrChatNotFound
+	}
+
+	return chat, nil
+}
+
+func (s *chatSvcImpl) GetChatsForAdmin(ctx context.Context, query types.ChatPaginationQuery, userID int64, departmentID *int64) ([]*model.Chat, *types.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	chats, total, err := s.chatRepo.FindAllChatsWithDetailsPaginated(ctx, query, userID, departmentID)
+	if err != nil {
+		s.logger.Error("find all chats paginated failed", zap.Error(err))
+		return nil, nil, err
+	}
+
+	totalPages := uint32(total) / query.Limit
+	if uint32(total)%query.Limit != 0 {
+		totalPages++
+	}
+
+	meta := &types.MetaResponse{
+		Total:      uint64(total),
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: uint16(totalPages),
+		HasPrev:    query.Page > 1,
+		HasNext:    query.Page < totalPages,
+	}
+
+	return chats, meta, nil
+}
+
+func (s *chatSvcImpl) SearchMessages(ctx context.Context, query types.MessageSearchQuery, departmentID *int64) ([]*types.MessageSearchResponse, *types.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	messages, total, err := s.chatRepo.SearchMessagesPaginated(ctx, query, departmentID)
+	if err != nil {
+		s.logger.Error("search messages failed", zap.Error(err))
+		return nil, nil, err
+	}
+
+	totalPages := uint32(total) / query.Limit
+	if uint32(total)%query.Limit != 0 {
+		totalPages++
+	}
+
+	meta := &types.MetaResponse{
+		Total:      uint64(total),
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: uint16(totalPages),
+		HasPrev:    query.Page > 1,
+		HasNext:    query.Page < totalPages,
+	}
+
+	return messages, meta, nil
+}
+
+func (s *chatSvcImpl) GetChatByID(ctx context.Context, chatID, userID int64, departmentID *int64) (*model.Chat, error) {
+	chat, err := s.chatRepo.FindChatByIDWithDetails(ctx, chatID, userID)
+	if err != nil {
+		s.logger.Error("find chat by id failed", zap.Error(err))
+		return nil, err
+	}
+
+	if chat == nil || !canAccessChat(chat, departmentID) {
+		return nil, common.ErrChatNotFound
+	}
+
+	return chat, nil
+}
+
+func (s *chatSvcImpl) ExportTranscript(ctx context.Context, chatID, userID int64, departmentID *int64, format string) ([]byte, string, string, error) {
+	chat, err := s.GetChatByID(ctx, chatID, userID, departmentID)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	transcript, err := s.loadTranscript(ctx, chat)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	fileName := fmt.Sprintf("chat-%d-transcript", transcript.ChatID)
+	switch format {
+	case "txt":
+		lines := append([]string{transcriptTitle(transcript)}, transcriptHeader(transcript)...)
+		lines = append(lines, "")
+		lines = append(lines, transcriptLines(transcript)...)
+		return []byte(strings.Join(lines, "\n") + "\n"), "text/plain; charset=utf-8", fileName + ".txt", nil
+	case "pdf":
+		paragraphs := append(transcriptHeader(transcript), "")
+		paragraphs = append(paragraphs, transcriptLines(transcript)...)
+		data, err := s.pdfGen.Document(transcriptTitle(transcript), paragraphs)
+		if err != nil {
+			s.logger.Error("generate transcript pdf failed", zap.Int64("id", chatID), zap.Error(err))
+			return nil, "", "", err
+		}
+		return data, "application/pdf", fileName + ".pdf", nil
+	default:
+		data, err := json.MarshalIndent(transcript, "", "  ")
+		if err != nil {
+			return nil, "", "", err
+		}
+		return data, "application/json; charset=utf-8", fileName + ".json", nil
+	}
+}
+
+func (s *chatSvcImpl) loadTranscript(ctx context.Context, chat *model.Chat) (*types.ChatTranscriptResponse, error) {
+	if chat.ArchivedAt == nil {
+		return common.ToChatTranscriptResponse(chat, time.Now()), nil
+	}
+	if chat.ArchiveKey == nil {
+		return nil, common.ErrChatArchived
+	}
+
+	reader, err := s.storage.NewReader(ctx, *chat.ArchiveKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, common.ErrChatArchived
+		}
+		s.logger.Error("read chat archive failed", zap.String("key", *chat.ArchiveKey), zap.Error(err))
+		return nil, err
+	}
+	defer reader.Close()
+
+	var transcript types.ChatTranscriptResponse
+	if err = json.NewDecoder(reader).Decode(&transcript); err != nil {
+		s.logger.Error("decode chat archive failed", zap.String("key", *chat.ArchiveKey), zap.Error(err))
+		return nil, err
+	}
+	transcript.ExportedAt = time.Now()
+
+	return &transcript, nil
+}
+
+func (s *chatSvcImpl) SendCheckoutTranscripts(ctx context.Context) error {
+	if !s.cfg.Chat.TranscriptEmail {
+		return nil
+	}
+
+	chatIDs, err := s.chatRepo.FindAllChatIDsForTranscriptEmail(ctx, time.Now(), common.TranscriptBatchSize)
+	if err != nil {
+		s.logger.Error("find chats for transcript email failed", zap.Error(err))
+		return err
+	}
+
+	for _, chatID := range chatIDs {
+		chat, err := s.chatRepo.FindChatByIDWithDetails(ctx, chatID, 0)
+		if err != nil {
+			s.logger.Error("find chat by id failed", zap.Int64("id", chatID), zap.Error(err))
+			return err
+		}
+		if chat == nil || chat.OrderRoom == nil || chat.OrderRoom.Booking == nil {
+			continue
+		}
+
+		if len(chat.Messages) > 0 {
+			transcript := common.ToChatTranscriptResponse(chat, time.Now())
+			emailMsg := types.TranscriptEmailMessage{
+				To:        chat.OrderRoom.Booking.GuestEmail,
+				Subject:   "Lịch sử trò chuyện của bạn tại Instay",
+				GuestName: transcript.GuestFullName,
+				RoomName:  transcript.RoomName,
+				Lines:     transcriptLines(transcript),
+			}
+
+			body, _ := json.Marshal(emailMsg)
+			if err = s.mqProvider.PublishMessage(common.ExchangeEmail, common.RoutingKeyTranscriptEmail, body); err != nil {
+				s.logger.Error("publish transcript email message failed", zap.Int64("id", chatID), zap.Error(err))
+				continue
+			}
+		}
+
+		if err = s.chatRepo.UpdateChatTx(s.db.WithContext(ctx), chatID, map[string]any{"transcript_sent_at": time.Now()}); err != nil {
+			s.logger.Error("update chat failed", zap.Int64("id", chatID), zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *chatSvcImpl) ApplyRetention(ctx context.Context) error {
+	if s.cfg.Chat.RetentionDays <= 0 {
+		return nil
+	}
+
+	expiredBefore := time.Now().AddDate(0, 0, -s.cfg.Chat.RetentionDays)
+	chatIDs, err := s.chatRepo.FindAllChatIDsForRetention(ctx, expiredBefore, common.TranscriptBatchSize)
+	if err != nil {
+		s.logger.Error("find chats for retention failed", zap.Error(err))
+		return err
+	}
+
+	for _, chatID := range chatIDs {
+		if err = s.retainChat(ctx, chatID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *chatSvcImpl) retainChat(ctx context.Context, chatID int64) error {
+	chat, err := s.chatRepo.FindChatByIDWithDetails(ctx, chatID, 0)
+	if err != nil {
+		s.logger.Error("find chat by id failed", zap.Int64("id", chatID), zap.Error(err))
+		return err
+	}
+	if chat == nil {
+		return nil
+	}
+
+	var archiveKey *string
+	if s.cfg.Chat.RetentionMode != "purge" {
+		key := fmt.Sprintf("%s%d.json", common.TranscriptArchivePrefix, chat.ID)
+		data, err := json.Marshal(common.ToChatTranscriptResponse(chat, time.Now()))
+		if err != nil {
+			return err
+		}
+
+		if err = s.storage.Put(ctx, key, data, &storage.PutOptions{ContentType: "application/json"}); err != nil {
+			s.logger.Error("write chat archive failed", zap.String("key", key), zap.Error(err))
+			return err
+		}
+		archiveKey = &key
+	}
+
+	var fileKeys []string
+	for _, message := range chat.Messages {
+		for _, attachment := range message.Attachments {
+			fileKeys = append(fileKeys, attachment.Key)
+			if attachment.ThumbnailKey != nil {
+				fileKeys = append(fileKeys, *attachment.ThumbnailKey)
+			}
+		}
+	}
+
+	if err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.chatRepo.DeleteMessagesByChatIDTx(tx, chat.ID); err != nil {
+			s.logger.Error("delete messages failed", zap.Int64("chat_id", chat.ID), zap.Error(err))
+			return err
+		}
+
+		updateData := map[string]any{
+			"archived_at": time.Now(),
+			"archive_key": archiveKey,
+		}
+		if err := s.chatRepo.UpdateChatTx(tx, chat.ID, updateData); err != nil {
+			s.logger.Error("update chat failed", zap.Int64("id", chat.ID), zap.Error(err))
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	go func() {
+		for _, key := range fileKeys {
+			if err := s.mqProvider.PublishMessage(common.ExchangeFile, common.RoutingKeyDeleteFile, []byte(key)); err != nil {
+				s.logger.Error("publish delete file message failed", zap.Error(err))
+			}
+		}
+	}()
+
+	return nil
+}
+
+func transcriptTitle(transcript *types.ChatTranscriptResponse) string {
+	return fmt.Sprintf("Lịch sử trò chuyện - %s", transcript.RoomName)
+}
+
+func transcriptHeader(transcript *types.ChatTranscriptResponse) []string {
+	header := []string{
+		fmt.Sprintf("Mã đặt phòng: %s", transcript.BookingNumber),
+		fmt.Sprintf("Khách hàng: %s", transcript.GuestFullName),
+	}
+	if transcript.CheckIn != nil && transcript.CheckOut != nil {
+		header = append(header,
+			fmt.Sprintf("Nhận phòng: %s", transcript.CheckIn.Format(common.TranscriptTimeLayout)),
+			fmt.Sprintf("Trả phòng: %s", transcript.CheckOut.Format(common.TranscriptTimeLayout)),
+		)
+	}
+
+	return header
+}
+
+func transcriptLines(transcript *types.ChatTranscriptResponse) []string {
+	lines := make([]string, 0, len(transcript.Messages))
+	for _, message := range transcript.Messages {
+		var content string
+		if message.Content != nil {
+			content = *message.Content
+		}
+		if len(message.Attachments) > 0 {
+			content = strings.TrimSpace(fmt.Sprintf("%s [Tệp đính kèm: %s]", content, strings.Join(message.Attachments, ", ")))
+		}
+
+		lines = append(lines, fmt.Sprintf("[%s] %s: %s", message.CreatedAt.Format(common.TranscriptTimeLayout), message.SenderName, content))
+	}
+
+	return lines
+}
+
+func (s *chatSvcImpl) ClaimChat(ctx context.Context, chatID, userID int64, departmentID *int64) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		chat, err := s.findChatForUpdate(tx, chatID, departmentID)
+		if err != nil {
+			return err
+		}
+		if chat.AssigneeID != nil {
+			if *chat.AssigneeID == userID {
+				return nil
+			}
+			return common.ErrChatAlreadyAssigned
+		}
+
+		return s.updateChatAssignee(tx, chat, userID, &userID, nil, "claim", nil)
+	})
+}
+
+func (s *chatSvcImpl) AssignChat(ctx context.Context, chatID, userID int64, departmentID *int64, req types.AssignChatRequest) error {
+	assignee, err := s.userRepo.FindByIDWithDepartment(ctx, req.AssigneeID)
+	if err != nil {
+		s.logger.Error("find user by id failed", zap.Int64("id", req.AssigneeID), zap.Error(err))
+		return err
+	}
+	if assignee == nil || !assignee.IsActive {
+		return common.ErrUserNotFound
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		chat, err := s.findChatForUpdate(tx, chatID, departmentID)
+		if err != nil {
+			return err
+		}
+		if !canHandleChat(assignee, chat.DepartmentID) {
+			return common.ErrAssigneeNotInDepartment
+		}
+
+		return s.updateChatAssignee(tx, chat, userID, &assignee.ID, nil, "assign", nil)
+	})
+}
+
+func (s *chatSvcImpl) TransferChat(ctx context.Context, chatID, userID int64, departmentID *int64, req types.TransferChatRequest) error {
+	if req.AssigneeID == nil && req.DepartmentID == nil {
+		return common.ErrTransferTargetRequired
+	}
+
+	if req.DepartmentID != nil {
+		department, err := s.departmentRepo.FindByID(ctx, *req.DepartmentID)
+		if err != nil {
+			s.logger.Error("find department by id failed", zap.Int64("id", *req.DepartmentID), zap.Error(err))
+			return err
+		}
+		if department == nil {
+			return common.ErrDepartmentNotFound
+		}
+	}
+
+	var assignee *model.User
+	if req.AssigneeID != nil {
+		var err error
+		assignee, err = s.userRepo.FindByIDWithDepartment(ctx, *req.AssigneeID)
+		if err != nil {
+			s.logger.Error("find user by id failed", zap.Int64("id", *req.AssigneeID), zap.Error(err))
+			return err
+		}
+		if assignee == nil || !assignee.IsActive {
+			return common.ErrUserNotFound
+		}
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		chat, err := s.findChatForUpdate(tx, chatID, departmentID)
+		if err != nil {
+			return err
+		}
+		if chat.AssigneeID != nil && *chat.AssigneeID != userID {
+			return common.ErrChatAssignedToOther
+		}
+
+		targetDepartmentID := chat.DepartmentID
+		if req.DepartmentID != nil {
+			targetDepartmentID = req.DepartmentID
+		}
+		if assignee != nil && !canHandleChat(assignee, targetDepartmentID) {
+			return common.ErrAssigneeNotInDepartment
+		}
+
+		return s.updateChatAssignee(tx, chat, userID, req.AssigneeID, req.DepartmentID, "transfer", &req.Note)
+	})
+}
+
+func (s *chatSvcImpl) findChatForUpdate(tx *gorm.DB, chatID int64, departmentID *int64) (*model.Chat, error) {
+	chat, err := s.chatRepo.FindChatByIDForUpdateTx(tx, chatID)
+	if err != nil {
+		if strings.Contains(err.Error(), "lock") {
+			return nil, common.ErrLockedRecord
+		}
+		s.logger.Error("find chat by id failed", zap.Int64("id", chatID), zap.Error(err))
+		return nil, err
+	}
+	if chat == nil || !canAccessChat(chat, departmentID) {
+		return nil, common.ErrChatNotFound
+	}
+
+	return chat, nil
+}
+
+func (s *chatSvcImpl) updateChatAssignee(tx *gorm.DB, chat *model.Chat, userID int64, assigneeID, departmentID *int64, action string, note *string) error {
+	var assignedAt *time.Time
+	if assigneeID != nil {
+		now := time.Now()
+		assignedAt = &now
+	}
+
+	updateData := map[string]any{
+		"assignee_id": assigneeID,
+		"assigned_at": assignedAt,
+	}
+	if departmentID != nil {
+		updateData["department_id"] = *departmentID
+	}
+
+	if err := s.chatRepo.UpdateChatTx(tx, chat.ID, updateData); err != nil {
+		s.logger.Error("update chat failed", zap.Int64("id", chat.ID), zap.Error(err))
+		return err
+	}
+
+	transferID, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate chat transfer id failed", zap.Error(err))
+		return err
+	}
+
+	transfer := &model.ChatTransfer{
+		ID:             transferID,
+		ChatID:         chat.ID,
+		Action:         action,
+		FromStaffID:    chat.AssigneeID,
+		ToStaffID:      assigneeID,
+		ToDepartmentID: departmentID,
+		Note:           note,
+		CreatedByID:    userID,
+	}
+
+	if err = s.chatRepo.CreateChatTransferTx(tx, transfer); err != nil {
+		s.logger.Error("create chat transfer failed", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func canAccessChat(chat *model.Chat, departmentID *int64) bool {
+	if departmentID == nil {
+		return true
+	}
+
+	return chat.DepartmentID != nil && *chat.DepartmentID == *departmentID
+}
+
+// canClientAccessChat applies the admin chat scope to WebSocket clients:
+// guests only reach their own chat, staff only chats in departmentID.
+func canClientAccessChat(chat *model.Chat, clientID int64, clientType string, departmentID *int64) bool {
+	if clientType == "guest" {
+		return chat.OrderRoomID == clientID
+	}
+
+	return canAccessChat(chat, departmentID)
+}
+
+func canHandleChat(staff *model.User, chatDepartmentID *int64) bool {
+	if staff.Department == nil {
+		return staff.Role == common.RoleAdmin
+	}
+	if staff.Department.Name == common.ChatDepartment {
+		return true
+	}
+
+	return chatDepartmentID != nil && *chatDepartmentID == staff.Department.ID
+}
+
+func (s *chatSvcImpl) GetMyChat(ctx context.Context, orderRoomID int64) (*model.Chat, error) {
+	chat, err := s.chatRepo.FindChatByOrderRoomIDWithDetails(ctx, orderRoomID)
+	if err != nil {
+		s.logger.Error("find chat by order room id failed", zap.Error(err))
+		return nil, err
+	}
+	if chat == nil {
+		return nil, common.ErrChatNotFound
+	}
+
+	return chat, nil
+}
+
+func (s *chatSvcImpl) CreateCannedResponse(ctx context.Context, userID int64, req types.CreateCannedResponseRequest) error {
+	id, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate canned response id failed", zap.Error(err))
+		return err
+	}
+
+	cannedResponse := &model.CannedResponse{
+		ID:           id,
+		Title:        req.Title,
+		Shortcut:     req.Shortcut,
+		Content:      req.Content,
+		DepartmentID: req.DepartmentID,
+		CreatedByID:  userID,
+		UpdatedByID:  userID,
+	}
+
+	if err = s.chatRepo.CreateCannedResponse(ctx, cannedResponse); err != nil {
+		if ok, _ := common.IsUniqueViolation(err); ok {
+			return common.ErrCannedResponseAlreadyExists
+		}
+		if common.IsForeignKeyViolation(err) {
+			return common.ErrDepartmentNotFound
+		}
+		s.logger.Error("create canned response failed", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *chatSvcImpl) GetCannedResponses(ctx context.Context, query types.CannedResponseQuery, departmentID *int64) ([]*model.CannedResponse, error) {
+	if departmentID != nil {
+		query.DepartmentID = *departmentID
+	}
+
+	cannedResponses, err := s.chatRepo.FindAllCannedResponsesWithDetails(ctx, query)
+	if err != nil {
+		s.logger.Error("find all canned responses failed", zap.Error(err))
+		return nil, err
+	}
+
+	return cannedResponses, nil
+}
+
+func (s *chatSvcImpl) UpdateCannedResponse(ctx context.Context, cannedResponseID, userID int64, req types.UpdateCannedResponseRequest) error {
+	cannedResponse, err := s.chatRepo.FindCannedResponseByID(ctx, cannedResponseID)
+	if err != nil {
+		s.logger.Error("find canned response by id failed", zap.Int64("id", cannedResponseID), zap.Error(err))
+		return err
+	}
+	if cannedResponse == nil {
+		return common.ErrCannedResponseNotFound
+	}
+
+	updateData := map[string]any{}
+
+	if req.Title != nil && *req.Title != cannedResponse.Title {
+		updateData["title"] = *req.Title
+	}
+	if req.Shortcut != nil && *req.Shortcut != cannedResponse.Shortcut {
+		updateData["shortcut"] = *req.Shortcut
+	}
+	if req.Content != nil && *req.Content != cannedResponse.Content {
+		updateData["content"] = *req.Content
+	}
+	if req.DepartmentID != nil && *req.DepartmentID != cannedResponse.DepartmentID {
+		updateData["department_id"] = *req.DepartmentID
+	}
+
+	if len(updateData) > 0 {
+		updateData["updated_by_id"] = userID
+		if err = s.chatRepo.UpdateCannedResponse(ctx, cannedResponseID, updateData); err != nil {
+			if ok, _ := common.IsUniqueViolation(err); ok {
+				return common.ErrCannedResponseAlreadyExists
+			}
+			if common.IsForeignKeyViolation(err) {
+				return common.ErrDepartmentNotFound
+			}
+			s.logger.Error("update canned response failed", zap.Int64("id", cannedResponseID), zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *chatSvcImpl) DeleteCannedResponse(ctx context.Context, cannedResponseID int64) error {
+	if err := s.chatRepo.DeleteCannedResponse(ctx, cannedResponseID); err != nil {
+		if errors.Is(err, common.ErrCannedResponseNotFound) {
+			return err
+		}
+		s.logger.Error("delete canned response failed", zap.Int64("id", cannedResponseID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *chatSvcImpl) CreateFAQ(ctx context.Context, userID int64, req types.CreateFAQRequest) error {
+	id, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate faq id failed", zap.Error(err))
+		return err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	faq := &model.FAQ{
+		ID:          id,
+		Question:    req.Question,
+		Answer:      req.Answer,
+		Keywords:    joinKeywords(req.Keywords),
+		IsActive:    isActive,
+		CreatedByID: userID,
+		UpdatedByID: userID,
+	}
+
+	if err = s.chatRepo.CreateFAQ(ctx, faq); err != nil {
+		s.logger.Error("create faq failed", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *chatSvcImpl) GetFAQs(ctx context.Context, query types.FAQQuery) ([]*model.FAQ, error) {
+	faqs, err := s.chatRepo.FindAllFAQsWithDetails(ctx, query)
+	if err != nil {
+		s.logger.Error("find all faqs failed", zap.Error(err))
+		return nil, err
+	}
+
+	return faqs, nil
+}
+
+func (s *chatSvcImpl) UpdateFAQ(ctx context.Context, faqID, userID int64, req types.UpdateFAQRequest) error {
+	faq, err := s.chatRepo.FindFAQByID(ctx, faqID)
+	if err != nil {
+		s.logger.Error("find faq by id failed", zap.Int64("id", faqID), zap.Error(err))
+		return err
+	}
+	if faq == nil {
+		return common.ErrFAQNotFound
+	}
+
+	updateData := map[string]any{}
+
+	if req.Question != nil && *req.Question != faq.Question {
+		updateData["question"] = *req.Question
+	}
+	if req.Answer != nil && *req.Answer != faq.Answer {
+		updateData["answer"] = *req.Answer
+	}
+	if req.Keywords != nil {
+		if keywords := joinKeywords(req.Keywords); keywords != faq.Keywords {
+			updateData["keywords"] = keywords
+		}
+	}
+	if req.IsActive != nil && *req.IsActive != faq.IsActive {
+		updateData["is_active"] = *req.IsActive
+	}
+
+	if len(updateData) > 0 {
+		updateData["updated_by_id"] = userID
+		if err = s.chatRepo.UpdateFAQ(ctx, faqID, updateData); err != nil {
+			if errors.Is(err, common.ErrFAQNotFound) {
+				return err
+			}
+			s.logger.Error("update faq failed", zap.Int64("id", faqID), zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *chatSvcImpl) DeleteFAQ(ctx context.Context, faqID int64) error {
+	if err := s.chatRepo.DeleteFAQ(ctx, faqID); err != nil {
+		if errors.Is(err, common.ErrFAQNotFound) {
+			return err
+		}
+		s.logger.Error("delete faq failed", zap.Int64("id", faqID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func joinKeywords(keywords []string) string {
+	cleaned := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		keyword = strings.TrimSpace(strings.ReplaceAll(keyword, ",", " "))
+		if keyword != "" && !slices.Contains(cleaned, keyword) {
+			cleaned = append(cleaned, keyword)
+		}
+	}
+
+	return strings.Join(cleaned, ",")
+}
+
+func (s *chatSvcImpl) renderCannedResponse(ctx context.Context, chatID, staffID int64, senderType string, cannedResponseID int64) (string, error) {
+	if senderType != "staff" {
+		return "", common.ErrForbidden
+	}
+
+	cannedResponse, err := s.chatRepo.FindCannedResponseByID(ctx, cannedResponseID)
+	if err != nil {
+		s.logger.Error("find canned response by id failed", zap.Int64("id", cannedResponseID), zap.Error(err))
+		return "", err
+	}
+	if cannedResponse == nil {
+		return "", common.ErrCannedResponseNotFound
+	}
+
+	staff, err := s.userRepo.FindByIDWithDepartment(ctx, staffID)
+	if err != nil {
+		s.logger.Error("find user by id failed", zap.Int64("id", staffID), zap.Error(err))
+		return "", err
+	}
+	if staff == nil {
+		return "", common.ErrUserNotFound
+	}
+	if staff.Role != common.RoleAdmin && (staff.DepartmentID == nil || *staff.DepartmentID != cannedResponse.DepartmentID) {
+		return "", common.ErrCannedResponseNotFound
+	}
+
+	chat, err := s.chatRepo.FindChatByIDTx(s.db.WithContext(ctx), chatID)
+	if err != nil {
+		s.logger.Error("find chat by id failed", zap.Int64("id", chatID), zap.Error(err))
+		return "", err
+	}
+	if chat == nil {
+		return "", common.ErrChatNotFound
+	}
+
+	orderRoom, err := s.orderRepo.FindOrderRoomByIDWithDetails(ctx, chat.OrderRoomID)
+	if err != nil {
+		s.logger.Error("find order room by id failed", zap.Int64("id", chat.OrderRoomID), zap.Error(err))
+		return "", err
+	}
+	if orderRoom == nil {
+		return "", common.ErrOrderRoomNotFound
+	}
+
+	var guestName, bookingNumber, checkIn, checkOut, roomName string
+	if orderRoom.Booking != nil {
+		guestName = orderRoom.Booking.GuestFullName
+		bookingNumber = orderRoom.Booking.BookingNumber
+		checkIn = orderRoom.Booking.CheckIn.Format(common.CannedResponseTimeLayout)
+		checkOut = orderRoom.Booking.CheckOut.Format(common.CannedResponseTimeLayout)
+	}
+	if orderRoom.Room != nil {
+		roomName = orderRoom.Room.Name
+	}
+
+	replacer := strings.NewReplacer(
+		"{{guest_name}}", guestName,
+		"{{room_name}}", roomName,
+		"{{booking_number}}", bookingNumber,
+		"{{check_in}}", checkIn,
+		"{{check_out}}", checkOut,
+	)
+
+	return replacer.Replace(cannedResponse.Content), nil
 }
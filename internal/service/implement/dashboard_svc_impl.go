@@ -47,13 +47,15 @@ func NewDashboardService(
 
 func (s *dashboardSvcImpl) Overview(ctx context.Context) (*types.DashboardResponse, error) {
 	res := &types.DashboardResponse{
-		OrderServiceStats:    make([]*types.StatusChartResponse, 0),
-		RequestStats:         make([]*types.StatusChartResponse, 0),
-		DailyBookingStats:    make([]*types.DailyBookingChartResponse, 0),
-		BookingSourceStats:   make([]*types.ChartData, 0),
-		ServiceUsageStats:    make([]*types.ChartData, 0),
-		PopularRoomTypeStats: make([]*types.PopularRoomTypeChartData, 0),
-		RevenueSourceStats:   make([]*types.ChartData, 0),
+		OrderServiceStats:       make([]*types.StatusChartResponse, 0),
+		RequestStats:            make([]*types.StatusChartResponse, 0),
+		DailyBookingStats:       make([]*types.DailyBookingChartResponse, 0),
+		BookingSourceStats:      make([]*types.ChartData, 0),
+		ServiceUsageStats:       make([]*types.ChartData, 0),
+		PopularRoomTypeStats:    make([]*types.PopularRoomTypeChartData, 0),
+		RevenueSourceStats:      make([]*types.ChartData, 0),
+		PricingRuleRevenueStats: make([]*types.PricingRuleRevenueChartData, 0),
+		SLAComplianceStats:      make([]*types.SLAComplianceResponse, 0),
 	}
 
 	g, ctx := errgroup.WithContext(ctx)
@@ -150,6 +152,22 @@ func (s *dashboardSvcImpl) Overview(ctx context.Context) (*types.DashboardRespon
 		return nil
 	})
 
+	g.Go(func() error {
+		data, err := s.orderRepo.GetServiceRevenueByPricingRule(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range data {
+			item.Discount = item.ListRevenue - item.Revenue
+			res.ServiceListRevenue += item.ListRevenue
+			res.ServiceRevenue += item.Revenue
+		}
+		res.ServiceDiscount = res.ServiceListRevenue - res.ServiceRevenue
+		res.PricingRuleRevenueStats = data
+		return nil
+	})
+
 	g.Go(func() error {
 		count, err := s.serviceRepo.CountService(ctx)
 		if err != nil {
@@ -199,6 +217,22 @@ func (s *dashboardSvcImpl) Overview(ctx context.Context) (*types.DashboardRespon
 		return nil
 	})
 
+	g.Go(func() error {
+		data, err := s.requestRepo.SLAComplianceByDepartment(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range data {
+			item.Met = item.Total - item.Breached
+			if item.Total > 0 {
+				item.Compliance = math.Round((float64(item.Met)/float64(item.Total))*100*100) / 100
+			}
+		}
+		res.SLAComplianceStats = data
+		return nil
+	})
+
 	g.Go(func() error {
 		minDate, maxDate, err := s.bookingRepo.GetBookingDateRange(ctx)
 		if err != nil {
@@ -15,17 +15,20 @@ import (
 
 type departmentSvcImpl struct {
 	departmentRepo repository.DepartmentRepository
+	userRepo       repository.UserRepository
 	sfGen          snowflake.Generator
 	logger         *zap.Logger
 }
 
 func NewDepartmentService(
 	departmentRepo repository.DepartmentRepository,
+	userRepo repository.UserRepository,
 	sfGen snowflake.Generator,
 	logger *zap.Logger,
 ) service.DepartmentService {
 	return &departmentSvcImpl{
 		departmentRepo,
+		userRepo,
 		sfGen,
 		logger,
 	}
@@ -119,6 +122,20 @@ func (s *departmentSvcImpl) UpdateDepartment(ctx context.Context, id, userID int
 	if req.Description != nil && department.Description != *req.Description {
 		updateData["description"] = *req.Description
 	}
+	if req.HeadID != nil && (department.HeadID == nil || *department.HeadID != *req.HeadID) {
+		head, err := s.userRepo.FindByIDWithDepartment(ctx, *req.HeadID)
+		if err != nil {
+			s.logger.Error("find user by id failed", zap.Int64("id", *req.HeadID), zap.Error(err))
+			return err
+		}
+		if head == nil {
+			return common.ErrUserNotFound
+		}
+		if head.DepartmentID == nil || *head.DepartmentID != id {
+			return common.ErrDepartmentHeadNotInDepartment
+		}
+		updateData["head_id"] = *req.HeadID
+	}
 
 	if len(updateData) > 0 {
 		updateData["updated_by_id"] = userID
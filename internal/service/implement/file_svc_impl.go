@@ -2,78 +2,166 @@ package implement
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
-	"cloud.google.com/go/storage"
 	"github.com/InstaySystem/is_v1-be/internal/common"
 	"github.com/InstaySystem/is_v1-be/internal/config"
+	"github.com/InstaySystem/is_v1-be/internal/model"
+	"github.com/InstaySystem/is_v1-be/internal/provider/mq"
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
+	"github.com/InstaySystem/is_v1-be/internal/repository"
 	"github.com/InstaySystem/is_v1-be/internal/service"
 	"github.com/InstaySystem/is_v1-be/internal/types"
+	"github.com/InstaySystem/is_v1-be/pkg/snowflake"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+const defaultUploadGracePeriod = 24 * time.Hour
+
 type fileSvcImpl struct {
-	client *storage.Client
-	cfg    *config.Config
-	logger *zap.Logger
+	fileRepo   repository.FileRepository
+	sfGen      snowflake.Generator
+	storage    storage.StorageProvider
+	mqProvider mq.MessageQueueProvider
+	cfg        *config.Config
+	logger     *zap.Logger
 }
 
-func NewFileService(client *storage.Client, cfg *config.Config, logger *zap.Logger) service.FileService {
+func NewFileService(
+	fileRepo repository.FileRepository,
+	sfGen snowflake.Generator,
+	storage storage.StorageProvider,
+	mqProvider mq.MessageQueueProvider,
+	cfg *config.Config,
+	logger *zap.Logger,
+) service.FileService {
 	return &fileSvcImpl{
-		client,
+		fileRepo,
+		sfGen,
+		storage,
+		mqProvider,
 		cfg,
 		logger,
 	}
 }
 
-func (s *fileSvcImpl) CreateUploadURLs(ctx context.Context, req types.UploadPresignedURLsRequest) ([]*types.UploadPresignedURLResponse, error) {
+func (s *fileSvcImpl) CreateUploadURLs(ctx context.Context, clientID int64, clientType string, req types.UploadPresignedURLsRequest) ([]*types.UploadPresignedURLResponse, error) {
+	requested := make(map[string]int64)
+	for _, file := range req.Files {
+		policy := common.UploadPolicies[file.Purpose]
+		if !slices.Contains(policy.ClientTypes, clientType) {
+			return nil, common.ErrForbidden
+		}
+		if !slices.Contains(policy.AllowedTypes, file.ContentType) {
+			return nil, common.ErrUnsupportedFileType
+		}
+		if file.Size > policy.MaxSize {
+			return nil, common.ErrFileTooLarge
+		}
+		requested[file.Purpose]++
+	}
+
+	since := time.Now().Add(-24 * time.Hour)
+	for purpose, count := range requested {
+		used, err := s.fileRepo.CountUploadsByOwnerSince(ctx, clientType, clientID, purpose, since)
+		if err != nil {
+			s.logger.Error("count uploads failed", zap.Int64("owner_id", clientID), zap.String("purpose", purpose), zap.Error(err))
+			return nil, err
+		}
+		if used+count > common.UploadPolicies[purpose].DailyQuota {
+			return nil, common.ErrUploadQuotaExceeded
+		}
+	}
+
 	result := make([]*types.UploadPresignedURLResponse, 0, len(req.Files))
+	uploads := make([]*model.Upload, 0, len(req.Files))
 
 	for _, file := range req.Files {
 		name := strings.TrimSuffix(file.FileName, filepath.Ext(file.FileName))
 		ext := filepath.Ext(file.FileName)
 
-		key := fmt.Sprintf("%s-%s%s", uuid.NewString(), common.GenerateSlug(name), ext)
-
-		url, err := s.client.Bucket(s.cfg.GCS.Bucket).SignedURL(
-			key,
-			&storage.SignedURLOptions{
-				Method:      "PUT",
-				Expires:     time.Now().Add(15 * time.Minute),
-				ContentType: file.ContentType,
-				Scheme:      storage.SigningSchemeV4,
-			},
-		)
+		key := fmt.Sprintf("%s%s-%s%s", common.UploadPolicies[file.Purpose].Prefix, uuid.NewString(), common.GenerateSlug(name), ext)
+
+		opts := &storage.SignedURLOptions{
+			Method:        http.MethodPut,
+			Expires:       time.Now().Add(15 * time.Minute),
+			ContentType:   file.ContentType,
+			ContentLength: file.Size,
+		}
+		url, err := s.storage.SignedURL(key, opts)
 		if err != nil {
 			s.logger.Error("generate upload signed URL failed", zap.Error(err))
 			return nil, err
 		}
 
+		id, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate upload id failed", zap.Error(err))
+			return nil, err
+		}
+
+		uploads = append(uploads, &model.Upload{
+			ID:          id,
+			Key:         key,
+			Purpose:     file.Purpose,
+			OwnerType:   clientType,
+			OwnerID:     clientID,
+			ContentType: file.ContentType,
+			Size:        file.Size,
+			Status:      common.UploadStatusPending,
+		})
 		result = append(result, &types.UploadPresignedURLResponse{
-			Key: key,
-			Url: url,
+			Key:     key,
+			Url:     url,
+			Headers: s.storage.UploadHeaders(opts),
 		})
 	}
 
+	if err := s.fileRepo.CreateUploads(ctx, uploads); err != nil {
+		s.logger.Error("create uploads failed", zap.Error(err))
+		return nil, err
+	}
+
 	return result, nil
 }
 
-func (s *fileSvcImpl) CreateViewURLs(ctx context.Context, req types.ViewPresignedURLsRequest) ([]*types.ViewPresignedURLResponse, error) {
+func (s *fileSvcImpl) CreateViewURLs(ctx context.Context, clientID int64, clientType string, req types.ViewPresignedURLsRequest) ([]*types.ViewPresignedURLResponse, error) {
+	if clientType != "staff" {
+		var private []string
+		for _, key := range req.Keys {
+			if !isPublicUploadKey(key) {
+				private = append(private, key)
+			}
+		}
+
+		visible, err := s.fileRepo.FindKeysVisibleToGuest(ctx, clientID, private)
+		if err != nil {
+			s.logger.Error("find keys visible to guest failed", zap.Int64("order_room_id", clientID), zap.Error(err))
+			return nil, err
+		}
+		for _, key := range private {
+			if !slices.Contains(visible, key) {
+				return nil, common.ErrForbidden
+			}
+		}
+	}
+
 	result := make([]*types.ViewPresignedURLResponse, 0, len(req.Keys))
 
 	for _, key := range req.Keys {
-		url, err := s.client.Bucket(s.cfg.GCS.Bucket).SignedURL(
-			key,
-			&storage.SignedURLOptions{
-				Method:  "GET",
-				Expires: time.Now().Add(15 * time.Minute),
-				Scheme:  storage.SigningSchemeV4,
-			},
-		)
+		url, err := s.storage.SignedURL(key, &storage.SignedURLOptions{
+			Method:  http.MethodGet,
+			Expires: time.Now().Add(15 * time.Minute),
+		})
 		if err != nil {
 			s.logger.Error("generate view signed URL failed", zap.Error(err))
 			return nil, err
@@ -86,3 +174,124 @@ func (s *fileSvcImpl) CreateViewURLs(ctx context.Context, req types.ViewPresigne
 
 	return result, nil
 }
+
+func (s *fileSvcImpl) GetObject(ctx context.Context, key string, query url.Values) (io.ReadCloser, *storage.ObjectAttrs, error) {
+	if err := s.verifySignedURL(http.MethodGet, key, "", 0, query); err != nil {
+		return nil, nil, err
+	}
+
+	attrs, err := s.storage.Attrs(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, nil, common.ErrFileNotFound
+		}
+		s.logger.Error("get object attrs failed", zap.String("key", key), zap.Error(err))
+		return nil, nil, err
+	}
+
+	reader, err := s.storage.NewReader(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, nil, common.ErrFileNotFound
+		}
+		s.logger.Error("open object failed", zap.String("key", key), zap.Error(err))
+		return nil, nil, err
+	}
+
+	return reader, attrs, nil
+}
+
+func (s *fileSvcImpl) PutObject(ctx context.Context, key, contentType string, contentLength int64, query url.Values, body io.Reader) error {
+	if err := s.verifySignedURL(http.MethodPut, key, contentType, contentLength, query); err != nil {
+		return err
+	}
+	if contentLength > common.MaxUploadFileSize {
+		return common.ErrFileTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, common.MaxUploadFileSize+1))
+	if err != nil {
+		s.logger.Error("read upload body failed", zap.String("key", key), zap.Error(err))
+		return err
+	}
+	if len(data) > common.MaxUploadFileSize {
+		return common.ErrFileTooLarge
+	}
+
+	if err = s.storage.Put(ctx, key, data, &storage.PutOptions{ContentType: contentType}); err != nil {
+		s.logger.Error("put object failed", zap.String("key", key), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// SweepOrphanedUploads removes uploads that no entity has claimed within the
+// grace period. The rows are dropped first and the objects are deleted by the
+// file queue consumer, so a failed publish leaves at most a stray object.
+func (s *fileSvcImpl) SweepOrphanedUploads(ctx context.Context) error {
+	gracePeriod := s.cfg.Upload.GracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = defaultUploadGracePeriod
+	}
+
+	createdBefore := time.Now().Add(-gracePeriod)
+	swept := 0
+	// Keep taking batches until one comes back short so a backlog larger
+	// than a batch is cleared in one tick instead of growing forever.
+	for ctx.Err() == nil {
+		keys, err := s.fileRepo.DeletePendingUploadsCreatedBefore(ctx, createdBefore, common.UploadSweepBatchSize)
+		if err != nil {
+			s.logger.Error("delete orphaned uploads failed", zap.Error(err))
+			return err
+		}
+
+		for _, key := range keys {
+			if err = s.mqProvider.PublishMessage(common.ExchangeFile, common.RoutingKeyDeleteFile, []byte(key)); err != nil {
+				s.logger.Error("publish delete file message failed", zap.String("key", key), zap.Error(err))
+			}
+		}
+
+		swept += len(keys)
+		if len(keys) < common.UploadSweepBatchSize {
+			break
+		}
+	}
+
+	if swept > 0 {
+		s.logger.Info("orphaned uploads swept", zap.Int("count", swept))
+	}
+
+	return ctx.Err()
+}
+
+// verifySignedURL only succeeds for backends that serve their own signed URLs;
+// with GCS or S3 clients talk to the bucket directly and these routes are dead.
+func (s *fileSvcImpl) verifySignedURL(method, key, contentType string, contentLength int64, query url.Values) error {
+	verifier, ok := s.storage.(storage.SignedURLVerifier)
+	if !ok {
+		return common.ErrFileNotFound
+	}
+
+	if err := verifier.VerifySignedURL(method, key, contentType, contentLength, query); err != nil {
+		if errors.Is(err, storage.ErrInvalidSignature) || errors.Is(err, storage.ErrInvalidKey) {
+			return common.ErrInvalidSignedURL
+		}
+		return err
+	}
+
+	return nil
+}
+
+// isPublicUploadKey reports whether key, or the original a generated variant
+// was derived from, was uploaded for a purpose anyone may view.
+func isPublicUploadKey(key string) bool {
+	key = strings.TrimPrefix(key, common.ServiceImageVariantPrefix)
+	for _, policy := range common.UploadPolicies {
+		if policy.Public && strings.HasPrefix(key, policy.Prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -34,7 +34,7 @@ func NewNotificationService(
 	}
 }
 
-func (s *notificationSvcImpl) GetNotificationsForAdmin(ctx context.Context, query types.NotificationPaginationQuery, userID, departmentID int64) ([]*model.Notification, *types.MetaResponse, error) {
+func (s *notificationSvcImpl) GetNotificationsForAdmin(ctx context.Context, query types.NotificationPaginationQuery, userID int64, departmentID *int64) ([]*model.Notification, *types.MetaResponse, error) {
 	var notifications []*model.Notification
 	var total int64
 
@@ -102,7 +102,7 @@ func (s *notificationSvcImpl) GetNotificationsForAdmin(ctx context.Context, quer
 	return notifications, meta, nil
 }
 
-func (s *notificationSvcImpl) CountUnreadNotificationsForAdmin(ctx context.Context, userID, departmentID int64) (int64, error) {
+func (s *notificationSvcImpl) CountUnreadNotificationsForAdmin(ctx context.Context, userID int64, departmentID *int64) (int64, error) {
 	count, err := s.notificationRepo.CountUnreadNotificationsByDepartmentID(ctx, userID, departmentID)
 	if err != nil {
 		s.logger.Error("count unread notifications by department id failed", zap.Error(err))
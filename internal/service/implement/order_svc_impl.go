@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/common"
+	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/model"
 	"github.com/InstaySystem/is_v1-be/internal/provider/cache"
 	"github.com/InstaySystem/is_v1-be/internal/provider/jwt"
@@ -29,11 +31,13 @@ type orderSvcImpl struct {
 	serviceRepo      repository.ServiceRepository
 	notificationRepo repository.Notification
 	chatRepo         repository.ChatRepository
+	userRepo         repository.UserRepository
 	sfGen            snowflake.Generator
 	logger           *zap.Logger
 	cacheProvider    cache.CacheProvider
 	jwtProvider      jwt.JWTProvider
 	mqProvider       mq.MessageQueueProvider
+	cfg              *config.Config
 }
 
 func NewOrderService(
@@ -44,11 +48,13 @@ func NewOrderService(
 	serviceRepo repository.ServiceRepository,
 	notificationRepo repository.Notification,
 	chatRepo repository.ChatRepository,
+	userRepo repository.UserRepository,
 	sfGen snowflake.Generator,
 	logger *zap.Logger,
 	cacheProvider cache.CacheProvider,
 	jwtProvider jwt.JWTProvider,
 	mqProvider mq.MessageQueueProvider,
+	cfg *config.Config,
 ) service.OrderService {
 	return &orderSvcImpl{
 		db,
@@ -58,11 +64,13 @@ func NewOrderService(
 		serviceRepo,
 		notificationRepo,
 		chatRepo,
+		userRepo,
 		sfGen,
 		logger,
 		cacheProvider,
 		jwtProvider,
 		mqProvider,
+		cfg,
 	}
 }
 
@@ -220,86 +228,337 @@ func (s *orderSvcImpl) CreateOrderService(ctx context.Context, orderRoomID int64
 		return 0, common.ErrOrderRoomNotFound
 	}
 
-	service, err := s.serviceRepo.FindServiceByIDWithServiceTypeDetails(ctx, req.ServiceID)
+	promoCode := normalizePromoCode(req.PromoCode)
+	line, err := s.prepareOrderServiceLine(ctx, orderRoomID, req.ServiceID, req.Quantity, req.SlotStartAt, req.OptionIDs, promoCode)
 	if err != nil {
-		s.logger.Error("find service by id failed", zap.Int64("id", req.ServiceID), zap.Error(err))
 		return 0, err
 	}
+	if promoCode != nil && !line.promoMatched {
+		return 0, common.ErrInvalidPromoCode
+	}
+	line.orderService.GuestNote = req.GuestNote
+
+	service := line.service
+	if err = s.db.WithContext(ctx).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err = s.createOrderServiceLineTx(tx, line); err != nil {
+			return err
+		}
+
+		content := fmt.Sprintf("Phòng %s đã đặt %s", orderRoom.Room.Name, describeOrderServiceLine(line, availabilityLocation(s.cfg)))
+		return s.notifyDepartmentTx(tx, service.ServiceType.Department, "service", orderRoomID, line.orderService.ID, content)
+	}); err != nil {
+		return 0, err
+	}
+
+	return line.orderService.ID, nil
+}
+
+type orderServiceLine struct {
+	orderService *model.OrderService
+	options      []*model.OrderServiceOption
+	service      *model.Service
+	slot         *model.ServiceSlot
+	promoMatched bool
+}
+
+// prepareOrderServiceLine validates availability, slot and options for one
+// ordered service and prices it. Nothing is written until
+// createOrderServiceLineTx runs.
+func (s *orderSvcImpl) prepareOrderServiceLine(ctx context.Context, orderRoomID, serviceID int64, quantity uint32, slotStartAt *time.Time, optionIDs []int64, promoCode *string) (*orderServiceLine, error) {
+	service, err := s.serviceRepo.FindServiceByIDWithServiceTypeDetails(ctx, serviceID)
+	if err != nil {
+		s.logger.Error("find service by id failed", zap.Int64("id", serviceID), zap.Error(err))
+		return nil, err
+	}
 	if service == nil {
-		return 0, common.ErrServiceNotFound
+		return nil, common.ErrServiceNotFound
+	}
+	if !service.IsActive {
+		return nil, common.ErrServiceUnavailable
+	}
+	if service.Stock != nil && *service.Stock < quantity {
+		return nil, common.ErrServiceOutOfStock
+	}
+
+	loc := availabilityLocation(s.cfg)
+	openingHours := effectiveOpeningHours(service)
+	hasSlots := service.SlotDurationMinutes != nil && service.SlotCapacity != nil
+
+	var slot *model.ServiceSlot
+	if hasSlots {
+		if slotStartAt == nil {
+			return nil, common.ErrInvalidServiceSlot
+		}
+
+		duration := time.Duration(*service.SlotDurationMinutes) * time.Minute
+		if !slotStartAt.After(time.Now()) || !isServiceSlotStart(openingHours, duration, *slotStartAt, loc) {
+			return nil, common.ErrInvalidServiceSlot
+		}
+		if quantity > *service.SlotCapacity {
+			return nil, common.ErrServiceSlotFull
+		}
+
+		slotID, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate service slot id failed", zap.Error(err))
+			return nil, err
+		}
+
+		slot = &model.ServiceSlot{
+			ID:        slotID,
+			ServiceID: service.ID,
+			StartAt:   *slotStartAt,
+			Reserved:  quantity,
+		}
+	} else {
+		if slotStartAt != nil {
+			return nil, common.ErrInvalidServiceSlot
+		}
+
+		now := time.Now()
+		if !isWithinOpeningHours(openingHours, now, now, loc) {
+			return nil, common.ErrServiceUnavailable
+		}
+	}
+
+	options, priceDelta, err := selectServiceOptions(service, optionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	listUnitPrice := service.Price + priceDelta
+	if listUnitPrice < 0 {
+		return nil, common.ErrInvalidServiceOptions
+	}
+
+	pricedAt := time.Now()
+	if slotStartAt != nil {
+		pricedAt = *slotStartAt
+	}
+
+	rules, err := s.serviceRepo.FindAllApplicablePricingRules(ctx, service.ID, service.ServiceTypeID, promoCode, pricedAt)
+	if err != nil {
+		s.logger.Error("find applicable pricing rules failed", zap.Int64("service_id", service.ID), zap.Error(err))
+		return nil, err
 	}
 
+	appliedRule, unitPrice, promoMatched := choosePricingRule(rules, service.Price, priceDelta, pricedAt, loc)
+
 	orderServiceID, err := s.sfGen.NextID()
 	if err != nil {
 		s.logger.Error("generate order service id failed", zap.Error(err))
-		return 0, err
+		return nil, err
+	}
+
+	for _, option := range options {
+		if option.ID, err = s.sfGen.NextID(); err != nil {
+			s.logger.Error("generate order service option id failed", zap.Error(err))
+			return nil, err
+		}
+		option.OrderServiceID = orderServiceID
 	}
 
 	orderService := &model.OrderService{
 		ID:          orderServiceID,
 		OrderRoomID: orderRoomID,
-		ServiceID:   req.ServiceID,
-		Quantity:    req.Quantity,
-		TotalPrice:  float64(req.Quantity) * service.Price,
+		ServiceID:   service.ID,
+		Quantity:    quantity,
+		ListPrice:   float64(quantity) * listUnitPrice,
+		TotalPrice:  float64(quantity) * unitPrice,
 		Status:      "pending",
-		GuestNote:   req.GuestNote,
+		SlotStartAt: slotStartAt,
+	}
+	if appliedRule != nil {
+		orderService.PricingRuleID = &appliedRule.ID
+		orderService.PricingRuleName = &appliedRule.Name
+		orderService.PromoCode = appliedRule.PromoCode
 	}
 
-	if err = s.db.WithContext(ctx).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err = s.orderRepo.CreateOrderServiceTx(tx, orderService); err != nil {
-			s.logger.Error("create order service failed", zap.Error(err))
-			return err
+	return &orderServiceLine{orderService, options, service, slot, promoMatched}, nil
+}
+
+// choosePricingRule picks the rule giving the lowest unit price at the given
+// moment, or none when no rule beats the list price. promoMatched reports
+// whether a promo code rule applied at all, even if another rule was cheaper.
+func choosePricingRule(rules []*model.PricingRule, basePrice, priceDelta float64, at time.Time, loc *time.Location) (*model.PricingRule, float64, bool) {
+	var appliedRule *model.PricingRule
+	unitPrice := basePrice + priceDelta
+	promoMatched := false
+	for _, rule := range rules {
+		if !pricingRuleMatches(rule, at, loc) {
+			continue
+		}
+		if rule.PromoCode != nil {
+			promoMatched = true
 		}
+		if price := priceWithRule(rule, basePrice, priceDelta); price < unitPrice {
+			appliedRule = rule
+			unitPrice = price
+		}
+	}
 
-		notificationID, err := s.sfGen.NextID()
+	return appliedRule, unitPrice, promoMatched
+}
+
+// pricingRuleMatches reports whether the rule's day type and time window cover
+// the moment the service is delivered. Windows ending before they start run
+// past midnight.
+func pricingRuleMatches(rule *model.PricingRule, at time.Time, loc *time.Location) bool {
+	local := at.In(loc)
+	isWeekend := local.Weekday() == time.Saturday || local.Weekday() == time.Sunday
+	if (rule.DayType == "weekday" && isWeekend) || (rule.DayType == "weekend" && !isWeekend) {
+		return false
+	}
+
+	if rule.StartTime == nil || rule.EndTime == nil {
+		return true
+	}
+
+	startAt, err := time.Parse(common.OpeningHourLayout, *rule.StartTime)
+	if err != nil {
+		return false
+	}
+	endAt, err := time.Parse(common.OpeningHourLayout, *rule.EndTime)
+	if err != nil {
+		return false
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	start := startAt.Hour()*60 + startAt.Minute()
+	end := endAt.Hour()*60 + endAt.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+
+	return minute >= start || minute < end
+}
+
+func priceWithRule(rule *model.PricingRule, basePrice, priceDelta float64) float64 {
+	var price float64
+	switch rule.DiscountType {
+	case "percentage":
+		price = (basePrice + priceDelta) * (1 - rule.DiscountValue/100)
+	case "fixed_amount":
+		price = basePrice + priceDelta - rule.DiscountValue
+	case "fixed_price":
+		price = rule.DiscountValue + priceDelta
+	}
+
+	return math.Max(math.Round(price*100)/100, 0)
+}
+
+func (s *orderSvcImpl) createOrderServiceLineTx(tx *gorm.DB, line *orderServiceLine) error {
+	if line.slot != nil {
+		reserved, err := s.serviceRepo.ReserveServiceSlotTx(tx, line.slot, *line.service.SlotCapacity)
 		if err != nil {
-			s.logger.Error("generate notification id failed", zap.Error(err))
+			s.logger.Error("reserve service slot failed", zap.Int64("service_id", line.service.ID), zap.Error(err))
 			return err
 		}
-
-		content := fmt.Sprintf("Phòng %s đã đặt %d %s", orderRoom.Room.Name, req.Quantity, service.Name)
-		notification := &model.Notification{
-			ID:           notificationID,
-			DepartmentID: service.ServiceType.DepartmentID,
-			OrderRoomID:  orderRoomID,
-			Type:         "service",
-			Receiver:     "staff",
-			Content:      content,
-			ContentID:    orderService.ID,
+		if !reserved {
+			return common.ErrServiceSlotFull
 		}
+	}
 
-		if err = s.notificationRepo.CreateNotificationTx(tx, notification); err != nil {
-			s.logger.Error("create notification failed", zap.Error(err))
+	if err := s.orderRepo.CreateOrderServiceTx(tx, line.orderService); err != nil {
+		s.logger.Error("create order service failed", zap.Error(err))
+		return err
+	}
+
+	if len(line.options) > 0 {
+		if err := s.orderRepo.CreateOrderServiceOptionsTx(tx, line.options); err != nil {
+			s.logger.Error("create order service options failed", zap.Error(err))
 			return err
 		}
+	}
 
-		staffIDs := make([]int64, 0, len(service.ServiceType.Department.Staffs))
-		for _, staff := range service.ServiceType.Department.Staffs {
-			staffIDs = append(staffIDs, staff.ID)
-		}
+	return nil
+}
 
-		serviceNotificationMsg := types.NotificationMessage{
-			Content:      notification.Content,
-			Type:         notification.Type,
-			ContentID:    notification.ContentID,
-			Receiver:     notification.Receiver,
-			DepartmentID: &service.ServiceType.DepartmentID,
-			ReceiverIDs:  staffIDs,
+func describeOrderServiceLine(line *orderServiceLine, loc *time.Location) string {
+	description := fmt.Sprintf("%d %s", line.orderService.Quantity, line.service.Name)
+	if len(line.options) > 0 {
+		optionNames := make([]string, 0, len(line.options))
+		for _, option := range line.options {
+			optionNames = append(optionNames, option.OptionName)
 		}
+		description += fmt.Sprintf(" (%s)", strings.Join(optionNames, ", "))
+	}
+	if line.slot != nil {
+		description += fmt.Sprintf(" lúc %s", line.slot.StartAt.In(loc).Format(common.CannedResponseTimeLayout))
+	}
 
-		go func(msg types.NotificationMessage) {
-			body, _ := json.Marshal(msg)
-			if err := s.mqProvider.PublishMessage(common.ExchangeNotification, common.RoutingKeyServiceNotification, body); err != nil {
-				s.logger.Error("publish service notification message failed", zap.Error(err))
-			}
-		}(serviceNotificationMsg)
+	return description
+}
 
-		return nil
-	}); err != nil {
-		return 0, err
+func (s *orderSvcImpl) notifyDepartmentTx(tx *gorm.DB, department *model.Department, notificationType string, orderRoomID, contentID int64, content string) error {
+	msg, err := s.createDepartmentNotificationTx(tx, department, notificationType, orderRoomID, contentID, content)
+	if err != nil {
+		return err
 	}
 
-	return orderServiceID, nil
+	go s.publishServiceNotification(msg)
+
+	return nil
+}
+
+// createDepartmentNotificationTx stores a staff notification and returns the
+// message to publish once the transaction has committed.
+func (s *orderSvcImpl) createDepartmentNotificationTx(tx *gorm.DB, department *model.Department, notificationType string, orderRoomID, contentID int64, content string) (*types.NotificationMessage, error) {
+	notificationID, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate notification id failed", zap.Error(err))
+		return nil, err
+	}
+
+	notification := &model.Notification{
+		ID:           notificationID,
+		DepartmentID: department.ID,
+		OrderRoomID:  orderRoomID,
+		Type:         notificationType,
+		Receiver:     "staff",
+		Content:      content,
+		ContentID:    contentID,
+	}
+
+	if err = s.notificationRepo.CreateNotificationTx(tx, notification); err != nil {
+		s.logger.Error("create notification failed", zap.Error(err))
+		return nil, err
+	}
+
+	staffIDs := make([]int64, 0, len(department.Staffs))
+	for _, staff := range department.Staffs {
+		staffIDs = append(staffIDs, staff.ID)
+	}
+
+	return &types.NotificationMessage{
+		Content:      notification.Content,
+		Type:         notification.Type,
+		ContentID:    notification.ContentID,
+		Receiver:     notification.Receiver,
+		DepartmentID: &department.ID,
+		ReceiverIDs:  staffIDs,
+	}, nil
+}
+
+func (s *orderSvcImpl) publishServiceNotification(msg *types.NotificationMessage) {
+	body, _ := json.Marshal(msg)
+	if err := s.mqProvider.PublishMessage(common.ExchangeNotification, common.RoutingKeyServiceNotification, body); err != nil {
+		s.logger.Error("publish service notification message failed", zap.Error(err))
+	}
+}
+
+// publishServiceNotificationsAfterCommit sends messages collected inside a
+// transaction, so a rollback never leaves staff with an alert for nothing.
+func (s *orderSvcImpl) publishServiceNotificationsAfterCommit(msgs []*types.NotificationMessage) {
+	if len(msgs) == 0 {
+		return
+	}
+
+	go func() {
+		for _, msg := range msgs {
+			s.publishServiceNotification(msg)
+		}
+	}()
 }
 
 func (s *orderSvcImpl) GetOrderServiceByID(ctx context.Context, userID int64, orderServiceID int64, departmentID *int64) (*model.OrderService, error) {
@@ -315,34 +574,10 @@ func (s *orderSvcImpl) GetOrderServiceByID(ctx context.Context, userID int64, or
 		return nil, common.ErrOrderServiceNotFound
 	}
 
-	unreadNotifications, err := s.notificationRepo.FindAllUnreadNotificationsByContentIDAndType(ctx, userID, orderServiceID, "service")
-	if err != nil {
-		s.logger.Error("find unread notifications failed", zap.Error(err))
+	if err = s.markServiceNotificationsRead(ctx, userID, orderServiceID, "service"); err != nil {
 		return nil, err
 	}
 
-	if len(unreadNotifications) > 0 {
-		notificationStaffs := make([]*model.NotificationStaff, 0, len(unreadNotifications))
-		for _, notification := range unreadNotifications {
-			id, err := s.sfGen.NextID()
-			if err != nil {
-				s.logger.Error("generate notification staff id failed", zap.Error(err))
-				return nil, err
-			}
-
-			notificationStaffs = append(notificationStaffs, &model.NotificationStaff{
-				ID:             id,
-				NotificationID: notification.ID,
-				StaffID:        userID,
-			})
-		}
-
-		if err = s.notificationRepo.CreateNotificationStaffs(ctx, notificationStaffs); err != nil {
-			s.logger.Error("create notification staffs failed", zap.Error(err))
-			return nil, err
-		}
-	}
-
 	return orderService, nil
 }
 
@@ -368,6 +603,9 @@ func (s *orderSvcImpl) UpdateOrderServiceForGuest(ctx context.Context, orderRoom
 		if orderService == nil {
 			return common.ErrOrderServiceNotFound
 		}
+		if orderService.ServiceOrderID != nil {
+			return common.ErrOrderServiceInServiceOrder
+		}
 
 		if orderService.Status != "pending" || req.Status != "cancelled" {
 			return common.ErrInvalidStatus
@@ -382,6 +620,10 @@ func (s *orderSvcImpl) UpdateOrderServiceForGuest(ctx context.Context, orderRoom
 			return err
 		}
 
+		if err = s.releaseServiceSlotTx(tx, orderService); err != nil {
+			return err
+		}
+
 		notificationID, err := s.sfGen.NextID()
 		if err != nil {
 			s.logger.Error("generate notification id failed", zap.Error(err))
@@ -405,8 +647,12 @@ func (s *orderSvcImpl) UpdateOrderServiceForGuest(ctx context.Context, orderRoom
 		}
 
 		staffIDs := make([]int64, 0, len(orderService.Service.ServiceType.Department.Staffs))
-		for _, staff := range orderService.Service.ServiceType.Department.Staffs {
-			staffIDs = append(staffIDs, staff.ID)
+		if orderService.AssigneeID != nil {
+			staffIDs = append(staffIDs, *orderService.AssigneeID)
+		} else {
+			for _, staff := range orderService.Service.ServiceType.Department.Staffs {
+				staffIDs = append(staffIDs, staff.ID)
+			}
 		}
 
 		serviceNotificationMsg := types.NotificationMessage{
@@ -433,7 +679,7 @@ func (s *orderSvcImpl) UpdateOrderServiceForGuest(ctx context.Context, orderRoom
 	return nil
 }
 
-func (s *orderSvcImpl) GetOrderServicesForAdmin(ctx context.Context, query types.OrderServicePaginationQuery, departmentID *int64) ([]*model.OrderService, *types.MetaResponse, error) {
+func (s *orderSvcImpl) GetOrderServicesForAdmin(ctx context.Context, query types.OrderServicePaginationQuery, userID int64, departmentID *int64) ([]*model.OrderService, *types.MetaResponse, error) {
 	if query.Page == 0 {
 		query.Page = 1
 	}
@@ -441,7 +687,7 @@ func (s *orderSvcImpl) GetOrderServicesForAdmin(ctx context.Context, query types
 		query.Limit = 10
 	}
 
-	orderServices, total, err := s.orderRepo.FindAllOrderServicesWithDetailsPaginated(ctx, query, departmentID)
+	orderServices, total, err := s.orderRepo.FindAllOrderServicesWithDetailsPaginated(ctx, query, userID, departmentID)
 	if err != nil {
 		s.logger.Error("find all order services paginated failed", zap.Error(err))
 		return nil, nil, err
@@ -465,6 +711,7 @@ func (s *orderSvcImpl) GetOrderServicesForAdmin(ctx context.Context, query types
 }
 
 func (s *orderSvcImpl) UpdateOrderServiceForAdmin(ctx context.Context, departmentID *int64, userID, orderServiceID int64, req types.UpdateOrderServiceRequest) error {
+	var stockMsg *types.NotificationMessage
 	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		orderService, err := s.orderRepo.FindOrderServiceByIDWithServiceDetailsAndOrderRoomDetailsTx(tx, orderServiceID)
 		if err != nil {
@@ -481,23 +728,37 @@ func (s *orderSvcImpl) UpdateOrderServiceForAdmin(ctx context.Context, departmen
 		if departmentID != nil && orderService.Service.ServiceType.DepartmentID != *departmentID {
 			return common.ErrOrderServiceNotFound
 		}
+		if orderService.ServiceOrderID != nil {
+			return common.ErrOrderServiceInServiceOrder
+		}
 
 		if orderService.OrderRoom.Booking.CheckOut.Before(time.Now()) {
 			return common.ErrBookingExpired
 		}
 
-		if orderService.Status != "pending" || !slices.Contains([]string{"rejected", "accepted"}, req.Status) {
+		if !canTransitionOrderService(orderService.Status, req.Status) {
 			return common.ErrInvalidStatus
 		}
 
+		if departmentID != nil && orderService.AssigneeID != nil && *orderService.AssigneeID != userID {
+			return common.ErrOrderServiceAssignedToOther
+		}
+
 		updateData := map[string]any{
 			"status":        req.Status,
 			"updated_by_id": userID,
 		}
+		if req.Status == "accepted" && departmentID != nil && orderService.AssigneeID == nil {
+			updateData["assignee_id"] = userID
+			updateData["assigned_at"] = time.Now()
+		}
 
 		if req.Status == "rejected" && req.Reason != nil {
 			updateData["reject_reason"] = *req.Reason
 		}
+		if req.Status == "cancelled" && req.Reason != nil {
+			updateData["cancel_reason"] = *req.Reason
+		}
 		if req.Status == "accepted" && req.StaffNote != nil {
 			updateData["staff_note"] = *req.StaffNote
 		}
@@ -507,26 +768,105 @@ func (s *orderSvcImpl) UpdateOrderServiceForAdmin(ctx context.Context, departmen
 			return err
 		}
 
-		notificationID, err := s.sfGen.NextID()
-		if err != nil {
-			s.logger.Error("generate notification id failed", zap.Error(err))
-			return err
+		if req.Status == "accepted" {
+			if stockMsg, err = s.consumeServiceStockTx(tx, orderService, orderService.Service.ServiceType.Department); err != nil {
+				return err
+			}
+		} else {
+			if err = s.releaseServiceSlotTx(tx, orderService); err != nil {
+				return err
+			}
+			if err = s.restockServiceTx(tx, orderService); err != nil {
+				return err
+			}
 		}
 
 		displayStatus := "được chấp nhận"
-		if req.Status == "rejected" {
+		switch req.Status {
+		case "rejected":
 			displayStatus = "bị từ chối"
+		case "cancelled":
+			displayStatus = "bị hủy"
 		}
 
 		content := fmt.Sprintf("%d %s đã %s", orderService.Quantity, orderService.Service.Name, displayStatus)
+		return s.notifyGuestTx(tx, orderService.Service.ServiceType.DepartmentID, "service", orderService.OrderRoomID, orderService.ID, content)
+	}); err != nil {
+		return err
+	}
+
+	if stockMsg != nil {
+		s.publishServiceNotificationsAfterCommit([]*types.NotificationMessage{stockMsg})
+	}
+
+	return nil
+}
+
+func (s *orderSvcImpl) ClaimOrderService(ctx context.Context, orderServiceID, userID int64, departmentID *int64) error {
+	if departmentID == nil {
+		return common.ErrAssigneeNotInDepartment
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		orderService, err := s.findOrderServiceForAssignment(tx, orderServiceID, departmentID)
+		if err != nil {
+			return err
+		}
+		if orderService.AssigneeID != nil {
+			if *orderService.AssigneeID == userID {
+				return nil
+			}
+			return common.ErrOrderServiceAlreadyAssigned
+		}
+
+		return s.updateOrderServiceAssignee(tx, orderService, userID, userID)
+	})
+}
+
+func (s *orderSvcImpl) AssignOrderService(ctx context.Context, orderServiceID, userID int64, departmentID *int64, req types.AssignOrderServiceRequest) error {
+	assignee, err := s.userRepo.FindByIDWithDepartment(ctx, req.AssigneeID)
+	if err != nil {
+		s.logger.Error("find user by id failed", zap.Int64("id", req.AssigneeID), zap.Error(err))
+		return err
+	}
+	if assignee == nil || !assignee.IsActive {
+		return common.ErrUserNotFound
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		orderService, err := s.findOrderServiceForAssignment(tx, orderServiceID, departmentID)
+		if err != nil {
+			return err
+		}
+		if !canBeAssigned(assignee, orderService.Service.ServiceType.DepartmentID) {
+			return common.ErrAssigneeNotInDepartment
+		}
+		if orderService.AssigneeID != nil && *orderService.AssigneeID == assignee.ID {
+			return nil
+		}
+
+		if err = s.updateOrderServiceAssignee(tx, orderService, userID, assignee.ID); err != nil {
+			return err
+		}
+		if assignee.ID == userID {
+			return nil
+		}
+
+		notificationID, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate notification id failed", zap.Error(err))
+			return err
+		}
+
+		content := fmt.Sprintf("%d %s của phòng %s đã được giao cho %s", orderService.Quantity, orderService.Service.Name, orderService.OrderRoom.Room.Name, strings.TrimSpace(assignee.LastName+" "+assignee.FirstName))
 		notification := &model.Notification{
 			ID:           notificationID,
 			DepartmentID: orderService.Service.ServiceType.DepartmentID,
+			OrderRoomID:  orderService.OrderRoomID,
 			Type:         "service",
-			Receiver:     "guest",
+			Receiver:     "staff",
 			Content:      content,
 			ContentID:    orderService.ID,
-			OrderRoomID:  orderService.OrderRoomID,
 		}
 
 		if err = s.notificationRepo.CreateNotificationTx(tx, notification); err != nil {
@@ -534,12 +874,13 @@ func (s *orderSvcImpl) UpdateOrderServiceForAdmin(ctx context.Context, departmen
 			return err
 		}
 
-		serviceNotificationMsg := types.NotificationMessage{
-			Content:     notification.Content,
-			Type:        notification.Type,
-			ContentID:   notification.ContentID,
-			Receiver:    notification.Receiver,
-			ReceiverIDs: []int64{orderService.OrderRoomID},
+		assignNotificationMsg := types.NotificationMessage{
+			Content:      notification.Content,
+			Type:         notification.Type,
+			ContentID:    notification.ContentID,
+			Receiver:     notification.Receiver,
+			DepartmentID: &orderService.Service.ServiceType.DepartmentID,
+			ReceiverIDs:  []int64{assignee.ID},
 		}
 
 		go func(msg types.NotificationMessage) {
@@ -547,10 +888,42 @@ func (s *orderSvcImpl) UpdateOrderServiceForAdmin(ctx context.Context, departmen
 			if err := s.mqProvider.PublishMessage(common.ExchangeNotification, common.RoutingKeyServiceNotification, body); err != nil {
 				s.logger.Error("publish service notification message failed", zap.Error(err))
 			}
-		}(serviceNotificationMsg)
+		}(assignNotificationMsg)
 
 		return nil
-	}); err != nil {
+	})
+}
+
+func (s *orderSvcImpl) findOrderServiceForAssignment(tx *gorm.DB, orderServiceID int64, departmentID *int64) (*model.OrderService, error) {
+	orderService, err := s.orderRepo.FindOrderServiceByIDWithServiceDetailsAndOrderRoomDetailsTx(tx, orderServiceID)
+	if err != nil {
+		if strings.Contains(err.Error(), "lock") {
+			return nil, common.ErrLockedRecord
+		}
+		s.logger.Error("find order service by id failed", zap.Int64("id", orderServiceID), zap.Error(err))
+		return nil, err
+	}
+	if orderService == nil {
+		return nil, common.ErrOrderServiceNotFound
+	}
+	if departmentID != nil && orderService.Service.ServiceType.DepartmentID != *departmentID {
+		return nil, common.ErrOrderServiceNotFound
+	}
+	if orderService.Status != "pending" && orderService.Status != "accepted" {
+		return nil, common.ErrInvalidStatus
+	}
+
+	return orderService, nil
+}
+
+func (s *orderSvcImpl) updateOrderServiceAssignee(tx *gorm.DB, orderService *model.OrderService, userID, assigneeID int64) error {
+	updateData := map[string]any{
+		"assignee_id":   assigneeID,
+		"assigned_at":   time.Now(),
+		"updated_by_id": userID,
+	}
+	if err := s.orderRepo.UpdateOrderServiceTx(tx, orderService.ID, updateData); err != nil {
+		s.logger.Error("update order service failed", zap.Int64("id", orderService.ID), zap.Error(err))
 		return err
 	}
 
@@ -575,3 +948,526 @@ func (s *orderSvcImpl) GetOrderServicesForGuest(ctx context.Context, orderRoomID
 
 	return orderServices, nil
 }
+
+// selectServiceOptions validates the chosen option IDs against the service's
+// option groups and returns the snapshot rows plus the per-unit price delta.
+func selectServiceOptions(service *model.Service, optionIDs []int64) ([]*model.OrderServiceOption, float64, error) {
+	selected := make([]*model.OrderServiceOption, 0, len(optionIDs))
+	matched := 0
+	var priceDelta float64
+
+	for _, group := range service.OptionGroups {
+		count := 0
+		for _, option := range group.Options {
+			if !slices.Contains(optionIDs, option.ID) {
+				continue
+			}
+
+			count++
+			priceDelta += option.PriceDelta
+			selected = append(selected, &model.OrderServiceOption{
+				OptionID:   &option.ID,
+				GroupName:  group.Name,
+				OptionName: option.Name,
+				PriceDelta: option.PriceDelta,
+			})
+		}
+
+		if (group.IsRequired && count == 0) || (group.SelectionType == "single" && count > 1) {
+			return nil, 0, common.ErrInvalidServiceOptions
+		}
+		matched += count
+	}
+
+	if matched != len(optionIDs) {
+		return nil, 0, common.ErrInvalidServiceOptions
+	}
+
+	return selected, priceDelta, nil
+}
+
+func (s *orderSvcImpl) releaseServiceSlotTx(tx *gorm.DB, orderService *model.OrderService) error {
+	if orderService.SlotStartAt == nil {
+		return nil
+	}
+
+	if err := s.serviceRepo.ReleaseServiceSlotTx(tx, orderService.ServiceID, *orderService.SlotStartAt, orderService.Quantity); err != nil {
+		s.logger.Error("release service slot failed", zap.Int64("order_service_id", orderService.ID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// consumeServiceStockTx takes the ordered quantity off the stock and returns
+// the low-stock alert, if the order crossed the threshold, for the caller to
+// publish after commit.
+func (s *orderSvcImpl) consumeServiceStockTx(tx *gorm.DB, orderService *model.OrderService, department *model.Department) (*types.NotificationMessage, error) {
+	if orderService.Service.Stock == nil {
+		return nil, nil
+	}
+
+	service, err := s.serviceRepo.DecrementServiceStockTx(tx, orderService.ServiceID, orderService.Quantity)
+	if err != nil {
+		s.logger.Error("decrement service stock failed", zap.Int64("order_service_id", orderService.ID), zap.Error(err))
+		return nil, err
+	}
+	if service == nil {
+		return nil, common.ErrServiceOutOfStock
+	}
+
+	var threshold uint32
+	if service.LowStockThreshold != nil {
+		threshold = *service.LowStockThreshold
+	}
+	if *service.Stock > threshold || *service.Stock+orderService.Quantity <= threshold {
+		return nil, nil
+	}
+
+	content := fmt.Sprintf("%s sắp hết hàng, còn lại %d", orderService.Service.Name, *service.Stock)
+	if *service.Stock == 0 {
+		content = fmt.Sprintf("%s đã hết hàng", orderService.Service.Name)
+	}
+
+	return s.createDepartmentNotificationTx(tx, department, "stock", orderService.OrderRoomID, orderService.ServiceID, content)
+}
+
+func (s *orderSvcImpl) restockServiceTx(tx *gorm.DB, orderService *model.OrderService) error {
+	if orderService.Status != "accepted" {
+		return nil
+	}
+
+	if err := s.serviceRepo.IncrementServiceStockTx(tx, orderService.ServiceID, orderService.Quantity); err != nil {
+		s.logger.Error("increment service stock failed", zap.Int64("order_service_id", orderService.ID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func canTransitionOrderService(from, to string) bool {
+	switch from {
+	case "pending":
+		return to == "accepted" || to == "rejected"
+	case "accepted":
+		return to == "cancelled"
+	default:
+		return false
+	}
+}
+
+func (s *orderSvcImpl) CreateServiceOrder(ctx context.Context, orderRoomID int64, req types.CreateServiceOrderRequest) (int64, error) {
+	orderRoom, err := s.orderRepo.FindOrderRoomByIDWithDetails(ctx, orderRoomID)
+	if err != nil {
+		s.logger.Error("find order room by id failed", zap.Int64("id", orderRoomID), zap.Error(err))
+		return 0, err
+	}
+	if orderRoom == nil {
+		return 0, common.ErrOrderRoomNotFound
+	}
+
+	if req.DeliveryAt != nil && (!req.DeliveryAt.After(time.Now()) || !req.DeliveryAt.Before(orderRoom.Booking.CheckOut)) {
+		return 0, common.ErrInvalidDeliveryTime
+	}
+
+	serviceOrderID, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate service order id failed", zap.Error(err))
+		return 0, err
+	}
+
+	promoCode := normalizePromoCode(req.PromoCode)
+	promoMatched := false
+	lines := make([]*orderServiceLine, 0, len(req.Lines))
+	var totalPrice float64
+	for _, reqLine := range req.Lines {
+		line, err := s.prepareOrderServiceLine(ctx, orderRoomID, reqLine.ServiceID, reqLine.Quantity, reqLine.SlotStartAt, reqLine.OptionIDs, promoCode)
+		if err != nil {
+			return 0, err
+		}
+		if len(lines) > 0 && line.service.ServiceType.DepartmentID != lines[0].service.ServiceType.DepartmentID {
+			return 0, common.ErrServiceOrderMixedDepartments
+		}
+
+		line.orderService.ServiceOrderID = &serviceOrderID
+		totalPrice += line.orderService.TotalPrice
+		promoMatched = promoMatched || line.promoMatched
+		lines = append(lines, line)
+	}
+	if promoCode != nil && !promoMatched {
+		return 0, common.ErrInvalidPromoCode
+	}
+
+	department := lines[0].service.ServiceType.Department
+	serviceOrder := &model.ServiceOrder{
+		ID:           serviceOrderID,
+		OrderRoomID:  orderRoomID,
+		DepartmentID: department.ID,
+		Status:       "pending",
+		TotalPrice:   totalPrice,
+		GuestNote:    req.GuestNote,
+		DeliveryAt:   req.DeliveryAt,
+	}
+
+	loc := availabilityLocation(s.cfg)
+	if err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err = s.orderRepo.CreateServiceOrderTx(tx, serviceOrder); err != nil {
+			s.logger.Error("create service order failed", zap.Error(err))
+			return err
+		}
+
+		descriptions := make([]string, 0, len(lines))
+		for _, line := range lines {
+			if err = s.createOrderServiceLineTx(tx, line); err != nil {
+				return err
+			}
+			descriptions = append(descriptions, describeOrderServiceLine(line, loc))
+		}
+
+		content := fmt.Sprintf("Phòng %s đã đặt đơn gồm %s", orderRoom.Room.Name, strings.Join(descriptions, ", "))
+		if req.DeliveryAt != nil {
+			content += fmt.Sprintf(", giao lúc %s", req.DeliveryAt.In(loc).Format(common.CannedResponseTimeLayout))
+		}
+
+		return s.notifyDepartmentTx(tx, department, "service_order", orderRoomID, serviceOrderID, content)
+	}); err != nil {
+		return 0, err
+	}
+
+	return serviceOrderID, nil
+}
+
+func (s *orderSvcImpl) GetServiceOrdersForGuest(ctx context.Context, orderRoomID int64) ([]*model.ServiceOrder, error) {
+	serviceOrders, err := s.orderRepo.FindAllServiceOrdersByOrderRoomIDWithDetails(ctx, orderRoomID)
+	if err != nil {
+		s.logger.Error("find all service orders by order room id failed", zap.Error(err))
+		return nil, err
+	}
+
+	updateData := map[string]any{
+		"read_at": time.Now(),
+		"is_read": true,
+	}
+	if err = s.notificationRepo.UpdateNotificationsByOrderRoomIDAndType(ctx, orderRoomID, "service_order", updateData); err != nil {
+		s.logger.Error("update read service notification failed", zap.Error(err))
+		return nil, err
+	}
+
+	return serviceOrders, nil
+}
+
+func (s *orderSvcImpl) UpdateServiceOrderForGuest(ctx context.Context, orderRoomID, serviceOrderID int64, req types.UpdateServiceOrderRequest) error {
+	if req.Status != "cancelled" || req.Reason == nil {
+		return common.ErrInvalidStatus
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		serviceOrder, err := s.findServiceOrderForUpdate(tx, serviceOrderID)
+		if err != nil {
+			return err
+		}
+		if serviceOrder.OrderRoomID != orderRoomID {
+			return common.ErrServiceOrderNotFound
+		}
+		if serviceOrder.Status != "pending" {
+			return common.ErrInvalidStatus
+		}
+
+		updateData := map[string]any{
+			"status":        "cancelled",
+			"cancel_reason": *req.Reason,
+		}
+		if err = s.orderRepo.UpdateServiceOrderTx(tx, serviceOrderID, updateData); err != nil {
+			s.logger.Error("update service order failed", zap.Int64("id", serviceOrderID), zap.Error(err))
+			return err
+		}
+		if err = s.orderRepo.UpdateOrderServicesByServiceOrderIDTx(tx, serviceOrderID, nil, updateData); err != nil {
+			s.logger.Error("update service order lines failed", zap.Int64("id", serviceOrderID), zap.Error(err))
+			return err
+		}
+
+		for _, line := range serviceOrder.Lines {
+			if err = s.releaseServiceSlotTx(tx, line); err != nil {
+				return err
+			}
+		}
+
+		content := fmt.Sprintf("Phòng %s đã hủy đơn gồm %d dịch vụ", serviceOrder.OrderRoom.Room.Name, len(serviceOrder.Lines))
+		return s.notifyDepartmentTx(tx, serviceOrder.Department, "service_order", orderRoomID, serviceOrderID, content)
+	})
+}
+
+func (s *orderSvcImpl) UpdateServiceOrderForAdmin(ctx context.Context, departmentID *int64, userID, serviceOrderID int64, req types.UpdateServiceOrderRequest) error {
+	if req.Status != "accepted" && len(req.RejectedLines) > 0 {
+		return common.ErrInvalidStatus
+	}
+
+	var stockMsgs []*types.NotificationMessage
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		serviceOrder, err := s.findServiceOrderForUpdate(tx, serviceOrderID)
+		if err != nil {
+			return err
+		}
+		if departmentID != nil && serviceOrder.DepartmentID != *departmentID {
+			return common.ErrServiceOrderNotFound
+		}
+		if serviceOrder.OrderRoom.Booking.CheckOut.Before(time.Now()) {
+			return common.ErrBookingExpired
+		}
+		if !canTransitionOrderService(serviceOrder.Status, req.Status) {
+			return common.ErrInvalidStatus
+		}
+
+		rejectedIDs := make([]int64, 0, len(req.RejectedLines))
+		for _, rejected := range req.RejectedLines {
+			if !slices.ContainsFunc(serviceOrder.Lines, func(line *model.OrderService) bool { return line.ID == rejected.ID }) ||
+				slices.Contains(rejectedIDs, rejected.ID) {
+				return common.ErrOrderServiceNotFound
+			}
+			rejectedIDs = append(rejectedIDs, rejected.ID)
+		}
+		if len(rejectedIDs) == len(serviceOrder.Lines) {
+			return common.ErrInvalidStatus
+		}
+
+		now := time.Now()
+		orderData := map[string]any{
+			"status":        req.Status,
+			"updated_by_id": userID,
+		}
+		lineData := map[string]any{
+			"status":        req.Status,
+			"updated_by_id": userID,
+		}
+
+		if req.Status == "accepted" {
+			if req.StaffNote != nil {
+				orderData["staff_note"] = *req.StaffNote
+				lineData["staff_note"] = *req.StaffNote
+			}
+			if departmentID != nil {
+				lineData["assignee_id"] = userID
+				lineData["assigned_at"] = now
+			}
+		} else if req.Reason != nil {
+			reasonField := "reject_reason"
+			if req.Status == "cancelled" {
+				reasonField = "cancel_reason"
+			}
+			orderData[reasonField] = *req.Reason
+			lineData[reasonField] = *req.Reason
+		}
+
+		excludedIDs := slices.Clone(rejectedIDs)
+		var totalPrice float64
+		for _, line := range serviceOrder.Lines {
+			if req.Status == "accepted" && !slices.Contains(rejectedIDs, line.ID) {
+				totalPrice += line.TotalPrice
+				stockMsg, err := s.consumeServiceStockTx(tx, line, serviceOrder.Department)
+				if err != nil {
+					return err
+				}
+				if stockMsg != nil {
+					stockMsgs = append(stockMsgs, stockMsg)
+				}
+				continue
+			}
+			if req.Status == "cancelled" && line.Status != "accepted" {
+				excludedIDs = append(excludedIDs, line.ID)
+				continue
+			}
+
+			if err = s.releaseServiceSlotTx(tx, line); err != nil {
+				return err
+			}
+			if err = s.restockServiceTx(tx, line); err != nil {
+				return err
+			}
+		}
+		if req.Status != "cancelled" {
+			orderData["total_price"] = totalPrice
+		}
+
+		if err = s.orderRepo.UpdateServiceOrderTx(tx, serviceOrderID, orderData); err != nil {
+			s.logger.Error("update service order failed", zap.Int64("id", serviceOrderID), zap.Error(err))
+			return err
+		}
+		if err = s.orderRepo.UpdateOrderServicesByServiceOrderIDTx(tx, serviceOrderID, excludedIDs, lineData); err != nil {
+			s.logger.Error("update service order lines failed", zap.Int64("id", serviceOrderID), zap.Error(err))
+			return err
+		}
+
+		for _, rejected := range req.RejectedLines {
+			updateData := map[string]any{
+				"status":        "rejected",
+				"updated_by_id": userID,
+			}
+			if rejected.Reason != nil {
+				updateData["reject_reason"] = *rejected.Reason
+			}
+
+			if err = s.orderRepo.UpdateOrderServiceTx(tx, rejected.ID, updateData); err != nil {
+				s.logger.Error("update order service failed", zap.Int64("id", rejected.ID), zap.Error(err))
+				return err
+			}
+		}
+
+		content := "Đơn dịch vụ của bạn đã bị từ chối"
+		switch req.Status {
+		case "accepted":
+			content = "Đơn dịch vụ của bạn đã được chấp nhận"
+			if len(rejectedIDs) > 0 {
+				content += fmt.Sprintf(", %d dịch vụ bị từ chối", len(rejectedIDs))
+			}
+		case "cancelled":
+			content = "Đơn dịch vụ của bạn đã bị hủy"
+		}
+
+		return s.notifyGuestTx(tx, serviceOrder.DepartmentID, "service_order", serviceOrder.OrderRoomID, serviceOrderID, content)
+	}); err != nil {
+		return err
+	}
+
+	s.publishServiceNotificationsAfterCommit(stockMsgs)
+	return nil
+}
+
+func (s *orderSvcImpl) GetServiceOrderByID(ctx context.Context, userID, serviceOrderID int64, departmentID *int64) (*model.ServiceOrder, error) {
+	serviceOrder, err := s.orderRepo.FindServiceOrderByIDWithDetails(ctx, serviceOrderID)
+	if err != nil {
+		s.logger.Error("find service order by id failed", zap.Int64("id", serviceOrderID), zap.Error(err))
+		return nil, err
+	}
+	if serviceOrder == nil {
+		return nil, common.ErrServiceOrderNotFound
+	}
+	if departmentID != nil && serviceOrder.DepartmentID != *departmentID {
+		return nil, common.ErrServiceOrderNotFound
+	}
+
+	if err = s.markServiceNotificationsRead(ctx, userID, serviceOrderID, "service_order"); err != nil {
+		return nil, err
+	}
+
+	return serviceOrder, nil
+}
+
+func (s *orderSvcImpl) GetServiceOrdersForAdmin(ctx context.Context, query types.ServiceOrderPaginationQuery, departmentID *int64) ([]*model.ServiceOrder, *types.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	serviceOrders, total, err := s.orderRepo.FindAllServiceOrdersWithDetailsPaginated(ctx, query, departmentID)
+	if err != nil {
+		s.logger.Error("find all service orders paginated failed", zap.Error(err))
+		return nil, nil, err
+	}
+
+	totalPages := uint32(total) / query.Limit
+	if uint32(total)%query.Limit != 0 {
+		totalPages++
+	}
+
+	meta := &types.MetaResponse{
+		Total:      uint64(total),
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: uint16(totalPages),
+		HasPrev:    query.Page > 1,
+		HasNext:    query.Page < totalPages,
+	}
+
+	return serviceOrders, meta, nil
+}
+
+func (s *orderSvcImpl) findServiceOrderForUpdate(tx *gorm.DB, serviceOrderID int64) (*model.ServiceOrder, error) {
+	serviceOrder, err := s.orderRepo.FindServiceOrderByIDWithLinesAndOrderRoomDetailsTx(tx, serviceOrderID)
+	if err != nil {
+		if strings.Contains(err.Error(), "lock") {
+			return nil, common.ErrLockedRecord
+		}
+		s.logger.Error("find service order by id failed", zap.Int64("id", serviceOrderID), zap.Error(err))
+		return nil, err
+	}
+	if serviceOrder == nil {
+		return nil, common.ErrServiceOrderNotFound
+	}
+
+	return serviceOrder, nil
+}
+
+func (s *orderSvcImpl) markServiceNotificationsRead(ctx context.Context, userID, contentID int64, notificationType string) error {
+	unreadNotifications, err := s.notificationRepo.FindAllUnreadNotificationsByContentIDAndType(ctx, userID, contentID, notificationType)
+	if err != nil {
+		s.logger.Error("find unread notifications failed", zap.Error(err))
+		return err
+	}
+	if len(unreadNotifications) == 0 {
+		return nil
+	}
+
+	notificationStaffs := make([]*model.NotificationStaff, 0, len(unreadNotifications))
+	for _, notification := range unreadNotifications {
+		id, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate notification staff id failed", zap.Error(err))
+			return err
+		}
+
+		notificationStaffs = append(notificationStaffs, &model.NotificationStaff{
+			ID:             id,
+			NotificationID: notification.ID,
+			StaffID:        userID,
+		})
+	}
+
+	if err = s.notificationRepo.CreateNotificationStaffs(ctx, notificationStaffs); err != nil {
+		s.logger.Error("create notification staffs failed", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *orderSvcImpl) notifyGuestTx(tx *gorm.DB, departmentID int64, notificationType string, orderRoomID, contentID int64, content string) error {
+	notificationID, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate notification id failed", zap.Error(err))
+		return err
+	}
+
+	notification := &model.Notification{
+		ID:           notificationID,
+		DepartmentID: departmentID,
+		Type:         notificationType,
+		Receiver:     "guest",
+		Content:      content,
+		ContentID:    contentID,
+		OrderRoomID:  orderRoomID,
+	}
+
+	if err = s.notificationRepo.CreateNotificationTx(tx, notification); err != nil {
+		s.logger.Error("create notification failed", zap.Error(err))
+		return err
+	}
+
+	serviceNotificationMsg := types.NotificationMessage{
+		Content:     notification.Content,
+		Type:        notification.Type,
+		ContentID:   notification.ContentID,
+		Receiver:    notification.Receiver,
+		ReceiverIDs: []int64{orderRoomID},
+	}
+
+	go func(msg types.NotificationMessage) {
+		body, _ := json.Marshal(msg)
+		if err := s.mqProvider.PublishMessage(common.ExchangeNotification, common.RoutingKeyServiceNotification, body); err != nil {
+			s.logger.Error("publish service notification message failed", zap.Error(err))
+		}
+	}(serviceNotificationMsg)
+
+	return nil
+}
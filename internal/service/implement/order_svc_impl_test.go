@@ -0,0 +1,65 @@
+package implement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/InstaySystem/is_v1-be/internal/model"
+)
+
+func TestChoosePricingRule(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	monday := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	saturday := time.Date(2026, 1, 3, 10, 0, 0, 0, time.UTC)
+	lateMonday := time.Date(2026, 1, 5, 23, 30, 0, 0, time.UTC)
+
+	happyHour := &model.PricingRule{ID: 1, DayType: "all", StartTime: str("09:00"), EndTime: str("11:00"), DiscountType: "percentage", DiscountValue: 20}
+	weekend := &model.PricingRule{ID: 2, DayType: "weekend", DiscountType: "fixed_amount", DiscountValue: 30}
+	overnight := &model.PricingRule{ID: 3, DayType: "weekday", StartTime: str("22:00"), EndTime: str("02:00"), DiscountType: "fixed_price", DiscountValue: 50}
+	promo := &model.PricingRule{ID: 4, DayType: "all", PromoCode: str("SAVE5"), DiscountType: "fixed_amount", DiscountValue: 5}
+	markup := &model.PricingRule{ID: 5, DayType: "all", DiscountType: "percentage", DiscountValue: -10}
+	free := &model.PricingRule{ID: 6, DayType: "all", DiscountType: "fixed_amount", DiscountValue: 500}
+
+	tests := []struct {
+		name             string
+		rules            []*model.PricingRule
+		at               time.Time
+		priceDelta       float64
+		wantRuleID       int64
+		wantPrice        float64
+		wantPromoMatched bool
+	}{
+		{"no rules keeps the list price", nil, monday, 0, 0, 100, false},
+		{"time window applies", []*model.PricingRule{happyHour}, monday, 0, 1, 80, false},
+		{"options are discounted too", []*model.PricingRule{happyHour}, monday, 20, 1, 96, false},
+		{"weekend rule skipped on a weekday", []*model.PricingRule{weekend}, monday, 0, 0, 100, false},
+		{"cheapest matching rule wins", []*model.PricingRule{happyHour, weekend}, saturday, 0, 2, 70, false},
+		{"window past midnight", []*model.PricingRule{happyHour, overnight}, lateMonday, 0, 3, 50, false},
+		{"fixed price keeps the options delta", []*model.PricingRule{overnight}, lateMonday, 15, 3, 65, false},
+		{"promo reported even when beaten", []*model.PricingRule{promo, happyHour}, monday, 0, 1, 80, true},
+		{"promo applied when cheapest", []*model.PricingRule{promo}, saturday, 0, 4, 95, true},
+		{"rule raising the price is ignored", []*model.PricingRule{markup}, monday, 0, 0, 100, false},
+		{"price never goes below zero", []*model.PricingRule{free}, monday, 0, 6, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, price, promoMatched := choosePricingRule(tt.rules, 100, tt.priceDelta, tt.at, time.UTC)
+
+			var ruleID int64
+			if rule != nil {
+				ruleID = rule.ID
+			}
+			if ruleID != tt.wantRuleID {
+				t.Errorf("rule = %d, want %d", ruleID, tt.wantRuleID)
+			}
+			if price != tt.wantPrice {
+				t.Errorf("price = %v, want %v", price, tt.wantPrice)
+			}
+			if promoMatched != tt.wantPromoMatched {
+				t.Errorf("promoMatched = %v, want %v", promoMatched, tt.wantPromoMatched)
+			}
+		})
+	}
+}
@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/common"
+	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/model"
 	"github.com/InstaySystem/is_v1-be/internal/provider/mq"
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
 	"github.com/InstaySystem/is_v1-be/internal/repository"
 	"github.com/InstaySystem/is_v1-be/internal/service"
 	"github.com/InstaySystem/is_v1-be/internal/types"
@@ -24,9 +28,13 @@ type requestSvcImpl struct {
 	requestRepo      repository.RequestRepository
 	orderRepo        repository.OrderRepository
 	notificationRepo repository.Notification
+	userRepo         repository.UserRepository
+	fileRepo         repository.FileRepository
 	sfGen            snowflake.Generator
 	logger           *zap.Logger
 	mqProvider       mq.MessageQueueProvider
+	storage          storage.StorageProvider
+	cfg              *config.Config
 }
 
 func NewRequestService(
@@ -34,18 +42,26 @@ func NewRequestService(
 	requestRepo repository.RequestRepository,
 	orderRepo repository.OrderRepository,
 	notificationRepo repository.Notification,
+	userRepo repository.UserRepository,
+	fileRepo repository.FileRepository,
 	sfGen snowflake.Generator,
 	logger *zap.Logger,
 	mqProvider mq.MessageQueueProvider,
+	storage storage.StorageProvider,
+	cfg *config.Config,
 ) service.RequestService {
 	return &requestSvcImpl{
 		db,
 		requestRepo,
 		orderRepo,
 		notificationRepo,
+		userRepo,
+		fileRepo,
 		sfGen,
 		logger,
 		mqProvider,
+		storage,
+		cfg,
 	}
 }
 
@@ -57,12 +73,14 @@ func (s *requestSvcImpl) CreateRequestType(ctx context.Context, userID int64, re
 	}
 
 	requestType := &model.RequestType{
-		ID:           id,
-		Name:         req.Name,
-		Slug:         common.GenerateSlug(req.Name),
-		DepartmentID: req.DepartmentID,
-		CreatedByID:  userID,
-		UpdatedByID:  userID,
+		ID:                 id,
+		Name:               req.Name,
+		Slug:               common.GenerateSlug(req.Name),
+		DepartmentID:       req.DepartmentID,
+		AcceptSLAMinutes:   req.AcceptSLAMinutes,
+		CompleteSLAMinutes: req.CompleteSLAMinutes,
+		CreatedByID:        userID,
+		UpdatedByID:        userID,
 	}
 
 	if err = s.requestRepo.CreateRequestType(ctx, requestType); err != nil {
@@ -89,16 +107,69 @@ func (s *requestSvcImpl) GetRequestTypesForAdmin(ctx context.Context) ([]*model.
 	return requestTypes, nil
 }
 
-func (s *requestSvcImpl) GetRequestTypesForGuest(ctx context.Context) ([]*model.RequestType, error) {
+func (s *requestSvcImpl) GetRequestTypesForGuest(ctx context.Context, locales []string) ([]*model.RequestType, error) {
 	requestTypes, err := s.requestRepo.FindAllRequestTypesWithDetails(ctx)
 	if err != nil {
 		s.logger.Error("get request types for admin failed", zap.Error(err))
 		return nil, err
 	}
 
+	for _, requestType := range requestTypes {
+		if translation, ok := findTranslation(requestType.Translations, locales, func(t *model.RequestTypeTranslation) string { return t.Locale }); ok {
+			requestType.Name = translation.Name
+		}
+	}
+
 	return requestTypes, nil
 }
 
+func (s *requestSvcImpl) UpsertRequestTypeTranslation(ctx context.Context, requestTypeID int64, locale string, req types.NameTranslationRequest) error {
+	locale, ok := common.NormalizeLocale(locale)
+	if !ok {
+		return common.ErrInvalidLocale
+	}
+
+	id, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate request type translation id failed", zap.Error(err))
+		return err
+	}
+
+	translation := &model.RequestTypeTranslation{
+		ID:            id,
+		RequestTypeID: requestTypeID,
+		Locale:        locale,
+		Name:          req.Name,
+	}
+
+	if err = s.requestRepo.UpsertRequestTypeTranslation(ctx, translation); err != nil {
+		if common.IsForeignKeyViolation(err) {
+			return common.ErrRequestTypeNotFound
+		}
+		s.logger.Error("upsert request type translation failed", zap.Int64("id", requestTypeID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *requestSvcImpl) DeleteRequestTypeTranslation(ctx context.Context, requestTypeID int64, locale string) error {
+	locale, ok := common.NormalizeLocale(locale)
+	if !ok {
+		return common.ErrInvalidLocale
+	}
+
+	if err := s.requestRepo.DeleteRequestTypeTranslation(ctx, requestTypeID, locale); err != nil {
+		if errors.Is(err, common.ErrTranslationNotFound) {
+			return err
+		}
+		s.logger.Error("delete request type translation failed", zap.Int64("id", requestTypeID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
 func (s *requestSvcImpl) UpdateRequestType(ctx context.Context, requestTypeID, userID int64, req types.UpdateRequestTypeRequest) error {
 	requestType, err := s.requestRepo.FindRequestTypeByID(ctx, requestTypeID)
 	if err != nil {
@@ -118,6 +189,12 @@ func (s *requestSvcImpl) UpdateRequestType(ctx context.Context, requestTypeID, u
 	if req.DepartmentID != nil && *req.DepartmentID != requestType.DepartmentID {
 		updateData["department_id"] = *req.DepartmentID
 	}
+	if req.AcceptSLAMinutes != nil {
+		updateData["accept_sla_minutes"] = slaMinutesValue(*req.AcceptSLAMinutes)
+	}
+	if req.CompleteSLAMinutes != nil {
+		updateData["complete_sla_minutes"] = slaMinutesValue(*req.CompleteSLAMinutes)
+	}
 
 	if len(updateData) > 0 {
 		updateData["updated_by_id"] = userID
@@ -166,6 +243,23 @@ func (s *requestSvcImpl) CreateRequest(ctx context.Context, orderRoomID int64, r
 		s.logger.Error("find request type by id failed", zap.Int64("id", req.RequestTypeID), zap.Error(err))
 		return 0, err
 	}
+	if requestType == nil {
+		return 0, common.ErrRequestTypeNotFound
+	}
+
+	if len(req.Attachments) > common.MaxRequestAttachments {
+		return 0, common.ErrTooManyAttachments
+	}
+
+	attachments, err := s.prepareRequestAttachments(ctx, orderRoomID, req.Attachments)
+	if err != nil {
+		return 0, err
+	}
+
+	priority := req.Priority
+	if priority == "" {
+		priority = "normal"
+	}
 
 	requestID, err := s.sfGen.NextID()
 	if err != nil {
@@ -173,69 +267,285 @@ func (s *requestSvcImpl) CreateRequest(ctx context.Context, orderRoomID int64, r
 		return 0, err
 	}
 
+	now := time.Now()
 	request := &model.Request{
 		ID:            requestID,
 		Content:       req.Content,
 		Status:        "pending",
+		Priority:      priority,
 		RequestTypeID: requestType.ID,
 		OrderRoomID:   orderRoomID,
+		AcceptDueAt:   slaDueAt(now, requestType.AcceptSLAMinutes),
+		CompleteDueAt: slaDueAt(now, requestType.CompleteSLAMinutes),
 	}
 
 	if err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err = s.requestRepo.CreateRequest(ctx, request); err != nil {
+		if err = s.requestRepo.CreateRequestTx(tx, request); err != nil {
 			s.logger.Error("create request failed", zap.Error(err))
 			return err
 		}
 
-		notificationID, err := s.sfGen.NextID()
+		if len(attachments) > 0 {
+			for _, attachment := range attachments {
+				attachment.RequestID = requestID
+			}
+
+			if err = s.requestRepo.CreateRequestAttachmentsTx(tx, attachments); err != nil {
+				if ok, _ := common.IsUniqueViolation(err); ok {
+					return common.ErrAttachmentNotFound
+				}
+				s.logger.Error("create request attachments failed", zap.Error(err))
+				return err
+			}
+
+			keys := make([]string, 0, len(attachments))
+			for _, attachment := range attachments {
+				keys = append(keys, attachment.Key)
+			}
+			if err = s.fileRepo.ConfirmUploadsTx(tx, keys); err != nil {
+				if errors.Is(err, common.ErrUploadExpired) {
+					return err
+				}
+				s.logger.Error("confirm uploads failed", zap.Error(err))
+				return err
+			}
+		}
+
+		return s.notifyNewRequestTx(tx, request, requestType, orderRoom.Room.Name)
+	}); err != nil {
+		return 0, err
+	}
+
+	return requestID, nil
+}
+
+func (s *requestSvcImpl) notifyNewRequestTx(tx *gorm.DB, request *model.Request, requestType *model.RequestType, roomName string) error {
+	notificationID, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate notification id failed", zap.Error(err))
+		return err
+	}
+
+	content := fmt.Sprintf("Phòng %s yêu cầu %s", roomName, requestType.Name)
+	if request.Priority == "urgent" {
+		content = "[Khẩn cấp] " + content
+	}
+	notification := &model.Notification{
+		ID:           notificationID,
+		DepartmentID: requestType.DepartmentID,
+		OrderRoomID:  request.OrderRoomID,
+		Type:         "request",
+		Receiver:     "staff",
+		Content:      content,
+		ContentID:    request.ID,
+	}
+
+	if err = s.notificationRepo.CreateNotificationTx(tx, notification); err != nil {
+		s.logger.Error("create notification failed", zap.Error(err))
+		return err
+	}
+
+	staffIDs := make([]int64, 0, len(requestType.Department.Staffs))
+	for _, staff := range requestType.Department.Staffs {
+		staffIDs = append(staffIDs, staff.ID)
+	}
+
+	requestNotificationMsg := types.NotificationMessage{
+		Content:      notification.Content,
+		Type:         notification.Type,
+		ContentID:    notification.ContentID,
+		Receiver:     notification.Receiver,
+		DepartmentID: &requestType.DepartmentID,
+		ReceiverIDs:  staffIDs,
+	}
+
+	go func(msg types.NotificationMessage) {
+		body, _ := json.Marshal(msg)
+		if err := s.mqProvider.PublishMessage(common.ExchangeNotification, common.RoutingKeyRequestNotification, body); err != nil {
+			s.logger.Error("publish request notification message failed", zap.Error(err))
+		}
+	}(requestNotificationMsg)
+
+	return nil
+}
+
+func (s *requestSvcImpl) CreateRequestSchedule(ctx context.Context, orderRoomID int64, req types.CreateRequestScheduleRequest) (int64, error) {
+	orderRoom, err := s.orderRepo.FindOrderRoomByIDWithDetails(ctx, orderRoomID)
+	if err != nil {
+		s.logger.Error("find order room by id failed", zap.Int64("id", orderRoomID), zap.Error(err))
+		return 0, err
+	}
+	if orderRoom == nil {
+		return 0, common.ErrOrderRoomNotFound
+	}
+
+	now := time.Now()
+	if !req.ScheduledAt.After(now) || !req.ScheduledAt.Before(orderRoom.Booking.CheckOut) {
+		return 0, common.ErrInvalidScheduleTime
+	}
+
+	requestType, err := s.requestRepo.FindRequestTypeByID(ctx, req.RequestTypeID)
+	if err != nil {
+		s.logger.Error("find request type by id failed", zap.Int64("id", req.RequestTypeID), zap.Error(err))
+		return 0, err
+	}
+	if requestType == nil {
+		return 0, common.ErrRequestTypeNotFound
+	}
+
+	priority := req.Priority
+	if priority == "" {
+		priority = "normal"
+	}
+
+	recurrence := req.Recurrence
+	if recurrence == "" {
+		recurrence = "none"
+	}
+
+	scheduleID, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate request schedule id failed", zap.Error(err))
+		return 0, err
+	}
+
+	schedule := &model.RequestSchedule{
+		ID:            scheduleID,
+		OrderRoomID:   orderRoomID,
+		RequestTypeID: requestType.ID,
+		Content:       req.Content,
+		Priority:      priority,
+		Recurrence:    recurrence,
+		Status:        "active",
+		NextRunAt:     req.ScheduledAt,
+	}
+
+	if err = s.requestRepo.CreateRequestSchedule(ctx, schedule); err != nil {
+		s.logger.Error("create request schedule failed", zap.Error(err))
+		return 0, err
+	}
+
+	return scheduleID, nil
+}
+
+func (s *requestSvcImpl) GetRequestSchedulesForGuest(ctx context.Context, orderRoomID int64) ([]*model.RequestSchedule, error) {
+	schedules, err := s.requestRepo.FindAllRequestSchedulesByOrderRoomIDWithDetails(ctx, orderRoomID)
+	if err != nil {
+		s.logger.Error("find all request schedules by order room id failed", zap.Error(err))
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func (s *requestSvcImpl) CancelRequestSchedule(ctx context.Context, orderRoomID, scheduleID int64) error {
+	cancelled, err := s.requestRepo.CancelRequestSchedule(ctx, scheduleID, orderRoomID, time.Now())
+	if err != nil {
+		s.logger.Error("cancel request schedule failed", zap.Int64("id", scheduleID), zap.Error(err))
+		return err
+	}
+	if !cancelled {
+		return common.ErrRequestScheduleNotFound
+	}
+
+	return nil
+}
+
+func (s *requestSvcImpl) MaterializeScheduledRequests(ctx context.Context) error {
+	now := time.Now()
+	cancelled, err := s.requestRepo.CancelRequestSchedulesAfterCheckOut(ctx, now)
+	if err != nil {
+		s.logger.Error("cancel request schedules after check-out failed", zap.Error(err))
+		return err
+	}
+	if cancelled > 0 {
+		s.logger.Info("request schedules cancelled after check-out", zap.Int64("count", cancelled))
+	}
+
+	scheduleIDs, err := s.requestRepo.FindAllDueRequestScheduleIDs(ctx, now, common.RequestScheduleBatchSize)
+	if err != nil {
+		s.logger.Error("find all due request schedule ids failed", zap.Error(err))
+		return err
+	}
+
+	// One broken schedule must not hold back the others; it stays due and is
+	// retried on the next tick.
+	for _, scheduleID := range scheduleIDs {
+		if err = s.materializeScheduledRequest(ctx, scheduleID, now); err != nil {
+			s.logger.Error("materialize scheduled request failed", zap.Int64("schedule_id", scheduleID), zap.Error(err))
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (s *requestSvcImpl) materializeScheduledRequest(ctx context.Context, scheduleID int64, now time.Time) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		schedule, err := s.requestRepo.FindDueRequestScheduleByIDWithDetailsTx(tx, scheduleID, now)
 		if err != nil {
-			s.logger.Error("generate notification id failed", zap.Error(err))
+			s.logger.Error("find due request schedule by id failed", zap.Int64("id", scheduleID), zap.Error(err))
 			return err
 		}
+		if schedule == nil {
+			return nil
+		}
 
-		content := fmt.Sprintf("Phòng %s yêu cầu %s", orderRoom.Room.Name, requestType.Name)
-		notification := &model.Notification{
-			ID:           notificationID,
-			DepartmentID: requestType.DepartmentID,
-			OrderRoomID:  orderRoomID,
-			Type:         "request",
-			Receiver:     "staff",
-			Content:      content,
-			ContentID:    request.ID,
+		requestID, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate request id failed", zap.Error(err))
+			return err
 		}
 
-		if err = s.notificationRepo.CreateNotificationTx(tx, notification); err != nil {
-			s.logger.Error("create notification failed", zap.Error(err))
+		request := &model.Request{
+			ID:            requestID,
+			Content:       schedule.Content,
+			Status:        "pending",
+			Priority:      schedule.Priority,
+			RequestTypeID: schedule.RequestTypeID,
+			OrderRoomID:   schedule.OrderRoomID,
+			ScheduleID:    &schedule.ID,
+			AcceptDueAt:   slaDueAt(now, schedule.RequestType.AcceptSLAMinutes),
+			CompleteDueAt: slaDueAt(now, schedule.RequestType.CompleteSLAMinutes),
+		}
+
+		if err = s.requestRepo.CreateRequestTx(tx, request); err != nil {
+			s.logger.Error("create scheduled request failed", zap.Int64("schedule_id", scheduleID), zap.Error(err))
 			return err
 		}
 
-		staffIDs := make([]int64, 0, len(requestType.Department.Staffs))
-		for _, staff := range requestType.Department.Staffs {
-			staffIDs = append(staffIDs, staff.ID)
+		updateData := map[string]any{"last_run_at": now}
+		nextRunAt := nextScheduleRunAt(schedule, now)
+		if nextRunAt == nil {
+			updateData["status"] = "completed"
+		} else {
+			updateData["next_run_at"] = *nextRunAt
 		}
 
-		requestNotificationMsg := types.NotificationMessage{
-			Content:      notification.Content,
-			Type:         notification.Type,
-			ContentID:    notification.ContentID,
-			Receiver:     notification.Receiver,
-			DepartmentID: &requestType.DepartmentID,
-			ReceiverIDs:  staffIDs,
+		if err = s.requestRepo.UpdateRequestScheduleTx(tx, scheduleID, updateData); err != nil {
+			s.logger.Error("update request schedule failed", zap.Int64("id", scheduleID), zap.Error(err))
+			return err
 		}
 
-		go func(msg types.NotificationMessage) {
-			body, _ := json.Marshal(msg)
-			if err := s.mqProvider.PublishMessage(common.ExchangeNotification, common.RoutingKeyRequestNotification, body); err != nil {
-				s.logger.Error("publish request notification message failed", zap.Error(err))
-			}
-		}(requestNotificationMsg)
+		return s.notifyNewRequestTx(tx, request, schedule.RequestType, schedule.OrderRoom.Room.Name)
+	})
+}
 
+func nextScheduleRunAt(schedule *model.RequestSchedule, now time.Time) *time.Time {
+	if schedule.Recurrence != "daily" {
 		return nil
-	}); err != nil {
-		return 0, err
 	}
 
-	return requestID, nil
+	nextRunAt := schedule.NextRunAt.AddDate(0, 0, 1)
+	for !nextRunAt.After(now) {
+		nextRunAt = nextRunAt.AddDate(0, 0, 1)
+	}
+
+	if !nextRunAt.Before(schedule.OrderRoom.Booking.CheckOut) {
+		return nil
+	}
+
+	return &nextRunAt
 }
 
 func (s *requestSvcImpl) UpdateRequestForGuest(ctx context.Context, orderRoomID, requestID int64, status string) error {
@@ -270,6 +580,10 @@ func (s *requestSvcImpl) UpdateRequestForGuest(ctx context.Context, orderRoomID,
 			return err
 		}
 
+		if err = s.createRequestStatusNote(tx, requestID, nil, status); err != nil {
+			return err
+		}
+
 		notificationID, err := s.sfGen.NextID()
 		if err != nil {
 			s.logger.Error("generate notification id failed", zap.Error(err))
@@ -293,8 +607,12 @@ func (s *requestSvcImpl) UpdateRequestForGuest(ctx context.Context, orderRoomID,
 		}
 
 		staffIDs := make([]int64, 0, len(request.RequestType.Department.Staffs))
-		for _, staff := range request.RequestType.Department.Staffs {
-			staffIDs = append(staffIDs, staff.ID)
+		if request.AssigneeID != nil {
+			staffIDs = append(staffIDs, *request.AssigneeID)
+		} else {
+			for _, staff := range request.RequestType.Department.Staffs {
+				staffIDs = append(staffIDs, staff.ID)
+			}
 		}
 
 		requestNotificationMsg := types.NotificationMessage{
@@ -381,9 +699,165 @@ func (s *requestSvcImpl) GetRequestByID(ctx context.Context, userID, requestID i
 		}
 	}
 
+	for _, attachment := range request.Attachments {
+		url, err := s.signViewURL(attachment.Key)
+		if err != nil {
+			return nil, err
+		}
+		attachment.URL = url
+	}
+
 	return request, nil
 }
 
+func (s *requestSvcImpl) CreateRequestNote(ctx context.Context, requestID, userID int64, departmentID *int64, req types.CreateRequestNoteRequest) error {
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return common.ErrRequestNoteContentRequired
+	}
+
+	request, err := s.requestRepo.FindRequestByIDWithRequestType(ctx, requestID)
+	if err != nil {
+		s.logger.Error("find request by id failed", zap.Int64("id", requestID), zap.Error(err))
+		return err
+	}
+	if request == nil {
+		return common.ErrRequestNotFound
+	}
+	if departmentID != nil && request.RequestType.DepartmentID != *departmentID {
+		return common.ErrRequestNotFound
+	}
+
+	id, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate request note id failed", zap.Error(err))
+		return err
+	}
+
+	note := &model.RequestNote{
+		ID:        id,
+		RequestID: requestID,
+		Type:      req.Type,
+		Content:   content,
+		StaffID:   &userID,
+	}
+
+	if err = s.requestRepo.CreateRequestNote(ctx, note); err != nil {
+		s.logger.Error("create request note failed", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *requestSvcImpl) createRequestStatusNote(tx *gorm.DB, requestID int64, staffID *int64, status string) error {
+	id, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate request note id failed", zap.Error(err))
+		return err
+	}
+
+	var content string
+	switch status {
+	case "accepted":
+		content = "Đã tiếp nhận yêu cầu"
+	case "done":
+		content = "Đã hoàn thành yêu cầu"
+	case "cancelled":
+		content = "Khách đã hủy yêu cầu"
+	default:
+		content = status
+	}
+
+	note := &model.RequestNote{
+		ID:        id,
+		RequestID: requestID,
+		Type:      "status",
+		Content:   content,
+		StaffID:   staffID,
+	}
+
+	if err = s.requestRepo.CreateRequestNoteTx(tx, note); err != nil {
+		s.logger.Error("create request note failed", zap.Int64("request_id", requestID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *requestSvcImpl) prepareRequestAttachments(ctx context.Context, orderRoomID int64, reqs []types.CreateRequestAttachmentRequest) ([]*model.RequestAttachment, error) {
+	keys := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		if !common.IsUploadKeyFor(req.Key, common.UploadPurposeRequestPhoto) {
+			return nil, common.ErrInvalidUploadKey
+		}
+		if slices.Contains(keys, req.Key) {
+			return nil, common.ErrAttachmentNotFound
+		}
+		keys = append(keys, req.Key)
+	}
+
+	owned, err := s.fileRepo.CountUploadsOwnedBy(ctx, keys, "guest", orderRoomID)
+	if err != nil {
+		s.logger.Error("count uploads owned by guest failed", zap.Int64("order_room_id", orderRoomID), zap.Error(err))
+		return nil, err
+	}
+	if owned != int64(len(keys)) {
+		return nil, common.ErrAttachmentNotFound
+	}
+
+	attachments := make([]*model.RequestAttachment, 0, len(reqs))
+
+	for i, req := range reqs {
+
+		attrs, err := s.storage.Attrs(ctx, req.Key)
+		if err != nil {
+			if errors.Is(err, storage.ErrObjectNotExist) {
+				return nil, common.ErrAttachmentNotFound
+			}
+			s.logger.Error("get attachment attrs failed", zap.String("key", req.Key), zap.Error(err))
+			return nil, err
+		}
+
+		if !slices.Contains(common.AllowedRequestAttachmentTypes, attrs.ContentType) {
+			return nil, common.ErrUnsupportedAttachmentType
+		}
+		if attrs.Size > common.MaxAttachmentSize {
+			return nil, common.ErrAttachmentTooLarge
+		}
+
+		id, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate attachment id failed", zap.Error(err))
+			return nil, err
+		}
+
+		attachments = append(attachments, &model.RequestAttachment{
+			ID:          id,
+			Key:         req.Key,
+			FileName:    req.FileName,
+			ContentType: attrs.ContentType,
+			Size:        attrs.Size,
+			SortOrder:   uint32(i + 1),
+		})
+	}
+
+	return attachments, nil
+}
+
+func (s *requestSvcImpl) signViewURL(key string) (string, error) {
+	url, err := s.storage.SignedURL(key, &storage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(15 * time.Minute),
+	})
+	if err != nil {
+		s.logger.Error("generate view signed URL failed", zap.String("key", key), zap.Error(err))
+		return "", err
+	}
+
+	return url, nil
+}
+
 func (s *requestSvcImpl) UpdateRequestForAdmin(ctx context.Context, departmentID *int64, userID, requestID int64, status string) error {
 	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		request, err := s.requestRepo.FindRequestByIDWithRequestTypeDetailsAndOrderRoomDetailsTx(tx, requestID)
@@ -410,15 +884,33 @@ func (s *requestSvcImpl) UpdateRequestForAdmin(ctx context.Context, departmentID
 			return common.ErrInvalidStatus
 		}
 
+		if departmentID != nil && request.AssigneeID != nil && *request.AssigneeID != userID {
+			return common.ErrRequestAssignedToOther
+		}
+
 		updateData := map[string]any{
 			"status":        status,
 			"updated_by_id": userID,
 		}
+		switch status {
+		case "accepted":
+			updateData["accepted_at"] = time.Now()
+			if departmentID != nil && request.AssigneeID == nil {
+				updateData["assignee_id"] = userID
+				updateData["assigned_at"] = time.Now()
+			}
+		case "done":
+			updateData["done_at"] = time.Now()
+		}
 		if err = s.requestRepo.UpdateRequestTx(tx, requestID, updateData); err != nil {
 			s.logger.Error("update request failed", zap.Int64("id", requestID), zap.Error(err))
 			return err
 		}
 
+		if err = s.createRequestStatusNote(tx, requestID, &userID, status); err != nil {
+			return err
+		}
+
 		notificationID, err := s.sfGen.NextID()
 		if err != nil {
 			s.logger.Error("generate notification id failed", zap.Error(err))
@@ -469,7 +961,7 @@ func (s *requestSvcImpl) UpdateRequestForAdmin(ctx context.Context, departmentID
 	return nil
 }
 
-func (s *requestSvcImpl) GetRequestsForAdmin(ctx context.Context, query types.RequestPaginationQuery, departmentID *int64) ([]*model.Request, *types.MetaResponse, error) {
+func (s *requestSvcImpl) GetRequestsForAdmin(ctx context.Context, query types.RequestPaginationQuery, userID int64, departmentID *int64) ([]*model.Request, *types.MetaResponse, error) {
 	if query.Page == 0 {
 		query.Page = 1
 	}
@@ -477,7 +969,7 @@ func (s *requestSvcImpl) GetRequestsForAdmin(ctx context.Context, query types.Re
 		query.Limit = 10
 	}
 
-	requests, total, err := s.requestRepo.FindAllRequestsWithDetailsPaginated(ctx, query, departmentID)
+	requests, total, err := s.requestRepo.FindAllRequestsWithDetailsPaginated(ctx, query, userID, departmentID)
 	if err != nil {
 		s.logger.Error("find all requests paginated failed", zap.Error(err))
 		return nil, nil, err
@@ -499,3 +991,284 @@ func (s *requestSvcImpl) GetRequestsForAdmin(ctx context.Context, query types.Re
 
 	return requests, meta, nil
 }
+
+func (s *requestSvcImpl) ClaimRequest(ctx context.Context, requestID, userID int64, departmentID *int64) error {
+	if departmentID == nil {
+		return common.ErrAssigneeNotInDepartment
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		request, err := s.findRequestForAssignment(tx, requestID, departmentID)
+		if err != nil {
+			return err
+		}
+		if request.AssigneeID != nil {
+			if *request.AssigneeID == userID {
+				return nil
+			}
+			return common.ErrRequestAlreadyAssigned
+		}
+
+		return s.updateRequestAssignee(tx, request, userID, userID)
+	})
+}
+
+func (s *requestSvcImpl) AssignRequest(ctx context.Context, requestID, userID int64, departmentID *int64, req types.AssignRequestRequest) error {
+	assignee, err := s.userRepo.FindByIDWithDepartment(ctx, req.AssigneeID)
+	if err != nil {
+		s.logger.Error("find user by id failed", zap.Int64("id", req.AssigneeID), zap.Error(err))
+		return err
+	}
+	if assignee == nil || !assignee.IsActive {
+		return common.ErrUserNotFound
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		request, err := s.findRequestForAssignment(tx, requestID, departmentID)
+		if err != nil {
+			return err
+		}
+		if !canBeAssigned(assignee, request.RequestType.DepartmentID) {
+			return common.ErrAssigneeNotInDepartment
+		}
+		if request.AssigneeID != nil && *request.AssigneeID == assignee.ID {
+			return nil
+		}
+
+		if err = s.updateRequestAssignee(tx, request, userID, assignee.ID); err != nil {
+			return err
+		}
+		if assignee.ID == userID {
+			return nil
+		}
+
+		notificationID, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate notification id failed", zap.Error(err))
+			return err
+		}
+
+		content := fmt.Sprintf("Yêu cầu %s của phòng %s đã được giao cho %s", request.RequestType.Name, request.OrderRoom.Room.Name, strings.TrimSpace(assignee.LastName+" "+assignee.FirstName))
+		notification := &model.Notification{
+			ID:           notificationID,
+			DepartmentID: request.RequestType.DepartmentID,
+			OrderRoomID:  request.OrderRoomID,
+			Type:         "request",
+			Receiver:     "staff",
+			Content:      content,
+			ContentID:    request.ID,
+		}
+
+		if err = s.notificationRepo.CreateNotificationTx(tx, notification); err != nil {
+			s.logger.Error("create notification failed", zap.Error(err))
+			return err
+		}
+
+		assignNotificationMsg := types.NotificationMessage{
+			Content:      notification.Content,
+			Type:         notification.Type,
+			ContentID:    notification.ContentID,
+			Receiver:     notification.Receiver,
+			DepartmentID: &request.RequestType.DepartmentID,
+			ReceiverIDs:  []int64{assignee.ID},
+		}
+
+		go func(msg types.NotificationMessage) {
+			body, _ := json.Marshal(msg)
+			if err := s.mqProvider.PublishMessage(common.ExchangeNotification, common.RoutingKeyRequestNotification, body); err != nil {
+				s.logger.Error("publish request notification message failed", zap.Error(err))
+			}
+		}(assignNotificationMsg)
+
+		return nil
+	})
+}
+
+func (s *requestSvcImpl) findRequestForAssignment(tx *gorm.DB, requestID int64, departmentID *int64) (*model.Request, error) {
+	request, err := s.requestRepo.FindRequestByIDWithRequestTypeDetailsAndOrderRoomDetailsTx(tx, requestID)
+	if err != nil {
+		if strings.Contains(err.Error(), "lock") {
+			return nil, common.ErrLockedRecord
+		}
+		s.logger.Error("find request by id failed", zap.Int64("id", requestID), zap.Error(err))
+		return nil, err
+	}
+	if request == nil {
+		return nil, common.ErrRequestNotFound
+	}
+	if departmentID != nil && request.RequestType.DepartmentID != *departmentID {
+		return nil, common.ErrRequestNotFound
+	}
+	if request.Status != "pending" && request.Status != "accepted" {
+		return nil, common.ErrInvalidStatus
+	}
+
+	return request, nil
+}
+
+func (s *requestSvcImpl) updateRequestAssignee(tx *gorm.DB, request *model.Request, userID, assigneeID int64) error {
+	updateData := map[string]any{
+		"assignee_id":   assigneeID,
+		"assigned_at":   time.Now(),
+		"updated_by_id": userID,
+	}
+	if err := s.requestRepo.UpdateRequestTx(tx, request.ID, updateData); err != nil {
+		s.logger.Error("update request failed", zap.Int64("id", request.ID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *requestSvcImpl) EscalateSLABreaches(ctx context.Context) error {
+	now := time.Now()
+	requests, err := s.requestRepo.FindAllRequestsBreachingSLA(ctx, now, common.SLABatchSize)
+	if err != nil {
+		s.logger.Error("find all requests breaching sla failed", zap.Error(err))
+		return err
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	adminIDs, err := s.userRepo.FindAllActiveAdminIDs(ctx)
+	if err != nil {
+		s.logger.Error("find all active admin ids failed", zap.Error(err))
+		return err
+	}
+
+	for _, request := range requests {
+		receiverIDs := slaEscalationReceiverIDs(request, adminIDs)
+
+		if request.Status == "pending" && request.AcceptBreachedAt == nil && request.AcceptDueAt != nil && request.AcceptDueAt.Before(now) {
+			content := fmt.Sprintf("Yêu cầu %s của phòng %s đã quá hạn tiếp nhận", request.RequestType.Name, request.OrderRoom.Room.Name)
+			if err = s.escalateSLABreach(ctx, request, "accept_breached_at", content, receiverIDs, now); err != nil {
+				s.logger.Error("escalate sla breach failed", zap.Int64("request_id", request.ID), zap.Error(err))
+				continue
+			}
+		}
+
+		if request.CompleteBreachedAt == nil && request.CompleteDueAt != nil && request.CompleteDueAt.Before(now) {
+			content := fmt.Sprintf("Yêu cầu %s của phòng %s đã quá hạn hoàn thành", request.RequestType.Name, request.OrderRoom.Room.Name)
+			if err = s.escalateSLABreach(ctx, request, "complete_breached_at", content, receiverIDs, now); err != nil {
+				s.logger.Error("escalate sla breach failed", zap.Int64("request_id", request.ID), zap.Error(err))
+			}
+		}
+	}
+
+	return nil
+}
+
+// escalateSLABreach stores the escalation once per receiver, addressed to
+// them, so it shows up in their notification list whether or not they
+// belong to the request's department, and pushes it to them live.
+func (s *requestSvcImpl) escalateSLABreach(ctx context.Context, request *model.Request, column, content string, receiverIDs []int64, breachedAt time.Time) error {
+	marked := false
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		marked, err = s.requestRepo.MarkRequestSLABreachedTx(tx, request.ID, column, breachedAt)
+		if err != nil {
+			s.logger.Error("mark request sla breached failed", zap.Int64("id", request.ID), zap.Error(err))
+			return err
+		}
+		if !marked {
+			return nil
+		}
+
+		for _, receiverID := range receiverIDs {
+			notificationID, err := s.sfGen.NextID()
+			if err != nil {
+				s.logger.Error("generate notification id failed", zap.Error(err))
+				return err
+			}
+
+			notification := &model.Notification{
+				ID:           notificationID,
+				DepartmentID: request.RequestType.DepartmentID,
+				OrderRoomID:  request.OrderRoomID,
+				StaffID:      &receiverID,
+				Type:         "request",
+				Receiver:     "staff",
+				Content:      content,
+				ContentID:    request.ID,
+			}
+
+			if err = s.notificationRepo.CreateNotificationTx(tx, notification); err != nil {
+				s.logger.Error("create notification failed", zap.Error(err))
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+	if !marked || len(receiverIDs) == 0 {
+		return nil
+	}
+
+	escalationMsg := types.NotificationMessage{
+		Content:      content,
+		Type:         "request",
+		ContentID:    request.ID,
+		Receiver:     "staff",
+		DepartmentID: &request.RequestType.DepartmentID,
+		ReceiverIDs:  receiverIDs,
+	}
+
+	go func(msg types.NotificationMessage) {
+		body, _ := json.Marshal(msg)
+		if err := s.mqProvider.PublishMessage(common.ExchangeNotification, common.RoutingKeyRequestNotification, body); err != nil {
+			s.logger.Error("publish sla escalation message failed", zap.Error(err))
+		}
+	}(escalationMsg)
+
+	return nil
+}
+
+// slaEscalationReceiverIDs lists who hears about a breach: the assignee, the
+// head of the request's department and every active admin.
+func slaEscalationReceiverIDs(request *model.Request, adminIDs []int64) []int64 {
+	receiverIDs := make([]int64, 0, len(adminIDs)+2)
+
+	if request.AssigneeID != nil {
+		receiverIDs = append(receiverIDs, *request.AssigneeID)
+	}
+
+	department := request.RequestType.Department
+	if department != nil && department.HeadID != nil && department.Head != nil && department.Head.IsActive &&
+		department.Head.DepartmentID != nil && *department.Head.DepartmentID == department.ID {
+		if !slices.Contains(receiverIDs, *department.HeadID) {
+			receiverIDs = append(receiverIDs, *department.HeadID)
+		}
+	}
+
+	for _, id := range adminIDs {
+		if !slices.Contains(receiverIDs, id) {
+			receiverIDs = append(receiverIDs, id)
+		}
+	}
+
+	return receiverIDs
+}
+
+func canBeAssigned(staff *model.User, departmentID int64) bool {
+	return staff.IsActive && staff.DepartmentID != nil && *staff.DepartmentID == departmentID
+}
+
+func slaDueAt(from time.Time, minutes *uint32) *time.Time {
+	if minutes == nil || *minutes == 0 {
+		return nil
+	}
+
+	dueAt := from.Add(time.Duration(*minutes) * time.Minute)
+	return &dueAt
+}
+
+func slaMinutesValue(minutes uint32) any {
+	if minutes == 0 {
+		return nil
+	}
+
+	return minutes
+}
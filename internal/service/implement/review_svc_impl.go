@@ -3,6 +3,8 @@ package implement
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/common"
 	"github.com/InstaySystem/is_v1-be/internal/model"
@@ -122,6 +124,22 @@ func (s *reviewSvcImpl) UpdateReview(ctx context.Context, req types.UpdateReview
 		updateData["star"] = *req.Star
 	}
 
+	// Moderation, featuring and the management response all refer to the
+	// text staff looked at, so an edit sends the review back for moderation
+	// instead of publishing the new text under them.
+	if len(updateData) > 0 && (review.ModeratedAt != nil || review.Response != nil || review.IsFeatured) {
+		updateData["is_featured"] = false
+		updateData["response"] = nil
+		updateData["responded_by_id"] = nil
+		updateData["responded_at"] = nil
+		if review.Status != common.ReviewStatusHidden {
+			updateData["status"] = common.ReviewStatusFlagged
+			updateData["moderation_reason"] = common.ReviewEditedReason
+			updateData["moderated_by_id"] = nil
+			updateData["moderated_at"] = time.Now()
+		}
+	}
+
 	if len(updateData) > 0 {
 		if err = s.reviewRepo.Update(ctx, review.ID, updateData); err != nil {
 			if errors.Is(err, common.ErrReviewNotFound) {
@@ -134,3 +152,136 @@ func (s *reviewSvcImpl) UpdateReview(ctx context.Context, req types.UpdateReview
 
 	return nil
 }
+
+func (s *reviewSvcImpl) RespondReview(ctx context.Context, reviewID, userID int64, req types.RespondReviewRequest) error {
+	response := strings.TrimSpace(req.Response)
+	if response == "" {
+		return common.ErrReviewResponseRequired
+	}
+
+	updateData := map[string]any{
+		"response":        response,
+		"responded_by_id": userID,
+		"responded_at":    time.Now(),
+	}
+
+	if err := s.reviewRepo.Update(ctx, reviewID, updateData); err != nil {
+		if errors.Is(err, common.ErrReviewNotFound) {
+			return err
+		}
+		s.logger.Error("respond review failed", zap.Int64("id", reviewID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *reviewSvcImpl) DeleteReviewResponse(ctx context.Context, reviewID int64) error {
+	updateData := map[string]any{
+		"response":        nil,
+		"responded_by_id": nil,
+		"responded_at":    nil,
+	}
+
+	if err := s.reviewRepo.Update(ctx, reviewID, updateData); err != nil {
+		if errors.Is(err, common.ErrReviewNotFound) {
+			return err
+		}
+		s.logger.Error("delete review response failed", zap.Int64("id", reviewID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *reviewSvcImpl) ModerateReview(ctx context.Context, reviewID, userID int64, req types.ModerateReviewRequest) error {
+	var reason *string
+	if req.Reason != nil {
+		if trimmed := strings.TrimSpace(*req.Reason); trimmed != "" {
+			reason = &trimmed
+		}
+	}
+	if req.Status != common.ReviewStatusApproved && reason == nil {
+		return common.ErrModerationReasonRequired
+	}
+
+	updateData := map[string]any{
+		"status":            req.Status,
+		"moderation_reason": reason,
+		"moderated_by_id":   userID,
+		"moderated_at":      time.Now(),
+	}
+	// Hidden or flagged reviews never reach the public listing, so they
+	// cannot stay featured either.
+	if req.Status != common.ReviewStatusApproved {
+		updateData["is_featured"] = false
+	}
+
+	if err := s.reviewRepo.Update(ctx, reviewID, updateData); err != nil {
+		if errors.Is(err, common.ErrReviewNotFound) {
+			return err
+		}
+		s.logger.Error("moderate review failed", zap.Int64("id", reviewID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *reviewSvcImpl) FeatureReview(ctx context.Context, reviewID int64, req types.FeatureReviewRequest) error {
+	review, err := s.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil {
+		s.logger.Error("find review by id failed", zap.Int64("id", reviewID), zap.Error(err))
+		return err
+	}
+	if review == nil {
+		return common.ErrReviewNotFound
+	}
+	if *req.IsFeatured && review.Status != common.ReviewStatusApproved {
+		return common.ErrReviewNotApproved
+	}
+	if review.IsFeatured == *req.IsFeatured {
+		return nil
+	}
+
+	if err = s.reviewRepo.Update(ctx, reviewID, map[string]any{"is_featured": *req.IsFeatured}); err != nil {
+		if errors.Is(err, common.ErrReviewNotFound) {
+			return err
+		}
+		s.logger.Error("feature review failed", zap.Int64("id", reviewID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *reviewSvcImpl) GetPublicReviews(ctx context.Context, query types.PublicReviewPaginationQuery) ([]*model.Review, *types.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	reviews, total, err := s.reviewRepo.FindAllApprovedPaginated(ctx, query)
+	if err != nil {
+		s.logger.Error("find all approved reviews paginated failed", zap.Error(err))
+		return nil, nil, err
+	}
+
+	totalPages := uint32(total) / query.Limit
+	if uint32(total)%query.Limit != 0 {
+		totalPages++
+	}
+
+	meta := &types.MetaResponse{
+		Total:      uint64(total),
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: uint16(totalPages),
+		HasPrev:    query.Page > 1,
+		HasNext:    query.Page < totalPages,
+	}
+
+	return reviews, meta, nil
+}
@@ -1,42 +1,77 @@
 package implement
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/InstaySystem/is_v1-be/internal/common"
+	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/model"
 	"github.com/InstaySystem/is_v1-be/internal/provider/mq"
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
 	"github.com/InstaySystem/is_v1-be/internal/repository"
 	"github.com/InstaySystem/is_v1-be/internal/service"
 	"github.com/InstaySystem/is_v1-be/internal/types"
+	"github.com/InstaySystem/is_v1-be/pkg/imaging"
 	"github.com/InstaySystem/is_v1-be/pkg/snowflake"
+	"github.com/InstaySystem/is_v1-be/pkg/xlsx"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+// pendingImageRequeueDelay leaves a freshly stored image to the message
+// published on commit before the worker publishes it again.
+const pendingImageRequeueDelay = 15 * time.Minute
+
 type serviceSvcImpl struct {
-	serviceRepo repository.ServiceRepository
-	db          *gorm.DB
-	sfGen       snowflake.Generator
-	logger      *zap.Logger
-	mqProvider  mq.MessageQueueProvider
+	serviceRepo    repository.ServiceRepository
+	departmentRepo repository.DepartmentRepository
+	fileRepo       repository.FileRepository
+	db             *gorm.DB
+	sfGen          snowflake.Generator
+	logger         *zap.Logger
+	mqProvider     mq.MessageQueueProvider
+	storage        storage.StorageProvider
+	imgProcessor   imaging.Processor
+	cfg            *config.Config
 }
 
 func NewServiceService(
 	serviceRepo repository.ServiceRepository,
+	departmentRepo repository.DepartmentRepository,
+	fileRepo repository.FileRepository,
 	db *gorm.DB,
 	sfGen snowflake.Generator,
 	logger *zap.Logger,
 	mqProvider mq.MessageQueueProvider,
+	storage storage.StorageProvider,
+	imgProcessor imaging.Processor,
+	cfg *config.Config,
 ) service.ServiceService {
 	return &serviceSvcImpl{
 		serviceRepo,
+		departmentRepo,
+		fileRepo,
 		db,
 		sfGen,
 		logger,
 		mqProvider,
+		storage,
+		imgProcessor,
+		cfg,
 	}
 }
 
@@ -47,6 +82,11 @@ func (s *serviceSvcImpl) CreateServiceType(ctx context.Context, userID int64, re
 		return err
 	}
 
+	openingHours, err := s.buildOpeningHours(req.OpeningHours, &id, nil)
+	if err != nil {
+		return err
+	}
+
 	serviceType := &model.ServiceType{
 		ID:           id,
 		Name:         req.Name,
@@ -54,6 +94,7 @@ func (s *serviceSvcImpl) CreateServiceType(ctx context.Context, userID int64, re
 		DepartmentID: req.DepartmentID,
 		CreatedByID:  userID,
 		UpdatedByID:  userID,
+		OpeningHours: openingHours,
 	}
 
 	if err = s.serviceRepo.CreateServiceType(ctx, serviceType); err != nil {
@@ -99,13 +140,17 @@ func (s *serviceSvcImpl) GetServiceTypesForAdmin(ctx context.Context) ([]*model.
 	return serviceTypes, nil
 }
 
-func (s *serviceSvcImpl) GetServiceTypesForGuest(ctx context.Context) ([]*model.ServiceType, error) {
+func (s *serviceSvcImpl) GetServiceTypesForGuest(ctx context.Context, locales []string) ([]*model.ServiceType, error) {
 	serviceTypes, err := s.serviceRepo.FindAllServiceType(ctx)
 	if err != nil {
 		s.logger.Error("get service types for guest failed", zap.Error(err))
 		return nil, err
 	}
 
+	for _, serviceType := range serviceTypes {
+		localizeServiceType(serviceType, locales)
+	}
+
 	return serviceTypes, nil
 }
 
@@ -129,21 +174,37 @@ func (s *serviceSvcImpl) UpdateServiceType(ctx context.Context, serviceTypeID, u
 		updateData["department_id"] = *req.DepartmentID
 	}
 
-	if len(updateData) > 0 {
-		updateData["updated_by_id"] = userID
-		if err := s.serviceRepo.UpdateServiceType(ctx, serviceTypeID, updateData); err != nil {
-			if ok, _ := common.IsUniqueViolation(err); ok {
-				return common.ErrServiceTypeAlreadyExists
-			}
-			if common.IsForeignKeyViolation(err) {
-				return common.ErrDepartmentNotFound
-			}
-			s.logger.Error("update service type failed", zap.Int64("id", serviceTypeID), zap.Error(err))
+	var openingHours []*model.ServiceOpeningHour
+	if req.OpeningHours != nil {
+		if openingHours, err = s.buildOpeningHours(*req.OpeningHours, &serviceTypeID, nil); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(updateData) > 0 {
+			updateData["updated_by_id"] = userID
+			if err := s.serviceRepo.UpdateServiceTypeTx(tx, serviceTypeID, updateData); err != nil {
+				if ok, _ := common.IsUniqueViolation(err); ok {
+					return common.ErrServiceTypeAlreadyExists
+				}
+				if common.IsForeignKeyViolation(err) {
+					return common.ErrDepartmentNotFound
+				}
+				s.logger.Error("update service type failed", zap.Int64("id", serviceTypeID), zap.Error(err))
+				return err
+			}
+		}
+
+		if req.OpeningHours != nil {
+			if err := s.serviceRepo.ReplaceServiceTypeOpeningHoursTx(tx, serviceTypeID, openingHours); err != nil {
+				s.logger.Error("replace service type opening hours failed", zap.Int64("id", serviceTypeID), zap.Error(err))
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
 func (s *serviceSvcImpl) DeleteServiceType(ctx context.Context, serviceTypeID int64) error {
@@ -162,22 +223,47 @@ func (s *serviceSvcImpl) DeleteServiceType(ctx context.Context, serviceTypeID in
 }
 
 func (s *serviceSvcImpl) CreateService(ctx context.Context, userID int64, req types.CreateServiceRequest) (int64, error) {
+	if (req.SlotDurationMinutes == nil) != (req.SlotCapacity == nil) {
+		return 0, common.ErrInvalidSlotSettings
+	}
+	for _, reqImg := range req.Images {
+		if !common.IsUploadKeyFor(reqImg.Key, common.UploadPurposeServiceImage) {
+			return 0, common.ErrInvalidUploadKey
+		}
+	}
+
 	serviceID, err := s.sfGen.NextID()
 	if err != nil {
 		s.logger.Error("generate service id failed", zap.Error(err))
 		return 0, err
 	}
 
+	openingHours, err := s.buildOpeningHours(req.OpeningHours, nil, &serviceID)
+	if err != nil {
+		return 0, err
+	}
+
+	optionGroups, err := s.buildOptionGroups(req.OptionGroups, serviceID)
+	if err != nil {
+		return 0, err
+	}
+
 	service := &model.Service{
-		ID:            serviceID,
-		Name:          req.Name,
-		Slug:          common.GenerateSlug(req.Name),
-		Price:         req.Price,
-		IsActive:      req.IsActive,
-		Description:   req.Description,
-		CreatedByID:   userID,
-		UpdatedByID:   userID,
-		ServiceTypeID: req.ServiceTypeID,
+		ID:                  serviceID,
+		Name:                req.Name,
+		Slug:                common.GenerateSlug(req.Name),
+		Price:               req.Price,
+		IsActive:            req.IsActive,
+		Description:         req.Description,
+		CreatedByID:         userID,
+		UpdatedByID:         userID,
+		ServiceTypeID:       req.ServiceTypeID,
+		OpeningHours:        openingHours,
+		OptionGroups:        optionGroups,
+		SlotDurationMinutes: req.SlotDurationMinutes,
+		SlotCapacity:        req.SlotCapacity,
+		Stock:               req.Stock,
+		LowStockThreshold:   req.LowStockThreshold,
 	}
 
 	serviceImages := make([]*model.ServiceImage, 0, len(req.Images))
@@ -200,7 +286,13 @@ func (s *serviceSvcImpl) CreateService(ctx context.Context, userID int64, req ty
 
 	service.ServiceImages = serviceImages
 
-	if err = s.serviceRepo.CreateService(ctx, service); err != nil {
+	if err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.serviceRepo.CreateServiceTx(tx, service); err != nil {
+			return err
+		}
+
+		return s.confirmServiceImageUploadsTx(tx, serviceImages)
+	}); err != nil {
 		if ok, _ := common.IsUniqueViolation(err); ok {
 			return 0, common.ErrServiceAlreadyExists
 		}
@@ -211,6 +303,8 @@ func (s *serviceSvcImpl) CreateService(ctx context.Context, userID int64, req ty
 		return 0, err
 	}
 
+	s.publishProcessImages(serviceImages)
+
 	return serviceID, nil
 }
 
@@ -268,6 +362,50 @@ func (s *serviceSvcImpl) UpdateService(ctx context.Context, serviceID, userID in
 		return common.ErrServiceNotFound
 	}
 
+	slotDuration := service.SlotDurationMinutes
+	if req.SlotDurationMinutes != nil {
+		slotDuration = req.SlotDurationMinutes
+		if *slotDuration == 0 {
+			slotDuration = nil
+		}
+	}
+	slotCapacity := service.SlotCapacity
+	if req.SlotCapacity != nil {
+		slotCapacity = req.SlotCapacity
+		if *slotCapacity == 0 {
+			slotCapacity = nil
+		}
+	}
+	if (slotDuration == nil) != (slotCapacity == nil) {
+		return common.ErrInvalidSlotSettings
+	}
+
+	for _, img := range req.UpdateImages {
+		if img.Key != nil && !common.IsUploadKeyFor(*img.Key, common.UploadPurposeServiceImage) {
+			return common.ErrInvalidUploadKey
+		}
+	}
+	for _, reqImg := range req.NewImages {
+		if !common.IsUploadKeyFor(reqImg.Key, common.UploadPurposeServiceImage) {
+			return common.ErrInvalidUploadKey
+		}
+	}
+
+	var openingHours []*model.ServiceOpeningHour
+	if req.OpeningHours != nil {
+		if openingHours, err = s.buildOpeningHours(*req.OpeningHours, nil, &serviceID); err != nil {
+			return err
+		}
+	}
+
+	var optionGroups []*model.ServiceOptionGroup
+	if req.OptionGroups != nil {
+		if optionGroups, err = s.buildOptionGroups(*req.OptionGroups, serviceID); err != nil {
+			return err
+		}
+	}
+
+	var processImages []*model.ServiceImage
 	if err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		updateData := map[string]any{}
 
@@ -287,6 +425,39 @@ func (s *serviceSvcImpl) UpdateService(ctx context.Context, serviceID, userID in
 		if req.ServiceTypeID != nil && *req.ServiceTypeID != service.ServiceTypeID {
 			updateData["service_type_id"] = *req.ServiceTypeID
 		}
+		if req.SlotDurationMinutes != nil {
+			updateData["slot_duration_minutes"] = slotDuration
+		}
+		if req.SlotCapacity != nil {
+			updateData["slot_capacity"] = slotCapacity
+		}
+		if req.TrackStock != nil && !*req.TrackStock {
+			updateData["stock"] = nil
+		} else if req.Stock != nil {
+			updateData["stock"] = *req.Stock
+		}
+		if req.LowStockThreshold != nil {
+			if *req.LowStockThreshold == 0 {
+				updateData["low_stock_threshold"] = nil
+			} else {
+				updateData["low_stock_threshold"] = *req.LowStockThreshold
+			}
+		}
+
+		durationChanged := !equalUint32Ptr(slotDuration, service.SlotDurationMinutes)
+		if durationChanged || !equalUint32Ptr(slotCapacity, service.SlotCapacity) {
+			reserved, err := s.serviceRepo.FindMaxUpcomingSlotReservedTx(tx, serviceID, time.Now())
+			if err != nil {
+				s.logger.Error("find upcoming slot reservations failed", zap.Int64("id", serviceID), zap.Error(err))
+				return err
+			}
+			// Booked slots keep their start times, so the slot grid can only
+			// move while nothing upcoming is booked, and the capacity cannot
+			// drop below what a slot already holds.
+			if reserved > 0 && (durationChanged || slotCapacity == nil || *slotCapacity < reserved) {
+				return common.ErrSlotsReserved
+			}
+		}
 
 		if len(updateData) > 0 {
 			updateData["updated_by_id"] = userID
@@ -302,6 +473,20 @@ func (s *serviceSvcImpl) UpdateService(ctx context.Context, serviceID, userID in
 			}
 		}
 
+		if req.OpeningHours != nil {
+			if err = s.serviceRepo.ReplaceServiceOpeningHoursTx(tx, serviceID, openingHours); err != nil {
+				s.logger.Error("replace service opening hours failed", zap.Int64("id", serviceID), zap.Error(err))
+				return err
+			}
+		}
+
+		if req.OptionGroups != nil {
+			if err = s.serviceRepo.ReplaceServiceOptionGroupsTx(tx, serviceID, optionGroups); err != nil {
+				s.logger.Error("replace service option groups failed", zap.Int64("id", serviceID), zap.Error(err))
+				return err
+			}
+		}
+
 		if len(req.DeleteImages) > 0 {
 			images, err := s.serviceRepo.FindAllServiceImagesByIDTx(tx, req.DeleteImages)
 			if err != nil {
@@ -317,11 +502,10 @@ func (s *serviceSvcImpl) UpdateService(ctx context.Context, serviceID, userID in
 				return err
 			}
 
-			ch := make(chan string, len(images))
-			for _, img := range images {
-				if strings.TrimSpace(img.Key) != "" {
-					ch <- img.Key
-				}
+			keys := serviceImageFileKeys(images)
+			ch := make(chan string, len(keys))
+			for _, key := range keys {
+				ch <- key
 			}
 			close(ch)
 
@@ -357,6 +541,10 @@ func (s *serviceSvcImpl) UpdateService(ctx context.Context, serviceID, userID in
 				}
 				if img.Key != nil {
 					updateData["key"] = *img.Key
+					updateData["status"] = common.ServiceImageStatusPending
+					updateData["width"] = nil
+					updateData["height"] = nil
+					processImages = append(processImages, &model.ServiceImage{ID: img.ID, Key: *img.Key})
 				}
 				if img.SortOrder != nil {
 					updateData["sort_order"] = *img.SortOrder
@@ -394,11 +582,244 @@ func (s *serviceSvcImpl) UpdateService(ctx context.Context, serviceID, userID in
 				s.logger.Error("create service images failed", zap.Error(err))
 				return err
 			}
+			processImages = append(processImages, images...)
 		}
 
-		return nil
+		return s.confirmServiceImageUploadsTx(tx, processImages)
 	}); err != nil {
-		return nil
+		return err
+	}
+
+	s.publishProcessImages(processImages)
+
+	return nil
+}
+
+func (s *serviceSvcImpl) AdjustServiceStock(ctx context.Context, serviceID, userID int64, req types.AdjustServiceStockRequest) error {
+	service, err := s.serviceRepo.FindServiceByIDWithServiceImages(ctx, serviceID)
+	if err != nil {
+		s.logger.Error("find service by id failed", zap.Int64("id", serviceID), zap.Error(err))
+		return err
+	}
+	if service == nil {
+		return common.ErrServiceNotFound
+	}
+
+	adjusted, err := s.serviceRepo.AdjustServiceStock(ctx, serviceID, userID, req.Delta)
+	if err != nil {
+		s.logger.Error("adjust service stock failed", zap.Int64("id", serviceID), zap.Error(err))
+		return err
+	}
+	if !adjusted {
+		return common.ErrInvalidStockAdjustment
+	}
+
+	return nil
+}
+
+func (s *serviceSvcImpl) UpsertServiceTypeTranslation(ctx context.Context, serviceTypeID int64, locale string, req types.NameTranslationRequest) error {
+	locale, ok := common.NormalizeLocale(locale)
+	if !ok {
+		return common.ErrInvalidLocale
+	}
+
+	id, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate service type translation id failed", zap.Error(err))
+		return err
+	}
+
+	translation := &model.ServiceTypeTranslation{
+		ID:            id,
+		ServiceTypeID: serviceTypeID,
+		Locale:        locale,
+		Name:          req.Name,
+	}
+
+	if err = s.serviceRepo.UpsertServiceTypeTranslation(ctx, translation); err != nil {
+		if common.IsForeignKeyViolation(err) {
+			return common.ErrServiceTypeNotFound
+		}
+		s.logger.Error("upsert service type translation failed", zap.Int64("id", serviceTypeID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *serviceSvcImpl) DeleteServiceTypeTranslation(ctx context.Context, serviceTypeID int64, locale string) error {
+	locale, ok := common.NormalizeLocale(locale)
+	if !ok {
+		return common.ErrInvalidLocale
+	}
+
+	if err := s.serviceRepo.DeleteServiceTypeTranslation(ctx, serviceTypeID, locale); err != nil {
+		if errors.Is(err, common.ErrTranslationNotFound) {
+			return err
+		}
+		s.logger.Error("delete service type translation failed", zap.Int64("id", serviceTypeID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *serviceSvcImpl) UpsertServiceTranslation(ctx context.Context, serviceID int64, locale string, req types.ServiceTranslationRequest) error {
+	locale, ok := common.NormalizeLocale(locale)
+	if !ok {
+		return common.ErrInvalidLocale
+	}
+
+	id, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate service translation id failed", zap.Error(err))
+		return err
+	}
+
+	translation := &model.ServiceTranslation{
+		ID:          id,
+		ServiceID:   serviceID,
+		Locale:      locale,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err = s.serviceRepo.UpsertServiceTranslation(ctx, translation); err != nil {
+		if common.IsForeignKeyViolation(err) {
+			return common.ErrServiceNotFound
+		}
+		s.logger.Error("upsert service translation failed", zap.Int64("id", serviceID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *serviceSvcImpl) DeleteServiceTranslation(ctx context.Context, serviceID int64, locale string) error {
+	locale, ok := common.NormalizeLocale(locale)
+	if !ok {
+		return common.ErrInvalidLocale
+	}
+
+	if err := s.serviceRepo.DeleteServiceTranslation(ctx, serviceID, locale); err != nil {
+		if errors.Is(err, common.ErrTranslationNotFound) {
+			return err
+		}
+		s.logger.Error("delete service translation failed", zap.Int64("id", serviceID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *serviceSvcImpl) CreatePricingRule(ctx context.Context, userID int64, req types.PricingRuleRequest) (int64, error) {
+	if !isValidPricingRuleRequest(req) {
+		return 0, common.ErrInvalidPricingRule
+	}
+
+	ruleID, err := s.sfGen.NextID()
+	if err != nil {
+		s.logger.Error("generate pricing rule id failed", zap.Error(err))
+		return 0, err
+	}
+
+	dayType := req.DayType
+	if dayType == "" {
+		dayType = "all"
+	}
+
+	rule := &model.PricingRule{
+		ID:            ruleID,
+		Name:          req.Name,
+		ServiceTypeID: req.ServiceTypeID,
+		ServiceID:     req.ServiceID,
+		DayType:       dayType,
+		StartTime:     req.StartTime,
+		EndTime:       req.EndTime,
+		StartsAt:      req.StartsAt,
+		EndsAt:        req.EndsAt,
+		PromoCode:     normalizePromoCode(req.PromoCode),
+		DiscountType:  req.DiscountType,
+		DiscountValue: req.DiscountValue,
+		IsActive:      *req.IsActive,
+		CreatedByID:   userID,
+		UpdatedByID:   userID,
+	}
+
+	if err = s.serviceRepo.CreatePricingRule(ctx, rule); err != nil {
+		if ok, _ := common.IsUniqueViolation(err); ok {
+			return 0, common.ErrPromoCodeAlreadyExists
+		}
+		if common.IsForeignKeyViolation(err) {
+			return 0, common.ErrServiceNotFound
+		}
+		s.logger.Error("create pricing rule failed", zap.Error(err))
+		return 0, err
+	}
+
+	return ruleID, nil
+}
+
+func (s *serviceSvcImpl) GetPricingRules(ctx context.Context) ([]*model.PricingRule, error) {
+	rules, err := s.serviceRepo.FindAllPricingRulesWithDetails(ctx)
+	if err != nil {
+		s.logger.Error("find all pricing rules failed", zap.Error(err))
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (s *serviceSvcImpl) UpdatePricingRule(ctx context.Context, ruleID, userID int64, req types.PricingRuleRequest) error {
+	if !isValidPricingRuleRequest(req) {
+		return common.ErrInvalidPricingRule
+	}
+
+	dayType := req.DayType
+	if dayType == "" {
+		dayType = "all"
+	}
+
+	updateData := map[string]any{
+		"name":            req.Name,
+		"service_type_id": req.ServiceTypeID,
+		"service_id":      req.ServiceID,
+		"day_type":        dayType,
+		"start_time":      req.StartTime,
+		"end_time":        req.EndTime,
+		"starts_at":       req.StartsAt,
+		"ends_at":         req.EndsAt,
+		"promo_code":      normalizePromoCode(req.PromoCode),
+		"discount_type":   req.DiscountType,
+		"discount_value":  req.DiscountValue,
+		"is_active":       *req.IsActive,
+		"updated_by_id":   userID,
+	}
+
+	if err := s.serviceRepo.UpdatePricingRule(ctx, ruleID, updateData); err != nil {
+		if errors.Is(err, common.ErrPricingRuleNotFound) {
+			return err
+		}
+		if ok, _ := common.IsUniqueViolation(err); ok {
+			return common.ErrPromoCodeAlreadyExists
+		}
+		if common.IsForeignKeyViolation(err) {
+			return common.ErrServiceNotFound
+		}
+		s.logger.Error("update pricing rule failed", zap.Int64("id", ruleID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *serviceSvcImpl) DeletePricingRule(ctx context.Context, ruleID int64) error {
+	if err := s.serviceRepo.DeletePricingRule(ctx, ruleID); err != nil {
+		if errors.Is(err, common.ErrPricingRuleNotFound) {
+			return err
+		}
+		s.logger.Error("delete pricing rule failed", zap.Int64("id", ruleID), zap.Error(err))
+		return err
 	}
 
 	return nil
@@ -426,11 +847,10 @@ func (s *serviceSvcImpl) DeleteService(ctx context.Context, serviceID int64) err
 	}
 
 	if len(service.ServiceImages) > 0 {
-		ch := make(chan string, len(service.ServiceImages))
-		for _, img := range service.ServiceImages {
-			if strings.TrimSpace(img.Key) != "" {
-				ch <- img.Key
-			}
+		keys := serviceImageFileKeys(service.ServiceImages)
+		ch := make(chan string, len(keys))
+		for _, key := range keys {
+			ch <- key
 		}
 		close(ch)
 
@@ -447,7 +867,7 @@ func (s *serviceSvcImpl) DeleteService(ctx context.Context, serviceID int64) err
 	return nil
 }
 
-func (s *serviceSvcImpl) GetServiceTypeBySlugWithServices(ctx context.Context, serviceTypeSlug string) (*model.ServiceType, error) {
+func (s *serviceSvcImpl) GetServiceTypeBySlugWithServices(ctx context.Context, serviceTypeSlug string, locales []string) (*model.ServiceType, error) {
 	serviceType, err := s.serviceRepo.FindServiceTypeBySlugWithActiveServiceDetails(ctx, serviceTypeSlug)
 	if err != nil {
 		s.logger.Error("find service type by slug failed", zap.String("slug", serviceTypeSlug), zap.Error(err))
@@ -457,10 +877,15 @@ func (s *serviceSvcImpl) GetServiceTypeBySlugWithServices(ctx context.Context, s
 		return nil, common.ErrServiceTypeNotFound
 	}
 
+	localizeServiceType(serviceType, locales)
+	for _, service := range serviceType.Services {
+		localizeService(service, locales)
+	}
+
 	return serviceType, nil
 }
 
-func (s *serviceSvcImpl) GetServiceBySlug(ctx context.Context, serviceSlug string) (*model.Service, error) {
+func (s *serviceSvcImpl) GetServiceBySlug(ctx context.Context, serviceSlug string, locales []string) (*model.Service, error) {
 	service, err := s.serviceRepo.FindServiceBySlugWithServiceTypeAndServiceImages(ctx, serviceSlug)
 	if err != nil {
 		s.logger.Error("find service by slug failed", zap.String("slug", serviceSlug), zap.Error(err))
@@ -470,5 +895,1116 @@ func (s *serviceSvcImpl) GetServiceBySlug(ctx context.Context, serviceSlug strin
 		return nil, common.ErrServiceNotFound
 	}
 
+	localizeService(service, locales)
+	localizeServiceType(service.ServiceType, locales)
+	service.OpeningHours = effectiveOpeningHours(service)
+
+	if service.SlotDurationMinutes != nil && service.SlotCapacity != nil {
+		loc := availabilityLocation(s.cfg)
+		now := time.Now()
+		until := now.AddDate(0, 0, max(s.cfg.Availability.SlotDays, 1))
+
+		reserved, err := s.serviceRepo.FindAllServiceSlotsByServiceIDBetween(ctx, service.ID, now, until)
+		if err != nil {
+			s.logger.Error("find all service slots failed", zap.Int64("id", service.ID), zap.Error(err))
+			return nil, err
+		}
+
+		reservedByStart := make(map[int64]uint32, len(reserved))
+		for _, slot := range reserved {
+			reservedByStart[slot.StartAt.Unix()] = slot.Reserved
+		}
+
+		duration := time.Duration(*service.SlotDurationMinutes) * time.Minute
+		for _, startAt := range serviceSlotStarts(service.OpeningHours, duration, now, until, loc) {
+			service.AvailableSlots = append(service.AvailableSlots, &model.ServiceSlot{
+				ServiceID: service.ID,
+				StartAt:   startAt,
+				Reserved:  reservedByStart[startAt.Unix()],
+			})
+		}
+	}
+
 	return service, nil
 }
+
+func (s *serviceSvcImpl) buildOptionGroups(reqs []types.ServiceOptionGroupRequest, serviceID int64) ([]*model.ServiceOptionGroup, error) {
+	groups := make([]*model.ServiceOptionGroup, 0, len(reqs))
+	for _, req := range reqs {
+		groupID, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate service option group id failed", zap.Error(err))
+			return nil, err
+		}
+
+		options := make([]*model.ServiceOption, 0, len(req.Options))
+		for _, reqOption := range req.Options {
+			optionID, err := s.sfGen.NextID()
+			if err != nil {
+				s.logger.Error("generate service option id failed", zap.Error(err))
+				return nil, err
+			}
+
+			options = append(options, &model.ServiceOption{
+				ID:         optionID,
+				GroupID:    groupID,
+				Name:       reqOption.Name,
+				PriceDelta: reqOption.PriceDelta,
+				SortOrder:  reqOption.SortOrder,
+			})
+		}
+
+		groups = append(groups, &model.ServiceOptionGroup{
+			ID:            groupID,
+			ServiceID:     serviceID,
+			Name:          req.Name,
+			SelectionType: req.SelectionType,
+			IsRequired:    req.IsRequired,
+			SortOrder:     req.SortOrder,
+			Options:       options,
+		})
+	}
+
+	return groups, nil
+}
+
+func (s *serviceSvcImpl) buildOpeningHours(reqs []types.OpeningHourRequest, serviceTypeID, serviceID *int64) ([]*model.ServiceOpeningHour, error) {
+	hours := make([]*model.ServiceOpeningHour, 0, len(reqs))
+	for _, req := range reqs {
+		id, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate opening hour id failed", zap.Error(err))
+			return nil, err
+		}
+
+		hours = append(hours, &model.ServiceOpeningHour{
+			ID:            id,
+			ServiceTypeID: serviceTypeID,
+			ServiceID:     serviceID,
+			DayOfWeek:     *req.DayOfWeek,
+			OpenTime:      req.OpenTime,
+			CloseTime:     req.CloseTime,
+		})
+	}
+
+	return hours, nil
+}
+
+func availabilityLocation(cfg *config.Config) *time.Location {
+	loc, err := time.LoadLocation(cfg.Availability.Timezone)
+	if err != nil {
+		return time.Local
+	}
+
+	return loc
+}
+
+type openingWindow struct {
+	start time.Time
+	end   time.Time
+}
+
+// effectiveOpeningHours returns the service's own opening hours, falling back
+// to its service type. An empty result means the service is always open.
+func effectiveOpeningHours(service *model.Service) []*model.ServiceOpeningHour {
+	if len(service.OpeningHours) > 0 || service.ServiceType == nil {
+		return service.OpeningHours
+	}
+
+	return service.ServiceType.OpeningHours
+}
+
+// openingWindows expands weekly opening hours into concrete windows for every
+// local day touching [from, to]. A close time at or before the open time runs
+// past midnight.
+func openingWindows(hours []*model.ServiceOpeningHour, from, to time.Time, loc *time.Location) []openingWindow {
+	if len(hours) == 0 {
+		hours = make([]*model.ServiceOpeningHour, 0, 7)
+		for day := range uint8(7) {
+			hours = append(hours, &model.ServiceOpeningHour{DayOfWeek: day, OpenTime: "00:00", CloseTime: "00:00"})
+		}
+	}
+
+	local := from.In(loc).AddDate(0, 0, -1)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	var windows []openingWindow
+	for !day.After(to) {
+		for _, hour := range hours {
+			if time.Weekday(hour.DayOfWeek) != day.Weekday() {
+				continue
+			}
+
+			openAt, err := time.Parse(common.OpeningHourLayout, hour.OpenTime)
+			if err != nil {
+				continue
+			}
+			closeAt, err := time.Parse(common.OpeningHourLayout, hour.CloseTime)
+			if err != nil {
+				continue
+			}
+
+			start := time.Date(day.Year(), day.Month(), day.Day(), openAt.Hour(), openAt.Minute(), 0, 0, loc)
+			end := time.Date(day.Year(), day.Month(), day.Day(), closeAt.Hour(), closeAt.Minute(), 0, 0, loc)
+			if !end.After(start) {
+				end = end.AddDate(0, 0, 1)
+			}
+
+			windows = append(windows, openingWindow{start, end})
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return windows
+}
+
+func isWithinOpeningHours(hours []*model.ServiceOpeningHour, start, end time.Time, loc *time.Location) bool {
+	for _, window := range openingWindows(hours, start, end, loc) {
+		if !start.Before(window.start) && !end.After(window.end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isServiceSlotStart(hours []*model.ServiceOpeningHour, duration time.Duration, startAt time.Time, loc *time.Location) bool {
+	for _, window := range openingWindows(hours, startAt, startAt, loc) {
+		if startAt.Before(window.start) || startAt.Add(duration).After(window.end) {
+			continue
+		}
+		if startAt.Sub(window.start)%duration == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func serviceSlotStarts(hours []*model.ServiceOpeningHour, duration time.Duration, from, to time.Time, loc *time.Location) []time.Time {
+	var starts []time.Time
+	for _, window := range openingWindows(hours, from, to, loc) {
+		for startAt := window.start; !startAt.Add(duration).After(window.end); startAt = startAt.Add(duration) {
+			if startAt.After(from) && startAt.Before(to) && !slices.ContainsFunc(starts, startAt.Equal) {
+				starts = append(starts, startAt)
+			}
+		}
+	}
+
+	slices.SortFunc(starts, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+
+	return starts
+}
+
+func isValidPricingRuleRequest(req types.PricingRuleRequest) bool {
+	if req.ServiceTypeID != nil && req.ServiceID != nil {
+		return false
+	}
+	if (req.StartTime == nil) != (req.EndTime == nil) || (req.StartTime != nil && *req.StartTime == *req.EndTime) {
+		return false
+	}
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return false
+	}
+
+	return req.DiscountType != "percentage" || req.DiscountValue <= 100
+}
+
+func normalizePromoCode(code *string) *string {
+	if code == nil {
+		return nil
+	}
+
+	normalized := strings.ToUpper(strings.TrimSpace(*code))
+	if normalized == "" {
+		return nil
+	}
+
+	return &normalized
+}
+
+// findTranslation returns the translation for the first preferred locale that
+// has one. Callers keep the original text when nothing matches.
+func findTranslation[T any](translations []T, locales []string, localeOf func(T) string) (T, bool) {
+	for _, locale := range locales {
+		for _, translation := range translations {
+			if localeOf(translation) == locale {
+				return translation, true
+			}
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+func localizeServiceType(serviceType *model.ServiceType, locales []string) {
+	if serviceType == nil {
+		return
+	}
+
+	if translation, ok := findTranslation(serviceType.Translations, locales, func(t *model.ServiceTypeTranslation) string { return t.Locale }); ok {
+		serviceType.Name = translation.Name
+	}
+}
+
+func localizeService(service *model.Service, locales []string) {
+	translation, ok := findTranslation(service.Translations, locales, func(t *model.ServiceTranslation) string { return t.Locale })
+	if !ok {
+		return
+	}
+
+	service.Name = translation.Name
+	if translation.Description != nil {
+		service.Description = *translation.Description
+	}
+}
+
+type catalogueServiceTypePlan struct {
+	row          int
+	slug         string
+	name         string
+	departmentID int64
+	existing     *model.ServiceType
+	updateData   map[string]any
+	id           int64
+}
+
+type catalogueServicePlan struct {
+	row           int
+	slug          string
+	name          string
+	price         *float64
+	isActive      *bool
+	description   *string
+	imageKeys     []string
+	serviceType   *catalogueServiceTypePlan
+	existing      *model.Service
+	updateData    map[string]any
+	moveType      bool
+	replaceImages bool
+}
+
+func (s *serviceSvcImpl) ImportServiceCatalogue(ctx context.Context, userID int64, fileName string, data []byte, dryRun bool) (*types.CatalogueImportReport, error) {
+	rows, err := readCatalogueRows(fileName, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows)-1 > common.MaxCatalogueRows {
+		return nil, common.ErrTooManyCatalogueRows
+	}
+
+	header := make(map[string]int, len(rows[0]))
+	for i, col := range rows[0] {
+		header[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+	_, hasTypeSlug := header["service_type_slug"]
+	_, hasTypeName := header["service_type_name"]
+	if !hasTypeSlug && !hasTypeName {
+		return nil, common.ErrInvalidCatalogueFile
+	}
+
+	serviceTypes, err := s.serviceRepo.FindAllServiceTypesWithDetails(ctx)
+	if err != nil {
+		s.logger.Error("find all service types failed", zap.Error(err))
+		return nil, err
+	}
+	services, err := s.serviceRepo.FindAllServicesWithServiceImages(ctx)
+	if err != nil {
+		s.logger.Error("find all services failed", zap.Error(err))
+		return nil, err
+	}
+	departments, err := s.departmentRepo.FindAll(ctx)
+	if err != nil {
+		s.logger.Error("find all departments failed", zap.Error(err))
+		return nil, err
+	}
+
+	serviceTypeBySlug := make(map[string]*model.ServiceType, len(serviceTypes))
+	for _, st := range serviceTypes {
+		serviceTypeBySlug[st.Slug] = st
+	}
+	serviceBySlug := make(map[string]*model.Service, len(services))
+	imageOwner := make(map[string]*model.Service)
+	for _, sv := range services {
+		serviceBySlug[sv.Slug] = sv
+		for _, img := range sv.ServiceImages {
+			imageOwner[img.Key] = sv
+		}
+	}
+	departmentByName := make(map[string]int64, len(departments))
+	for _, d := range departments {
+		departmentByName[d.Name] = d.ID
+	}
+
+	report := &types.CatalogueImportReport{
+		DryRun: dryRun,
+		Errors: make([]*types.CatalogueRowError, 0),
+	}
+	addError := func(row int, field, message string) {
+		report.Errors = append(report.Errors, &types.CatalogueRowError{Row: row, Field: field, Message: message})
+	}
+
+	typePlans := make([]*catalogueServiceTypePlan, 0)
+	typePlanBySlug := make(map[string]*catalogueServiceTypePlan)
+	servicePlans := make([]*catalogueServicePlan, 0)
+	serviceRowBySlug := make(map[string]int)
+	imageRowByKey := make(map[string]int)
+
+	for i, cells := range rows[1:] {
+		rowNum := i + 2
+		get := func(col string) string {
+			idx, ok := header[col]
+			if !ok || idx >= len(cells) {
+				return ""
+			}
+			return strings.TrimSpace(cells[idx])
+		}
+
+		isBlank := true
+		for _, col := range common.CatalogueColumns {
+			if get(col) != "" {
+				isBlank = false
+				break
+			}
+		}
+		if isBlank {
+			continue
+		}
+		report.TotalRows++
+
+		typeSlug := common.GenerateSlug(cmp.Or(get("service_type_slug"), get("service_type_name")))
+		if typeSlug == "" {
+			addError(rowNum, "service_type_slug", "service type slug or name is required")
+			continue
+		}
+
+		tp, ok := typePlanBySlug[typeSlug]
+		if !ok {
+			tp = &catalogueServiceTypePlan{row: rowNum, slug: typeSlug, existing: serviceTypeBySlug[typeSlug]}
+			typePlanBySlug[typeSlug] = tp
+			typePlans = append(typePlans, tp)
+		}
+
+		if name := get("service_type_name"); name != "" {
+			switch {
+			case len([]rune(name)) < 2 || len([]rune(name)) > 150:
+				addError(rowNum, "service_type_name", "must be between 2 and 150 characters")
+			case tp.name == "":
+				tp.name = name
+			case tp.name != name:
+				addError(rowNum, "service_type_name", fmt.Sprintf("conflicts with row %d", tp.row))
+			}
+		}
+		if department := get("department"); department != "" {
+			departmentID, ok := departmentByName[department]
+			switch {
+			case !ok:
+				addError(rowNum, "department", "department not found")
+			case tp.departmentID == 0:
+				tp.departmentID = departmentID
+			case tp.departmentID != departmentID:
+				addError(rowNum, "department", fmt.Sprintf("conflicts with row %d", tp.row))
+			}
+		}
+
+		serviceSlug := common.GenerateSlug(cmp.Or(get("service_slug"), get("service_name")))
+		if serviceSlug == "" {
+			for _, col := range []string{"price", "is_active", "description", "image_keys"} {
+				if get(col) != "" {
+					addError(rowNum, "service_slug", "service slug or name is required")
+					break
+				}
+			}
+			continue
+		}
+		if prevRow, ok := serviceRowBySlug[serviceSlug]; ok {
+			addError(rowNum, "service_slug", fmt.Sprintf("duplicate of row %d", prevRow))
+			continue
+		}
+		serviceRowBySlug[serviceSlug] = rowNum
+
+		sp := &catalogueServicePlan{
+			row:         rowNum,
+			slug:        serviceSlug,
+			serviceType: tp,
+			existing:    serviceBySlug[serviceSlug],
+		}
+
+		if name := get("service_name"); name != "" {
+			if len([]rune(name)) < 2 || len([]rune(name)) > 150 {
+				addError(rowNum, "service_name", "must be between 2 and 150 characters")
+			} else {
+				sp.name = name
+			}
+		}
+		if priceStr := get("price"); priceStr != "" {
+			price, err := strconv.ParseFloat(priceStr, 64)
+			if err != nil || math.IsNaN(price) || price <= 0 || price >= 1e8 {
+				addError(rowNum, "price", "must be a number greater than 0 and less than 100000000")
+			} else {
+				price = math.Round(price*100) / 100
+				sp.price = &price
+			}
+		}
+		if activeStr := get("is_active"); activeStr != "" {
+			isActive, ok := parseCatalogueBool(activeStr)
+			if !ok {
+				addError(rowNum, "is_active", "must be true or false")
+			} else {
+				sp.isActive = &isActive
+			}
+		}
+		if description := get("description"); description != "" {
+			sp.description = &description
+		}
+		if keysStr := get("image_keys"); keysStr != "" {
+			for key := range strings.SplitSeq(keysStr, common.CatalogueImageKeySeparator) {
+				key = strings.TrimSpace(key)
+				if key == "" {
+					continue
+				}
+				if len(key) < 2 || len(key) > 150 {
+					addError(rowNum, "image_keys", fmt.Sprintf("key %q must be between 2 and 150 characters", key))
+					continue
+				}
+				if prevRow, ok := imageRowByKey[key]; ok {
+					addError(rowNum, "image_keys", fmt.Sprintf("key %q is already used on row %d", key, prevRow))
+					continue
+				}
+				owner, owned := imageOwner[key]
+				if owned && (sp.existing == nil || owner.ID != sp.existing.ID) {
+					addError(rowNum, "image_keys", fmt.Sprintf("key %q belongs to service %q", key, owner.Slug))
+					continue
+				}
+				// Keys the service already has may predate upload prefixes and
+				// are kept; anything new must be a service image upload.
+				if !owned && !common.IsUploadKeyFor(key, common.UploadPurposeServiceImage) {
+					addError(rowNum, "image_keys", fmt.Sprintf("key %q was not uploaded as a service image", key))
+					continue
+				}
+				imageRowByKey[key] = rowNum
+				sp.imageKeys = append(sp.imageKeys, key)
+			}
+		}
+
+		if sp.existing == nil {
+			if sp.name == "" {
+				addError(rowNum, "service_name", "is required for a new service")
+			}
+			if sp.price == nil {
+				addError(rowNum, "price", "is required for a new service")
+			}
+			if sp.description == nil {
+				addError(rowNum, "description", "is required for a new service")
+			}
+			if len(sp.imageKeys) == 0 {
+				addError(rowNum, "image_keys", "at least one image key is required for a new service")
+			}
+		}
+
+		servicePlans = append(servicePlans, sp)
+	}
+
+	for _, tp := range typePlans {
+		if tp.existing == nil {
+			if tp.name == "" {
+				addError(tp.row, "service_type_name", "is required for a new service type")
+			}
+			if tp.departmentID == 0 {
+				addError(tp.row, "department", "is required for a new service type")
+			}
+			report.ServiceTypesCreated++
+			continue
+		}
+
+		tp.id = tp.existing.ID
+		tp.updateData = map[string]any{}
+		if tp.name != "" && tp.name != tp.existing.Name {
+			tp.updateData["name"] = tp.name
+		}
+		if tp.departmentID != 0 && tp.departmentID != tp.existing.DepartmentID {
+			tp.updateData["department_id"] = tp.departmentID
+		}
+		if len(tp.updateData) > 0 {
+			report.ServiceTypesUpdated++
+		}
+	}
+
+	for _, sp := range servicePlans {
+		if sp.existing == nil {
+			report.ServicesCreated++
+			continue
+		}
+
+		sp.updateData = map[string]any{}
+		if sp.name != "" && sp.name != sp.existing.Name {
+			sp.updateData["name"] = sp.name
+		}
+		if sp.price != nil && *sp.price != sp.existing.Price {
+			sp.updateData["price"] = *sp.price
+		}
+		if sp.isActive != nil && *sp.isActive != sp.existing.IsActive {
+			sp.updateData["is_active"] = *sp.isActive
+		}
+		if sp.description != nil && *sp.description != sp.existing.Description {
+			sp.updateData["description"] = *sp.description
+		}
+		sp.moveType = sp.serviceType.existing == nil || sp.serviceType.existing.ID != sp.existing.ServiceTypeID
+		if len(sp.imageKeys) > 0 {
+			currentKeys := make([]string, 0, len(sp.existing.ServiceImages))
+			for _, img := range sp.existing.ServiceImages {
+				currentKeys = append(currentKeys, img.Key)
+			}
+			sp.replaceImages = !slices.Equal(currentKeys, sp.imageKeys)
+		}
+		if len(sp.updateData) > 0 || sp.moveType || sp.replaceImages {
+			report.ServicesUpdated++
+		}
+	}
+
+	slices.SortStableFunc(report.Errors, func(a, b *types.CatalogueRowError) int {
+		return cmp.Compare(a.Row, b.Row)
+	})
+
+	if len(report.Errors) > 0 || dryRun {
+		return report, nil
+	}
+
+	var removedKeys []string
+	var processImages []*model.ServiceImage
+	if err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, tp := range typePlans {
+			if tp.existing != nil {
+				if len(tp.updateData) == 0 {
+					continue
+				}
+				tp.updateData["updated_by_id"] = userID
+				if err := s.serviceRepo.UpdateServiceTypeTx(tx, tp.id, tp.updateData); err != nil {
+					s.logger.Error("update service type failed", zap.Int64("id", tp.id), zap.Error(err))
+					return err
+				}
+				continue
+			}
+
+			id, err := s.sfGen.NextID()
+			if err != nil {
+				s.logger.Error("generate service type id failed", zap.Error(err))
+				return err
+			}
+			tp.id = id
+
+			if err = s.serviceRepo.CreateServiceTypeTx(tx, &model.ServiceType{
+				ID:           id,
+				Name:         tp.name,
+				Slug:         tp.slug,
+				DepartmentID: tp.departmentID,
+				CreatedByID:  userID,
+				UpdatedByID:  userID,
+			}); err != nil {
+				if ok, _ := common.IsUniqueViolation(err); ok {
+					return common.ErrServiceTypeAlreadyExists
+				}
+				s.logger.Error("create service type failed", zap.Error(err))
+				return err
+			}
+		}
+
+		for _, sp := range servicePlans {
+			if sp.existing == nil {
+				serviceID, err := s.sfGen.NextID()
+				if err != nil {
+					s.logger.Error("generate service id failed", zap.Error(err))
+					return err
+				}
+
+				images, err := s.buildCatalogueImages(serviceID, sp.imageKeys)
+				if err != nil {
+					return err
+				}
+
+				if err = s.serviceRepo.CreateServiceTx(tx, &model.Service{
+					ID:            serviceID,
+					Name:          sp.name,
+					Slug:          sp.slug,
+					Price:         *sp.price,
+					IsActive:      sp.isActive == nil || *sp.isActive,
+					Description:   *sp.description,
+					CreatedByID:   userID,
+					UpdatedByID:   userID,
+					ServiceTypeID: sp.serviceType.id,
+					ServiceImages: images,
+				}); err != nil {
+					if ok, _ := common.IsUniqueViolation(err); ok {
+						return common.ErrServiceAlreadyExists
+					}
+					s.logger.Error("create service failed", zap.Error(err))
+					return err
+				}
+				processImages = append(processImages, images...)
+				continue
+			}
+
+			serviceID := sp.existing.ID
+			if sp.moveType {
+				sp.updateData["service_type_id"] = sp.serviceType.id
+			}
+			if len(sp.updateData) > 0 {
+				sp.updateData["updated_by_id"] = userID
+				if err := s.serviceRepo.UpdateServiceTx(tx, serviceID, sp.updateData); err != nil {
+					s.logger.Error("update service failed", zap.Int64("id", serviceID), zap.Error(err))
+					return err
+				}
+			}
+
+			if !sp.replaceImages {
+				continue
+			}
+
+			imageIDs := make([]int64, 0, len(sp.existing.ServiceImages))
+			for _, img := range sp.existing.ServiceImages {
+				imageIDs = append(imageIDs, img.ID)
+				if !slices.Contains(sp.imageKeys, img.Key) {
+					removedKeys = append(removedKeys, serviceImageFileKeys([]*model.ServiceImage{img})...)
+				}
+			}
+			if err := s.serviceRepo.DeleteAllServiceImagesByIDTx(tx, imageIDs); err != nil {
+				s.logger.Error("delete service images by id failed", zap.Error(err))
+				return err
+			}
+
+			images, err := s.buildCatalogueImages(serviceID, sp.imageKeys)
+			if err != nil {
+				return err
+			}
+			if err = s.serviceRepo.CreateServiceImagesTx(tx, images); err != nil {
+				s.logger.Error("create service images failed", zap.Error(err))
+				return err
+			}
+			processImages = append(processImages, images...)
+		}
+
+		return s.confirmServiceImageUploadsTx(tx, processImages)
+	}); err != nil {
+		return nil, err
+	}
+
+	s.publishProcessImages(processImages)
+	s.publishDeleteFiles(removedKeys)
+
+	return report, nil
+}
+
+func (s *serviceSvcImpl) ExportServiceCatalogue(ctx context.Context, format string) ([]byte, string, string, error) {
+	serviceTypes, err := s.serviceRepo.FindAllServiceTypesWithDetails(ctx)
+	if err != nil {
+		s.logger.Error("find all service types failed", zap.Error(err))
+		return nil, "", "", err
+	}
+	services, err := s.serviceRepo.FindAllServicesWithServiceImages(ctx)
+	if err != nil {
+		s.logger.Error("find all services failed", zap.Error(err))
+		return nil, "", "", err
+	}
+
+	servicesByType := make(map[int64][]*model.Service, len(serviceTypes))
+	for _, sv := range services {
+		servicesByType[sv.ServiceTypeID] = append(servicesByType[sv.ServiceTypeID], sv)
+	}
+
+	rows := [][]string{common.CatalogueColumns}
+	for _, st := range serviceTypes {
+		department := ""
+		if st.Department != nil {
+			department = st.Department.Name
+		}
+		typeCells := []string{st.Slug, st.Name, department}
+
+		if len(servicesByType[st.ID]) == 0 {
+			rows = append(rows, append(typeCells, "", "", "", "", "", ""))
+			continue
+		}
+
+		for _, sv := range servicesByType[st.ID] {
+			keys := make([]string, 0, len(sv.ServiceImages))
+			for _, img := range sv.ServiceImages {
+				keys = append(keys, img.Key)
+			}
+
+			rows = append(rows, append(slices.Clone(typeCells),
+				sv.Slug,
+				sv.Name,
+				strconv.FormatFloat(sv.Price, 'f', 2, 64),
+				strconv.FormatBool(sv.IsActive),
+				sv.Description,
+				strings.Join(keys, common.CatalogueImageKeySeparator),
+			))
+		}
+	}
+	for _, row := range rows[1:] {
+		for i, cell := range row {
+			row[i] = escapeCatalogueCell(cell)
+		}
+	}
+
+	fileName := "service-catalogue-" + time.Now().Format("20060102")
+	if format == "xlsx" {
+		data, err := xlsx.Write(common.CatalogueSheetName, rows)
+		if err != nil {
+			s.logger.Error("write catalogue xlsx failed", zap.Error(err))
+			return nil, "", "", err
+		}
+		return data, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", fileName + ".xlsx", nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	if err = w.WriteAll(rows); err != nil {
+		s.logger.Error("write catalogue csv failed", zap.Error(err))
+		return nil, "", "", err
+	}
+
+	return buf.Bytes(), "text/csv; charset=utf-8", fileName + ".csv", nil
+}
+
+func (s *serviceSvcImpl) buildCatalogueImages(serviceID int64, keys []string) ([]*model.ServiceImage, error) {
+	images := make([]*model.ServiceImage, 0, len(keys))
+	for i, key := range keys {
+		imageID, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate service image id failed", zap.Error(err))
+			return nil, err
+		}
+
+		images = append(images, &model.ServiceImage{
+			ID:          imageID,
+			ServiceID:   serviceID,
+			Key:         key,
+			IsThumbnail: i == 0,
+			SortOrder:   uint32(i + 1),
+		})
+	}
+
+	return images, nil
+}
+
+func equalUint32Ptr(a, b *uint32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func readCatalogueRows(fileName string, data []byte) ([][]string, error) {
+	var rows [][]string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+		r.FieldsPerRecord = -1
+		rows, err = r.ReadAll()
+	case ".xlsx":
+		rows, err = xlsx.Read(bytes.NewReader(data), int64(len(data)), xlsx.Limits{
+			MaxRows:     common.MaxCatalogueRows + 1,
+			MaxColumns:  len(common.CatalogueColumns),
+			MaxPartSize: common.MaxCatalogueXMLSize,
+		})
+		if errors.Is(err, xlsx.ErrTooManyRows) {
+			return nil, common.ErrTooManyCatalogueRows
+		}
+	default:
+		return nil, common.ErrUnsupportedCatalogueFormat
+	}
+	if err != nil || len(rows) == 0 {
+		return nil, common.ErrInvalidCatalogueFile
+	}
+
+	for _, row := range rows {
+		for i, cell := range row {
+			row[i] = unescapeCatalogueCell(cell)
+		}
+	}
+
+	return rows, nil
+}
+
+// escapeCatalogueCell prefixes cells that a spreadsheet would evaluate as a
+// formula with an apostrophe, so an exported name like "=HYPERLINK(...)"
+// stays text when the file is opened. Values that already look escaped get
+// a second apostrophe so the import gives them back unchanged.
+func escapeCatalogueCell(cell string) string {
+	if cell != "" && strings.ContainsRune(common.CatalogueFormulaPrefixes, rune(cell[0])) || unescapeCatalogueCell(cell) != cell {
+		return "'" + cell
+	}
+	return cell
+}
+
+// unescapeCatalogueCell undoes escapeCatalogueCell so a catalogue can be
+// exported, edited and imported again without the values changing.
+func unescapeCatalogueCell(cell string) string {
+	trimmed := strings.TrimLeft(cell, "'")
+	if len(trimmed) < len(cell) && trimmed != "" && strings.ContainsRune(common.CatalogueFormulaPrefixes, rune(trimmed[0])) {
+		return cell[1:]
+	}
+	return cell
+}
+
+func parseCatalogueBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "1", "yes", "y":
+		return true, true
+	case "false", "0", "no", "n":
+		return false, true
+	}
+	return false, false
+}
+
+// ProcessServiceImage turns a browser upload into something fit to serve:
+// it checks the object really is an image, rewrites it without EXIF and
+// stores resized JPEG and WebP variants next to it. Objects that are not
+// images are deleted and the image is marked as failed.
+func (s *serviceSvcImpl) ProcessServiceImage(ctx context.Context, serviceImageID int64) error {
+	image, err := s.serviceRepo.FindServiceImageByIDWithVariants(ctx, serviceImageID)
+	if err != nil {
+		s.logger.Error("find service image by id failed", zap.Int64("id", serviceImageID), zap.Error(err))
+		return err
+	}
+	if image == nil || image.Status == common.ServiceImageStatusReady {
+		return nil
+	}
+
+	r, err := s.storage.NewReader(ctx, image.Key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			s.logger.Warn("service image not found", zap.String("key", image.Key))
+			return s.markServiceImageFailed(ctx, image)
+		}
+		s.logger.Error("open service image failed", zap.String("key", image.Key), zap.Error(err))
+		return err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(io.LimitReader(r, common.MaxServiceImageSize+1))
+	if err != nil {
+		s.logger.Error("read service image failed", zap.String("key", image.Key), zap.Error(err))
+		return err
+	}
+	if len(data) > common.MaxServiceImageSize {
+		s.logger.Warn("service image too large", zap.String("key", image.Key))
+		return s.rejectServiceImage(ctx, image)
+	}
+
+	result, err := s.imgProcessor.Process(data, common.ServiceImageMaxDimension, common.ServiceImageVariantWidths)
+	if err != nil {
+		s.logger.Warn("service image rejected", zap.String("key", image.Key), zap.Error(err))
+		return s.rejectServiceImage(ctx, image)
+	}
+
+	if err = s.uploadServiceImageFile(ctx, image.Key, result.Format, result.Original); err != nil {
+		return err
+	}
+
+	base := strings.TrimSuffix(image.Key, path.Ext(image.Key))
+	variants := make([]*model.ServiceImageVariant, 0, len(result.Variants))
+	for _, v := range result.Variants {
+		key := fmt.Sprintf("%s%s/w%d.%s", common.ServiceImageVariantPrefix, base, v.Width, imaging.Extension(v.Format))
+		if err = s.uploadServiceImageFile(ctx, key, v.Format, v.Data); err != nil {
+			return err
+		}
+
+		variantID, err := s.sfGen.NextID()
+		if err != nil {
+			s.logger.Error("generate service image variant id failed", zap.Error(err))
+			return err
+		}
+
+		variants = append(variants, &model.ServiceImageVariant{
+			ID:             variantID,
+			ServiceImageID: image.ID,
+			Key:            key,
+			Format:         v.Format,
+			Width:          uint32(v.Width),
+			Height:         uint32(v.Height),
+			Size:           int64(len(v.Data)),
+		})
+	}
+
+	if err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.serviceRepo.ReplaceServiceImageVariantsTx(tx, image.ID, variants); err != nil {
+			return err
+		}
+
+		return s.serviceRepo.UpdateServiceImageTx(tx, image.ID, map[string]any{
+			"status": common.ServiceImageStatusReady,
+			"width":  uint32(result.Width),
+			"height": uint32(result.Height),
+		})
+	}); err != nil {
+		if common.IsForeignKeyViolation(err) {
+			// The image was removed while it was being processed.
+			s.publishDeleteFiles(serviceImageFileKeys([]*model.ServiceImage{{Variants: variants}}))
+			return nil
+		}
+		s.logger.Error("save service image variants failed", zap.Int64("id", image.ID), zap.Error(err))
+		return err
+	}
+
+	staleKeys := make([]string, 0, len(image.Variants))
+	for _, old := range image.Variants {
+		if !slices.ContainsFunc(variants, func(v *model.ServiceImageVariant) bool { return v.Key == old.Key }) {
+			staleKeys = append(staleKeys, old.Key)
+		}
+	}
+	s.publishDeleteFiles(staleKeys)
+
+	return nil
+}
+
+func (s *serviceSvcImpl) uploadServiceImageFile(ctx context.Context, key, format string, data []byte) error {
+	if err := s.storage.Put(ctx, key, data, &storage.PutOptions{
+		ContentType:  imaging.ContentType(format),
+		CacheControl: "public, max-age=31536000",
+	}); err != nil {
+		s.logger.Error("upload service image failed", zap.String("key", key), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *serviceSvcImpl) rejectServiceImage(ctx context.Context, image *model.ServiceImage) error {
+	if err := s.markServiceImageFailed(ctx, image); err != nil {
+		return err
+	}
+
+	s.publishDeleteFiles(serviceImageFileKeys([]*model.ServiceImage{image}))
+	return nil
+}
+
+func (s *serviceSvcImpl) markServiceImageFailed(ctx context.Context, image *model.ServiceImage) error {
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.serviceRepo.ReplaceServiceImageVariantsTx(tx, image.ID, nil); err != nil {
+			return err
+		}
+
+		return s.serviceRepo.UpdateServiceImageTx(tx, image.ID, map[string]any{
+			"status": common.ServiceImageStatusFailed,
+			"width":  nil,
+			"height": nil,
+		})
+	}); err != nil {
+		s.logger.Error("mark service image failed failed", zap.Int64("id", image.ID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// RequeuePendingServiceImages publishes again every image that has been
+// pending for longer than the requeue delay. That covers images stored before
+// processing existed, which the migration left pending, and messages lost
+// between commit and publish or published before the consumer was bound.
+func (s *serviceSvcImpl) RequeuePendingServiceImages(ctx context.Context) error {
+	createdBefore := time.Now().Add(-pendingImageRequeueDelay)
+	var afterID int64
+	requeued := 0
+	for ctx.Err() == nil {
+		images, err := s.serviceRepo.FindAllServiceImagesByStatusCreatedBefore(ctx, common.ServiceImageStatusPending, createdBefore, afterID, common.ServiceImageRequeueBatchSize)
+		if err != nil {
+			s.logger.Error("find pending service images failed", zap.Error(err))
+			return err
+		}
+
+		for _, img := range images {
+			body, _ := json.Marshal(types.ProcessImageMessage{ServiceImageID: img.ID})
+			if err = s.mqProvider.PublishMessage(common.ExchangeFile, common.RoutingKeyProcessImage, body); err != nil {
+				s.logger.Error("publish process image message failed", zap.Int64("id", img.ID), zap.Error(err))
+			}
+		}
+
+		requeued += len(images)
+		if len(images) < common.ServiceImageRequeueBatchSize {
+			break
+		}
+		afterID = images[len(images)-1].ID
+	}
+
+	if requeued > 0 {
+		s.logger.Info("pending service images requeued", zap.Int("count", requeued))
+	}
+
+	return ctx.Err()
+}
+
+func (s *serviceSvcImpl) publishProcessImages(images []*model.ServiceImage) {
+	if len(images) == 0 {
+		return
+	}
+
+	ids := make([]int64, 0, len(images))
+	for _, img := range images {
+		ids = append(ids, img.ID)
+	}
+
+	go func() {
+		for _, id := range ids {
+			body, _ := json.Marshal(types.ProcessImageMessage{ServiceImageID: id})
+			if err := s.mqProvider.PublishMessage(common.ExchangeFile, common.RoutingKeyProcessImage, body); err != nil {
+				s.logger.Error("publish process image message failed", zap.Int64("id", id), zap.Error(err))
+			}
+		}
+	}()
+}
+
+// confirmServiceImageUploadsTx marks the uploaded files of images as claimed
+// so the orphaned upload sweeper leaves them alone.
+func (s *serviceSvcImpl) confirmServiceImageUploadsTx(tx *gorm.DB, images []*model.ServiceImage) error {
+	keys := make([]string, 0, len(images))
+	for _, img := range images {
+		keys = append(keys, img.Key)
+	}
+
+	if err := s.fileRepo.ConfirmUploadsTx(tx, keys); err != nil {
+		if errors.Is(err, common.ErrUploadExpired) {
+			return err
+		}
+		s.logger.Error("confirm uploads failed", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *serviceSvcImpl) publishDeleteFiles(keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	go func() {
+		for _, key := range keys {
+			if err := s.mqProvider.PublishMessage(common.ExchangeFile, common.RoutingKeyDeleteFile, []byte(key)); err != nil {
+				s.logger.Error("publish delete file message failed", zap.Error(err))
+			}
+		}
+	}()
+}
+
+// serviceImageFileKeys lists every storage object that belongs to images:
+// the uploaded files themselves and their generated variants.
+func serviceImageFileKeys(images []*model.ServiceImage) []string {
+	keys := make([]string, 0, len(images))
+	for _, img := range images {
+		if strings.TrimSpace(img.Key) != "" {
+			keys = append(keys, img.Key)
+		}
+		for _, v := range img.Variants {
+			keys = append(keys, v.Key)
+		}
+	}
+
+	return keys
+}
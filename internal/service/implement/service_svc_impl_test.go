@@ -0,0 +1,150 @@
+package implement
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/InstaySystem/is_v1-be/internal/common"
+	"github.com/InstaySystem/is_v1-be/pkg/xlsx"
+)
+
+func TestCatalogueCellEscaping(t *testing.T) {
+	tests := []struct {
+		cell    string
+		escaped string
+	}{
+		{"", ""},
+		{"Spa", "Spa"},
+		{"it's fine", "it's fine"},
+		{"'quoted", "'quoted"},
+		{"=HYPERLINK(\"x\")", "'=HYPERLINK(\"x\")"},
+		{"+84 123", "'+84 123"},
+		{"-5", "'-5"},
+		{"@sum", "'@sum"},
+		{"'=already", "''=already"},
+		{"''@twice", "'''@twice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cell, func(t *testing.T) {
+			escaped := escapeCatalogueCell(tt.cell)
+			if escaped != tt.escaped {
+				t.Errorf("escape = %q, want %q", escaped, tt.escaped)
+			}
+			if got := unescapeCatalogueCell(escaped); got != tt.cell {
+				t.Errorf("round trip = %q, want %q", got, tt.cell)
+			}
+		})
+	}
+}
+
+func TestParseCatalogueBool(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   bool
+		wantOK bool
+	}{
+		{"true", true, true},
+		{"YES", true, true},
+		{"1", true, true},
+		{"y", true, true},
+		{"False", false, true},
+		{"no", false, true},
+		{"0", false, true},
+		{"", false, false},
+		{"maybe", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := parseCatalogueBool(tt.value)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseCatalogueBool(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestReadCatalogueRows(t *testing.T) {
+	rows := [][]string{
+		{"service_slug", "name"},
+		{"massage", escapeCatalogueCell("=cmd")},
+	}
+	workbook, err := xlsx.Write(common.CatalogueSheetName, rows)
+	if err != nil {
+		t.Fatalf("write workbook: %v", err)
+	}
+
+	tooMany := make([][]string, common.MaxCatalogueRows+2)
+	for i := range tooMany {
+		tooMany[i] = []string{"x"}
+	}
+	largeWorkbook, err := xlsx.Write(common.CatalogueSheetName, tooMany)
+	if err != nil {
+		t.Fatalf("write large workbook: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		fileName string
+		data     []byte
+		want     [][]string
+		wantErr  error
+	}{
+		{
+			name:     "csv with byte order mark",
+			fileName: "catalogue.csv",
+			data:     []byte("\ufeffservice_slug,name\nmassage,'=cmd\n"),
+			want:     [][]string{{"service_slug", "name"}, {"massage", "=cmd"}},
+		},
+		{
+			name:     "csv with ragged rows",
+			fileName: "Catalogue.CSV",
+			data:     []byte("service_slug,name\nmassage\n"),
+			want:     [][]string{{"service_slug", "name"}, {"massage"}},
+		},
+		{
+			name:     "xlsx round trip",
+			fileName: "catalogue.xlsx",
+			data:     workbook,
+			want:     [][]string{{"service_slug", "name"}, {"massage", "=cmd"}},
+		},
+		{
+			name:     "xlsx over the row limit",
+			fileName: "catalogue.xlsx",
+			data:     largeWorkbook,
+			wantErr:  common.ErrTooManyCatalogueRows,
+		},
+		{
+			name:     "empty csv",
+			fileName: "catalogue.csv",
+			data:     nil,
+			wantErr:  common.ErrInvalidCatalogueFile,
+		},
+		{
+			name:     "broken xlsx",
+			fileName: "catalogue.xlsx",
+			data:     []byte("not a zip"),
+			wantErr:  common.ErrInvalidCatalogueFile,
+		},
+		{
+			name:     "unsupported extension",
+			fileName: "catalogue.json",
+			data:     []byte("[]"),
+			wantErr:  common.ErrUnsupportedCatalogueFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readCatalogueRows(tt.fileName, tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("rows = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
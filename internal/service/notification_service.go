@@ -8,9 +8,9 @@ import (
 )
 
 type NotificationService interface {
-	GetNotificationsForAdmin(ctx context.Context, query types.NotificationPaginationQuery, userID, departmentID int64) ([]*model.Notification, *types.MetaResponse, error)
+	GetNotificationsForAdmin(ctx context.Context, query types.NotificationPaginationQuery, userID int64, departmentID *int64) ([]*model.Notification, *types.MetaResponse, error)
 
-	CountUnreadNotificationsForAdmin(ctx context.Context, userID, departmentID int64) (int64, error)
+	CountUnreadNotificationsForAdmin(ctx context.Context, userID int64, departmentID *int64) (int64, error)
 
 	GetNotificationsForGuest(ctx context.Context, orderRoomID int64) ([]*model.Notification, error)
 
@@ -23,7 +23,23 @@ type OrderService interface {
 
 	UpdateOrderServiceForAdmin(ctx context.Context, departmentID *int64, userID, orderServiceID int64, req types.UpdateOrderServiceRequest) error
 
-	GetOrderServicesForAdmin(ctx context.Context, query types.OrderServicePaginationQuery, departmentID *int64) ([]*model.OrderService, *types.MetaResponse, error)
+	GetOrderServicesForAdmin(ctx context.Context, query types.OrderServicePaginationQuery, userID int64, departmentID *int64) ([]*model.OrderService, *types.MetaResponse, error)
+
+	ClaimOrderService(ctx context.Context, orderServiceID, userID int64, departmentID *int64) error
+
+	AssignOrderService(ctx context.Context, orderServiceID, userID int64, departmentID *int64, req types.AssignOrderServiceRequest) error
 
 	GetOrderServicesForGuest(ctx context.Context, orderRoomID int64) ([]*model.OrderService, error)
+
+	CreateServiceOrder(ctx context.Context, orderRoomID int64, req types.CreateServiceOrderRequest) (int64, error)
+
+	GetServiceOrdersForGuest(ctx context.Context, orderRoomID int64) ([]*model.ServiceOrder, error)
+
+	UpdateServiceOrderForGuest(ctx context.Context, orderRoomID, serviceOrderID int64, req types.UpdateServiceOrderRequest) error
+
+	GetServiceOrdersForAdmin(ctx context.Context, query types.ServiceOrderPaginationQuery, departmentID *int64) ([]*model.ServiceOrder, *types.MetaResponse, error)
+
+	GetServiceOrderByID(ctx context.Context, userID, serviceOrderID int64, departmentID *int64) (*model.ServiceOrder, error)
+
+	UpdateServiceOrderForAdmin(ctx context.Context, departmentID *int64, userID, serviceOrderID int64, req types.UpdateServiceOrderRequest) error
 }
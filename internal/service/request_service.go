@@ -12,7 +12,11 @@ type RequestService interface {
 
 	GetRequestTypesForAdmin(ctx context.Context) ([]*model.RequestType, error)
 
-	GetRequestTypesForGuest(ctx context.Context) ([]*model.RequestType, error)
+	GetRequestTypesForGuest(ctx context.Context, locales []string) ([]*model.RequestType, error)
+
+	UpsertRequestTypeTranslation(ctx context.Context, requestTypeID int64, locale string, req types.NameTranslationRequest) error
+
+	DeleteRequestTypeTranslation(ctx context.Context, requestTypeID int64, locale string) error
 
 	UpdateRequestType(ctx context.Context, requestTypeID, userID int64, req types.UpdateRequestTypeRequest) error
 
@@ -28,5 +32,21 @@ type RequestService interface {
 
 	UpdateRequestForAdmin(ctx context.Context, departmentID *int64, userID, requestID int64, status string) error
 
-	GetRequestsForAdmin(ctx context.Context, query types.RequestPaginationQuery, departmentID *int64) ([]*model.Request, *types.MetaResponse, error)
+	GetRequestsForAdmin(ctx context.Context, query types.RequestPaginationQuery, userID int64, departmentID *int64) ([]*model.Request, *types.MetaResponse, error)
+
+	CreateRequestNote(ctx context.Context, requestID, userID int64, departmentID *int64, req types.CreateRequestNoteRequest) error
+
+	ClaimRequest(ctx context.Context, requestID, userID int64, departmentID *int64) error
+
+	AssignRequest(ctx context.Context, requestID, userID int64, departmentID *int64, req types.AssignRequestRequest) error
+
+	CreateRequestSchedule(ctx context.Context, orderRoomID int64, req types.CreateRequestScheduleRequest) (int64, error)
+
+	GetRequestSchedulesForGuest(ctx context.Context, orderRoomID int64) ([]*model.RequestSchedule, error)
+
+	CancelRequestSchedule(ctx context.Context, orderRoomID, scheduleID int64) error
+
+	MaterializeScheduledRequests(ctx context.Context) error
+
+	EscalateSLABreaches(ctx context.Context) error
 }
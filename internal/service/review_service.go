@@ -15,4 +15,14 @@ type ReviewService interface {
 	GetReviews(ctx context.Context, query types.ReviewPaginationQuery) ([]*model.Review, *types.MetaResponse, error)
 
 	UpdateReview(ctx context.Context, req types.UpdateReviewRequest, orderRoomID int64) error
+
+	RespondReview(ctx context.Context, reviewID, userID int64, req types.RespondReviewRequest) error
+
+	DeleteReviewResponse(ctx context.Context, reviewID int64) error
+
+	ModerateReview(ctx context.Context, reviewID, userID int64, req types.ModerateReviewRequest) error
+
+	FeatureReview(ctx context.Context, reviewID int64, req types.FeatureReviewRequest) error
+
+	GetPublicReviews(ctx context.Context, query types.PublicReviewPaginationQuery) ([]*model.Review, *types.MetaResponse, error)
 }
@@ -12,7 +12,7 @@ type ServiceService interface {
 
 	GetServiceTypesForAdmin(ctx context.Context) ([]*model.ServiceType, error)
 
-	GetServiceTypesForGuest(ctx context.Context) ([]*model.ServiceType, error)
+	GetServiceTypesForGuest(ctx context.Context, locales []string) ([]*model.ServiceType, error)
 
 	UpdateServiceType(ctx context.Context, serviceType, userID int64, req types.UpdateServiceTypeRequest) error
 
@@ -28,7 +28,33 @@ type ServiceService interface {
 
 	DeleteService(ctx context.Context, serviceID int64) error
 
-	GetServiceTypeBySlugWithServices(ctx context.Context, serviceTypeSlug string) (*model.ServiceType, error)
+	AdjustServiceStock(ctx context.Context, serviceID, userID int64, req types.AdjustServiceStockRequest) error
 
-	GetServiceBySlug(ctx context.Context, serviceSlug string) (*model.Service, error)
+	CreatePricingRule(ctx context.Context, userID int64, req types.PricingRuleRequest) (int64, error)
+
+	GetPricingRules(ctx context.Context) ([]*model.PricingRule, error)
+
+	UpdatePricingRule(ctx context.Context, ruleID, userID int64, req types.PricingRuleRequest) error
+
+	DeletePricingRule(ctx context.Context, ruleID int64) error
+
+	GetServiceTypeBySlugWithServices(ctx context.Context, serviceTypeSlug string, locales []string) (*model.ServiceType, error)
+
+	GetServiceBySlug(ctx context.Context, serviceSlug string, locales []string) (*model.Service, error)
+
+	UpsertServiceTypeTranslation(ctx context.Context, serviceTypeID int64, locale string, req types.NameTranslationRequest) error
+
+	DeleteServiceTypeTranslation(ctx context.Context, serviceTypeID int64, locale string) error
+
+	UpsertServiceTranslation(ctx context.Context, serviceID int64, locale string, req types.ServiceTranslationRequest) error
+
+	DeleteServiceTranslation(ctx context.Context, serviceID int64, locale string) error
+
+	ImportServiceCatalogue(ctx context.Context, userID int64, fileName string, data []byte, dryRun bool) (*types.CatalogueImportReport, error)
+
+	ExportServiceCatalogue(ctx context.Context, format string) ([]byte, string, string, error)
+
+	ProcessServiceImage(ctx context.Context, serviceImageID int64) error
+
+	RequeuePendingServiceImages(ctx context.Context) error
 }
@@ -49,6 +49,36 @@ type AuthEmailMessage struct {
 	Otp     string `json:"otp"`
 }
 
+type TranscriptEmailMessage struct {
+	To        string   `json:"to"`
+	Subject   string   `json:"subject"`
+	GuestName string   `json:"guest_name"`
+	RoomName  string   `json:"room_name"`
+	Lines     []string `json:"lines"`
+}
+
+type TranscriptEmailData struct {
+	Subject   string
+	GuestName string
+	RoomName  string
+	Lines     []string
+}
+
+type ProcessImageMessage struct {
+	ServiceImageID int64 `json:"service_image_id"`
+}
+
+// UploadPolicy governs presigned uploads for one purpose. Keys are created
+// under Prefix, and Public files can be viewed by any authenticated client.
+type UploadPolicy struct {
+	Prefix       string
+	AllowedTypes []string
+	MaxSize      int64
+	DailyQuota   int64
+	ClientTypes  []string
+	Public       bool
+}
+
 type NotificationMessage struct {
 	Content      string  `json:"content"`
 	Type         string  `json:"type"`
@@ -90,6 +120,14 @@ type ChartData struct {
 	Percentage float64 `json:"percentage" gorm:"-"`
 }
 
+type PricingRuleRevenueChartData struct {
+	PricingRuleName string  `json:"pricing_rule_name" gorm:"column:pricing_rule_name"`
+	Count           int64   `json:"count" gorm:"column:count"`
+	ListRevenue     float64 `json:"list_revenue" gorm:"column:list_revenue"`
+	Revenue         float64 `json:"revenue" gorm:"column:revenue"`
+	Discount        float64 `json:"discount" gorm:"-"`
+}
+
 type PopularRoomTypeChartData struct {
 	RoomTypeName string  `json:"room_type_name" gorm:"column:room_type_name"`
 	Count        int64   `json:"count" gorm:"column:count"`
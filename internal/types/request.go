@@ -1,10 +1,15 @@
 package types
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 type UploadPresignedURLRequest struct {
 	FileName    string `json:"file_name" binding:"required"`
 	ContentType string `json:"content_type" binding:"required"`
+	Size        int64  `json:"size" binding:"required,min=1"`
+	Purpose     string `json:"purpose" binding:"required,oneof=service_image chat_image request_photo avatar"`
 }
 
 type UploadPresignedURLsRequest struct {
@@ -37,6 +42,7 @@ type UpdateDepartmentRequest struct {
 	Name        *string `json:"name" binding:"omitempty,min=2"`
 	DisplayName *string `json:"display_name" binding:"omitempty,min=2"`
 	Description *string `json:"description" binding:"omitempty"`
+	HeadID      *int64  `json:"head_id" binding:"omitempty"`
 }
 
 type LoginRequest struct {
@@ -97,22 +103,50 @@ type UserPaginationQuery struct {
 }
 
 type CreateServiceTypeRequest struct {
-	Name         string `json:"name" binding:"required,min=2"`
-	DepartmentID int64  `json:"department_id" binding:"required"`
+	Name         string               `json:"name" binding:"required,min=2"`
+	DepartmentID int64                `json:"department_id" binding:"required"`
+	OpeningHours []OpeningHourRequest `json:"opening_hours" binding:"omitempty,dive"`
 }
 
 type UpdateServiceTypeRequest struct {
-	Name         *string `json:"name" binding:"omitempty,min=2"`
-	DepartmentID *int64  `json:"department_id" binding:"omitempty"`
+	Name         *string               `json:"name" binding:"omitempty,min=2"`
+	DepartmentID *int64                `json:"department_id" binding:"omitempty"`
+	OpeningHours *[]OpeningHourRequest `json:"opening_hours" binding:"omitempty,dive"`
+}
+
+type OpeningHourRequest struct {
+	DayOfWeek *uint8 `json:"day_of_week" binding:"required,min=0,max=6"`
+	OpenTime  string `json:"open_time" binding:"required,datetime=15:04"`
+	CloseTime string `json:"close_time" binding:"required,datetime=15:04"`
 }
 
 type CreateServiceRequest struct {
-	Name          string                      `json:"name" binding:"required,min=2"`
-	Price         float64                     `json:"price" binding:"required,gt=0"`
-	IsActive      bool                        `json:"is_active" binding:"required"`
-	Description   string                      `json:"description" binding:"required"`
-	ServiceTypeID int64                       `json:"service_type_id" binding:"required"`
-	Images        []CreateServiceImageRequest `json:"images" binding:"required,min=1,dive"`
+	Name                string                      `json:"name" binding:"required,min=2"`
+	Price               float64                     `json:"price" binding:"required,gt=0"`
+	IsActive            bool                        `json:"is_active" binding:"required"`
+	Description         string                      `json:"description" binding:"required"`
+	ServiceTypeID       int64                       `json:"service_type_id" binding:"required"`
+	Images              []CreateServiceImageRequest `json:"images" binding:"required,min=1,dive"`
+	OpeningHours        []OpeningHourRequest        `json:"opening_hours" binding:"omitempty,dive"`
+	SlotDurationMinutes *uint32                     `json:"slot_duration_minutes" binding:"omitempty,gt=0"`
+	SlotCapacity        *uint32                     `json:"slot_capacity" binding:"omitempty,gt=0"`
+	OptionGroups        []ServiceOptionGroupRequest `json:"option_groups" binding:"omitempty,dive"`
+	Stock               *uint32                     `json:"stock" binding:"omitempty"`
+	LowStockThreshold   *uint32                     `json:"low_stock_threshold" binding:"omitempty"`
+}
+
+type ServiceOptionGroupRequest struct {
+	Name          string                 `json:"name" binding:"required,min=1"`
+	SelectionType string                 `json:"selection_type" binding:"required,oneof=single multiple"`
+	IsRequired    bool                   `json:"is_required"`
+	SortOrder     uint32                 `json:"sort_order" binding:"required,gt=0"`
+	Options       []ServiceOptionRequest `json:"options" binding:"required,min=1,dive"`
+}
+
+type ServiceOptionRequest struct {
+	Name       string  `json:"name" binding:"required,min=1"`
+	PriceDelta float64 `json:"price_delta"`
+	SortOrder  uint32  `json:"sort_order" binding:"required,gt=0"`
 }
 
 type CreateServiceImageRequest struct {
@@ -122,14 +156,57 @@ type CreateServiceImageRequest struct {
 }
 
 type UpdateServiceRequest struct {
-	Name          *string                     `json:"name" binding:"omitempty,min=2"`
-	Price         *float64                    `json:"price" binding:"omitempty,gt=0"`
-	IsActive      *bool                       `json:"is_active" binding:"omitempty"`
-	Description   *string                     `json:"description" binding:"omitempty"`
-	ServiceTypeID *int64                      `json:"service_type_id" binding:"omitempty"`
-	NewImages     []CreateServiceImageRequest `json:"new_images" binding:"omitempty,dive"`
-	UpdateImages  []UpdateServiceImageRequest `json:"update_images" binding:"omitempty,dive"`
-	DeleteImages  []int64                     `json:"delete_images" binding:"omitempty,dive"`
+	Name                *string                      `json:"name" binding:"omitempty,min=2"`
+	Price               *float64                     `json:"price" binding:"omitempty,gt=0"`
+	IsActive            *bool                        `json:"is_active" binding:"omitempty"`
+	Description         *string                      `json:"description" binding:"omitempty"`
+	ServiceTypeID       *int64                       `json:"service_type_id" binding:"omitempty"`
+	NewImages           []CreateServiceImageRequest  `json:"new_images" binding:"omitempty,dive"`
+	UpdateImages        []UpdateServiceImageRequest  `json:"update_images" binding:"omitempty,dive"`
+	DeleteImages        []int64                      `json:"delete_images" binding:"omitempty,dive"`
+	OpeningHours        *[]OpeningHourRequest        `json:"opening_hours" binding:"omitempty,dive"`
+	SlotDurationMinutes *uint32                      `json:"slot_duration_minutes" binding:"omitempty"`
+	SlotCapacity        *uint32                      `json:"slot_capacity" binding:"omitempty"`
+	OptionGroups        *[]ServiceOptionGroupRequest `json:"option_groups" binding:"omitempty,dive"`
+	TrackStock          *bool                        `json:"track_stock" binding:"omitempty"`
+	Stock               *uint32                      `json:"stock" binding:"omitempty"`
+	LowStockThreshold   *uint32                      `json:"low_stock_threshold" binding:"omitempty"`
+}
+
+type PricingRuleRequest struct {
+	Name          string     `json:"name" binding:"required,min=2"`
+	ServiceTypeID *int64     `json:"service_type_id" binding:"omitempty"`
+	ServiceID     *int64     `json:"service_id" binding:"omitempty"`
+	DayType       string     `json:"day_type" binding:"omitempty,oneof=all weekday weekend"`
+	StartTime     *string    `json:"start_time" binding:"omitempty,datetime=15:04"`
+	EndTime       *string    `json:"end_time" binding:"omitempty,datetime=15:04"`
+	StartsAt      *time.Time `json:"starts_at" binding:"omitempty"`
+	EndsAt        *time.Time `json:"ends_at" binding:"omitempty"`
+	PromoCode     *string    `json:"promo_code" binding:"omitempty,min=3,max=50"`
+	DiscountType  string     `json:"discount_type" binding:"required,oneof=percentage fixed_amount fixed_price"`
+	DiscountValue float64    `json:"discount_value" binding:"required,gt=0"`
+	IsActive      *bool      `json:"is_active" binding:"required"`
+}
+
+type ServiceTranslationRequest struct {
+	Name        string  `json:"name" binding:"required,min=1"`
+	Description *string `json:"description" binding:"omitempty"`
+}
+
+type NameTranslationRequest struct {
+	Name string `json:"name" binding:"required,min=1"`
+}
+
+type AdjustServiceStockRequest struct {
+	Delta int32 `json:"delta" binding:"required,ne=0"`
+}
+
+type CatalogueImportQuery struct {
+	DryRun bool `form:"dry_run" json:"dry_run"`
+}
+
+type CatalogueExportQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx" json:"format"`
 }
 
 type UpdateServiceImageRequest struct {
@@ -157,6 +234,14 @@ type ReviewPaginationQuery struct {
 	Filter string `form:"filter" binding:"omitempty" json:"filter"`
 	From   string `form:"from"   binding:"omitempty,datetime=2006-01-02" json:"from"`
 	To     string `form:"to"     binding:"omitempty,datetime=2006-01-02" json:"to"`
+
+	Status     string `form:"status" binding:"omitempty,oneof=approved hidden flagged" json:"status"`
+	IsFeatured *bool  `form:"is_featured" binding:"omitempty" json:"is_featured"`
+}
+
+type PublicReviewPaginationQuery struct {
+	Page  uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit uint32 `form:"limit" binding:"omitempty,min=1,max=50" json:"limit"`
 }
 
 type RoomPaginationQuery struct {
@@ -172,13 +257,17 @@ type RoomPaginationQuery struct {
 }
 
 type CreateRequestTypeRequest struct {
-	Name         string `json:"name" binding:"required,min=2"`
-	DepartmentID int64  `json:"department_id" binding:"required"`
+	Name               string  `json:"name" binding:"required,min=2"`
+	DepartmentID       int64   `json:"department_id" binding:"required"`
+	AcceptSLAMinutes   *uint32 `json:"accept_sla_minutes" binding:"omitempty,gt=0"`
+	CompleteSLAMinutes *uint32 `json:"complete_sla_minutes" binding:"omitempty,gt=0"`
 }
 
 type UpdateRequestTypeRequest struct {
-	Name         *string `json:"name" binding:"omitempty,min=2"`
-	DepartmentID *int64  `json:"department_id" binding:"omitempty"`
+	Name               *string `json:"name" binding:"omitempty,min=2"`
+	DepartmentID       *int64  `json:"department_id" binding:"omitempty"`
+	AcceptSLAMinutes   *uint32 `json:"accept_sla_minutes" binding:"omitempty"`
+	CompleteSLAMinutes *uint32 `json:"complete_sla_minutes" binding:"omitempty"`
 }
 
 type CreateRoomTypeRequest struct {
@@ -223,63 +312,200 @@ type VerifyOrderRoomRequest struct {
 }
 
 type CreateOrderServiceRequest struct {
-	ServiceID int64   `json:"service_id" binding:"required"`
-	Quantity  uint32  `json:"quantity" binding:"required,min=1"`
-	GuestNote *string `json:"guest_note" binding:"omitempty,min=1"`
+	ServiceID   int64      `json:"service_id" binding:"required"`
+	Quantity    uint32     `json:"quantity" binding:"required,min=1"`
+	GuestNote   *string    `json:"guest_note" binding:"omitempty,min=1"`
+	SlotStartAt *time.Time `json:"slot_start_at" binding:"omitempty"`
+	OptionIDs   []int64    `json:"option_ids" binding:"omitempty,dive"`
+	PromoCode   *string    `json:"promo_code" binding:"omitempty,min=3,max=50"`
 }
 
 type CreateRequestRequest struct {
-	RequestTypeID int64  `json:"request_type_id" binding:"required"`
-	Content       string `json:"content" binding:"required,min=1"`
+	RequestTypeID int64                            `json:"request_type_id" binding:"required"`
+	Content       string                           `json:"content" binding:"required,min=1"`
+	Priority      string                           `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	Attachments   []CreateRequestAttachmentRequest `json:"attachments" binding:"omitempty,max=5,dive"`
+}
+
+type CreateRequestScheduleRequest struct {
+	RequestTypeID int64     `json:"request_type_id" binding:"required"`
+	Content       string    `json:"content" binding:"required,min=1"`
+	Priority      string    `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	ScheduledAt   time.Time `json:"scheduled_at" binding:"required"`
+	Recurrence    string    `json:"recurrence" binding:"omitempty,oneof=none daily"`
+}
+
+type CreateRequestAttachmentRequest struct {
+	Key      string `json:"key" binding:"required,min=2"`
+	FileName string `json:"file_name" binding:"required"`
+}
+
+type CreateRequestNoteRequest struct {
+	Type    string `json:"type" binding:"required,oneof=note progress"`
+	Content string `json:"content" binding:"required,min=1"`
 }
 
 type UpdateRequestRequest struct {
 	Status string `json:"status" binding:"required,oneof=done accepted cancelled"`
 }
 
-type UpdateOrderServiceRequest struct {
-	Status    string  `json:"status" binding:"required,oneof=rejected accepted cancelled"`
-	Reason    *string `json:"reason" binding:"omitempty"`
-	StaffNote *string `json:"staff_note" binding:"omitempty"`
+type CreateServiceOrderRequest struct {
+	Lines      []CreateServiceOrderLineRequest `json:"lines" binding:"required,min=1,max=20,dive"`
+	GuestNote  *string                         `json:"guest_note" binding:"omitempty,min=1"`
+	DeliveryAt *time.Time                      `json:"delivery_at" binding:"omitempty"`
+	PromoCode  *string                         `json:"promo_code" binding:"omitempty,min=3,max=50"`
 }
 
-type OrderServicePaginationQuery struct {
+type CreateServiceOrderLineRequest struct {
+	ServiceID   int64      `json:"service_id" binding:"required"`
+	Quantity    uint32     `json:"quantity" binding:"required,min=1"`
+	SlotStartAt *time.Time `json:"slot_start_at" binding:"omitempty"`
+	OptionIDs   []int64    `json:"option_ids" binding:"omitempty,dive"`
+}
+
+type UpdateServiceOrderRequest struct {
+	Status        string                          `json:"status" binding:"required,oneof=rejected accepted cancelled"`
+	Reason        *string                         `json:"reason" binding:"omitempty"`
+	StaffNote     *string                         `json:"staff_note" binding:"omitempty"`
+	RejectedLines []RejectServiceOrderLineRequest `json:"rejected_lines" binding:"omitempty,dive"`
+}
+
+type RejectServiceOrderLineRequest struct {
+	ID     int64   `json:"id" binding:"required"`
+	Reason *string `json:"reason" binding:"omitempty"`
+}
+
+type ServiceOrderPaginationQuery struct {
 	Page   uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
 	Limit  uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
-	Sort   string `form:"sort" json:"sort"`
 	Order  string `form:"order" binding:"omitempty,oneof=asc desc" json:"order"`
-	Filter string `form:"filter" binding:"omitempty" json:"filter"`
 	From   string `form:"from"   binding:"omitempty,datetime=2006-01-02" json:"from"`
 	To     string `form:"to"     binding:"omitempty,datetime=2006-01-02" json:"to"`
 	Status string `form:"status" binding:"omitempty,oneof=accepted pending rejected cancelled" json:"status"`
 }
 
+type UpdateOrderServiceRequest struct {
+	Status    string  `json:"status" binding:"required,oneof=rejected accepted cancelled"`
+	Reason    *string `json:"reason" binding:"omitempty"`
+	StaffNote *string `json:"staff_note" binding:"omitempty"`
+}
+
+type OrderServicePaginationQuery struct {
+	Page       uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit      uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
+	Sort       string `form:"sort" json:"sort"`
+	Order      string `form:"order" binding:"omitempty,oneof=asc desc" json:"order"`
+	Filter     string `form:"filter" binding:"omitempty" json:"filter"`
+	From       string `form:"from"   binding:"omitempty,datetime=2006-01-02" json:"from"`
+	To         string `form:"to"     binding:"omitempty,datetime=2006-01-02" json:"to"`
+	Status     string `form:"status" binding:"omitempty,oneof=accepted pending rejected cancelled" json:"status"`
+	Assignee   string `form:"assignee" binding:"omitempty,oneof=mine unassigned all" json:"assignee"`
+	AssigneeID int64  `form:"assignee_id" binding:"omitempty" json:"assignee_id"`
+}
+
+type AssignOrderServiceRequest struct {
+	AssigneeID int64 `json:"assignee_id" binding:"required"`
+}
+
 type NotificationPaginationQuery struct {
 	Page  uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
 	Limit uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
 }
 
 type ChatPaginationQuery struct {
+	Page     uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit    uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
+	Search   string `form:"search" json:"search"`
+	Assignee string `form:"assignee" binding:"omitempty,oneof=mine unassigned all" json:"assignee"`
+}
+
+type CreateCannedResponseRequest struct {
+	Title        string `json:"title" binding:"required,min=2,max=150"`
+	Shortcut     string `json:"shortcut" binding:"required,min=1,max=50"`
+	Content      string `json:"content" binding:"required"`
+	DepartmentID int64  `json:"department_id" binding:"required"`
+}
+
+type UpdateCannedResponseRequest struct {
+	Title        *string `json:"title" binding:"omitempty,min=2,max=150"`
+	Shortcut     *string `json:"shortcut" binding:"omitempty,min=1,max=50"`
+	Content      *string `json:"content" binding:"omitempty,min=1"`
+	DepartmentID *int64  `json:"department_id" binding:"omitempty"`
+}
+
+type CannedResponseQuery struct {
+	DepartmentID int64  `form:"department_id" binding:"omitempty" json:"department_id"`
+	Search       string `form:"search" json:"search"`
+}
+
+type CreateFAQRequest struct {
+	Question string   `json:"question" binding:"required,min=2,max=255"`
+	Answer   string   `json:"answer" binding:"required"`
+	Keywords []string `json:"keywords" binding:"required,min=1,dive,min=1,max=100"`
+	IsActive *bool    `json:"is_active" binding:"omitempty"`
+}
+
+type UpdateFAQRequest struct {
+	Question *string  `json:"question" binding:"omitempty,min=2,max=255"`
+	Answer   *string  `json:"answer" binding:"omitempty,min=1"`
+	Keywords []string `json:"keywords" binding:"omitempty,min=1,dive,min=1,max=100"`
+	IsActive *bool    `json:"is_active" binding:"omitempty"`
+}
+
+type FAQQuery struct {
+	Search   string `form:"search" json:"search"`
+	IsActive *bool  `form:"is_active" json:"is_active"`
+}
+
+type MessageSearchQuery struct {
+	Q      string `form:"q" binding:"required,min=2,max=200" json:"q"`
+	ChatID int64  `form:"chat_id" binding:"omitempty" json:"chat_id"`
 	Page   uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
 	Limit  uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
-	Search string `form:"search" json:"search"`
+}
+
+type TranscriptQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=json txt pdf" json:"format"`
+}
+
+type AssignChatRequest struct {
+	AssigneeID int64 `json:"assignee_id" binding:"required"`
+}
+
+type TransferChatRequest struct {
+	AssigneeID   *int64 `json:"assignee_id" binding:"omitempty"`
+	DepartmentID *int64 `json:"department_id" binding:"omitempty"`
+	Note         string `json:"note" binding:"required,max=1000"`
 }
 
 type RequestPaginationQuery struct {
-	Page   uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
-	Limit  uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
-	Sort   string `form:"sort" json:"sort"`
-	Order  string `form:"order" binding:"omitempty,oneof=asc desc" json:"order"`
-	Filter string `form:"filter" binding:"omitempty" json:"filter"`
-	From   string `form:"from"   binding:"omitempty,datetime=2006-01-02" json:"from"`
-	To     string `form:"to"     binding:"omitempty,datetime=2006-01-02" json:"to"`
-	Status string `form:"status" binding:"omitempty,oneof=accepted pending rejected cancelled" json:"status"`
+	Page       uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit      uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
+	Sort       string `form:"sort" json:"sort"`
+	Order      string `form:"order" binding:"omitempty,oneof=asc desc" json:"order"`
+	Filter     string `form:"filter" binding:"omitempty" json:"filter"`
+	From       string `form:"from"   binding:"omitempty,datetime=2006-01-02" json:"from"`
+	To         string `form:"to"     binding:"omitempty,datetime=2006-01-02" json:"to"`
+	Status     string `form:"status" binding:"omitempty,oneof=accepted pending rejected cancelled" json:"status"`
+	Assignee   string `form:"assignee" binding:"omitempty,oneof=mine unassigned all" json:"assignee"`
+	AssigneeID int64  `form:"assignee_id" binding:"omitempty" json:"assignee_id"`
+	Priority   string `form:"priority" binding:"omitempty,oneof=low normal high urgent" json:"priority"`
+}
+
+type AssignRequestRequest struct {
+	AssigneeID int64 `json:"assignee_id" binding:"required"`
 }
 
 type CreateMessageRequest struct {
-	Content  *string `json:"content" binding:"omitempty"`
-	ImageKey *string `json:"image_key" binding:"omitempty"`
-	ChatID   int64   `json:"chat_id" binding:"request"`
+	Content     *string                          `json:"content" binding:"omitempty"`
+	TemplateID  *int64                           `json:"template_id" binding:"omitempty"`
+	Attachments []CreateMessageAttachmentRequest `json:"attachments" binding:"omitempty,max=10,dive"`
+	ChatID      int64                            `json:"chat_id" binding:"required"`
+}
+
+type CreateMessageAttachmentRequest struct {
+	Key      string `json:"key" binding:"required,min=2"`
+	FileName string `json:"file_name" binding:"required"`
 }
 
 type WSRequest struct {
@@ -291,6 +517,19 @@ type UpdateReadMessagesRequest struct {
 	ChatID int64 `json:"chat_id"`
 }
 
+type UpdateDeliveredMessagesRequest struct {
+	ChatID int64 `json:"chat_id"`
+}
+
+type ChatTypingRequest struct {
+	ChatID   int64 `json:"chat_id"`
+	IsTyping bool  `json:"is_typing"`
+}
+
+type ChatPresenceRequest struct {
+	ChatID int64 `json:"chat_id"`
+}
+
 type CreateReviewRequest struct {
 	Email   string `json:"email" binding:"required,email"`
 	Star    uint32 `json:"star" binding:"required,min=1,max=5"`
@@ -301,3 +540,16 @@ type UpdateReviewRequest struct {
 	Star    *uint32 `json:"star" binding:"omitempty,min=1,max=5"`
 	Content *string `json:"content" binding:"omitempty"`
 }
+
+type RespondReviewRequest struct {
+	Response string `json:"response" binding:"required,max=2000"`
+}
+
+type ModerateReviewRequest struct {
+	Status string  `json:"status" binding:"required,oneof=approved hidden flagged"`
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
+}
+
+type FeatureReviewRequest struct {
+	IsFeatured *bool `json:"is_featured" binding:"required"`
+}
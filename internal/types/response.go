@@ -8,8 +8,9 @@ type APIResponse struct {
 }
 
 type UploadPresignedURLResponse struct {
-	Url string `json:"url"`
-	Key string `json:"key"`
+	Url     string            `json:"url"`
+	Key     string            `json:"key"`
+	Headers map[string]string `json:"headers"`
 }
 
 type ViewPresignedURLResponse struct {
@@ -38,6 +39,7 @@ type DepartmentResponse struct {
 	UpdatedAt   time.Time          `json:"updated_at"`
 	CreatedBy   *BasicUserResponse `json:"created_by"`
 	UpdatedBy   *BasicUserResponse `json:"updated_by"`
+	Head        *BasicUserResponse `json:"head"`
 	StaffCount  int64              `json:"staff_count"`
 }
 
@@ -91,9 +93,65 @@ type ServiceTypeResponse struct {
 	CreatedBy    *BasicUserResponse        `json:"created_by"`
 	UpdatedBy    *BasicUserResponse        `json:"updated_by"`
 	Department   *SimpleDepartmentResponse `json:"department"`
+	OpeningHours []*OpeningHourResponse    `json:"opening_hours"`
+	Translations []*TranslationResponse    `json:"translations"`
 	ServiceCount int64                     `json:"service_count"`
 }
 
+type CatalogueImportReport struct {
+	DryRun              bool                 `json:"dry_run"`
+	TotalRows           int                  `json:"total_rows"`
+	ServiceTypesCreated int                  `json:"service_types_created"`
+	ServiceTypesUpdated int                  `json:"service_types_updated"`
+	ServicesCreated     int                  `json:"services_created"`
+	ServicesUpdated     int                  `json:"services_updated"`
+	Errors              []*CatalogueRowError `json:"errors"`
+}
+
+type CatalogueRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type TranslationResponse struct {
+	Locale      string  `json:"locale"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+type OpeningHourResponse struct {
+	DayOfWeek uint8  `json:"day_of_week"`
+	OpenTime  string `json:"open_time"`
+	CloseTime string `json:"close_time"`
+}
+
+type ServiceOptionGroupResponse struct {
+	ID            int64                    `json:"id"`
+	Name          string                   `json:"name"`
+	SelectionType string                   `json:"selection_type"`
+	IsRequired    bool                     `json:"is_required"`
+	Options       []*ServiceOptionResponse `json:"options"`
+}
+
+type ServiceOptionResponse struct {
+	ID         int64   `json:"id"`
+	Name       string  `json:"name"`
+	PriceDelta float64 `json:"price_delta"`
+}
+
+type OrderServiceOptionResponse struct {
+	GroupName  string  `json:"group_name"`
+	OptionName string  `json:"option_name"`
+	PriceDelta float64 `json:"price_delta"`
+}
+
+type ServiceSlotResponse struct {
+	StartAt   time.Time `json:"start_at"`
+	EndAt     time.Time `json:"end_at"`
+	Available uint32    `json:"available"`
+}
+
 type SimpleServiceTypeResponse struct {
 	ID   int64  `json:"id"`
 	Name string `json:"name"`
@@ -108,15 +166,27 @@ type SimpleServiceTypeWithBasicServices struct {
 }
 
 type SimpleServiceImageResponse struct {
-	ID  int64  `json:"id"`
-	Key string `json:"key"`
+	ID       int64                          `json:"id"`
+	Key      string                         `json:"key"`
+	Variants []*ServiceImageVariantResponse `json:"variants"`
 }
 
 type ServiceImageResponse struct {
-	ID          int64  `json:"id"`
-	Key         string `json:"key"`
-	IsThumbnail bool   `json:"is_thumbnail"`
-	SortOrder   uint32 `json:"sort_order"`
+	ID          int64                          `json:"id"`
+	Key         string                         `json:"key"`
+	IsThumbnail bool                           `json:"is_thumbnail"`
+	SortOrder   uint32                         `json:"sort_order"`
+	Status      string                         `json:"status"`
+	Width       *uint32                        `json:"width"`
+	Height      *uint32                        `json:"height"`
+	Variants    []*ServiceImageVariantResponse `json:"variants"`
+}
+
+type ServiceImageVariantResponse struct {
+	Key    string `json:"key"`
+	Format string `json:"format"`
+	Width  uint32 `json:"width"`
+	Height uint32 `json:"height"`
 }
 
 type BasicServiceResponse struct {
@@ -125,41 +195,58 @@ type BasicServiceResponse struct {
 	Slug        string                      `json:"slug"`
 	Price       float64                     `json:"price"`
 	IsActive    bool                        `json:"is_active"`
+	IsSoldOut   bool                        `json:"is_sold_out"`
 	ServiceType *SimpleServiceTypeResponse  `json:"service_type"`
 	Thumbnail   *SimpleServiceImageResponse `json:"thumbnail"`
 }
 
 type SimpleServiceResponse struct {
-	ID            int64                      `json:"id"`
-	Name          string                     `json:"name"`
-	Price         float64                    `json:"price"`
-	Description   string                     `json:"description"`
-	ServiceType   *SimpleServiceTypeResponse `json:"service_type"`
-	ServiceImages []*ServiceImageResponse    `json:"images"`
+	ID                  int64                         `json:"id"`
+	Name                string                        `json:"name"`
+	Price               float64                       `json:"price"`
+	Description         string                        `json:"description"`
+	ServiceType         *SimpleServiceTypeResponse    `json:"service_type"`
+	ServiceImages       []*ServiceImageResponse       `json:"images"`
+	OpeningHours        []*OpeningHourResponse        `json:"opening_hours"`
+	OptionGroups        []*ServiceOptionGroupResponse `json:"option_groups"`
+	SlotDurationMinutes *uint32                       `json:"slot_duration_minutes"`
+	Slots               []*ServiceSlotResponse        `json:"slots"`
+	IsSoldOut           bool                          `json:"is_sold_out"`
 }
 
 type ServiceResponse struct {
-	ID            int64                      `json:"id"`
-	Name          string                     `json:"name"`
-	Price         float64                    `json:"price"`
-	IsActive      bool                       `json:"is_active"`
-	Description   string                     `json:"description"`
-	CreatedAt     time.Time                  `json:"created_at"`
-	UpdatedAt     time.Time                  `json:"updated_at"`
-	ServiceType   *SimpleServiceTypeResponse `json:"service_type"`
-	CreatedBy     *BasicUserResponse         `json:"created_by"`
-	UpdatedBy     *BasicUserResponse         `json:"updated_by"`
-	ServiceImages []*ServiceImageResponse    `json:"images"`
+	ID                  int64                         `json:"id"`
+	Name                string                        `json:"name"`
+	Price               float64                       `json:"price"`
+	IsActive            bool                          `json:"is_active"`
+	Description         string                        `json:"description"`
+	CreatedAt           time.Time                     `json:"created_at"`
+	UpdatedAt           time.Time                     `json:"updated_at"`
+	ServiceType         *SimpleServiceTypeResponse    `json:"service_type"`
+	CreatedBy           *BasicUserResponse            `json:"created_by"`
+	UpdatedBy           *BasicUserResponse            `json:"updated_by"`
+	ServiceImages       []*ServiceImageResponse       `json:"images"`
+	OpeningHours        []*OpeningHourResponse        `json:"opening_hours"`
+	OptionGroups        []*ServiceOptionGroupResponse `json:"option_groups"`
+	SlotDurationMinutes *uint32                       `json:"slot_duration_minutes"`
+	SlotCapacity        *uint32                       `json:"slot_capacity"`
+	Stock               *uint32                       `json:"stock"`
+	LowStockThreshold   *uint32                       `json:"low_stock_threshold"`
+	IsSoldOut           bool                          `json:"is_sold_out"`
+	Translations        []*TranslationResponse        `json:"translations"`
 }
 
 type RequestTypeResponse struct {
-	ID         int64                     `json:"id"`
-	Name       string                    `json:"name"`
-	CreatedAt  time.Time                 `json:"created_at"`
-	UpdatedAt  time.Time                 `json:"updated_at"`
-	CreatedBy  *BasicUserResponse        `json:"created_by"`
-	UpdatedBy  *BasicUserResponse        `json:"updated_by"`
-	Department *SimpleDepartmentResponse `json:"department"`
+	ID                 int64                     `json:"id"`
+	Name               string                    `json:"name"`
+	AcceptSLAMinutes   *uint32                   `json:"accept_sla_minutes"`
+	CompleteSLAMinutes *uint32                   `json:"complete_sla_minutes"`
+	CreatedAt          time.Time                 `json:"created_at"`
+	UpdatedAt          time.Time                 `json:"updated_at"`
+	CreatedBy          *BasicUserResponse        `json:"created_by"`
+	UpdatedBy          *BasicUserResponse        `json:"updated_by"`
+	Department         *SimpleDepartmentResponse `json:"department"`
+	Translations       []*TranslationResponse    `json:"translations"`
 }
 
 type RoomTypeResponse struct {
@@ -252,42 +339,104 @@ type RoomResponse struct {
 }
 
 type SimpleOrderServiceResponse struct {
-	ID           int64                 `json:"id"`
-	Service      *BasicServiceResponse `json:"service"`
-	Quantity     uint32                `json:"quantity"`
-	TotalPrice   float64               `json:"total_price"`
-	Status       string                `json:"status"`
-	CreatedAt    time.Time             `json:"created_at"`
-	GuestNote    *string               `json:"guest_note"`
-	StaffNote    *string               `json:"staff_note"`
-	CancelReason *string               `json:"cancel_reason"`
-	RejectReason *string               `json:"reject_reason"`
+	ID             int64                         `json:"id"`
+	Service        *BasicServiceResponse         `json:"service"`
+	Quantity       uint32                        `json:"quantity"`
+	ListPrice      float64                       `json:"list_price"`
+	TotalPrice     float64                       `json:"total_price"`
+	PricingRule    *string                       `json:"pricing_rule"`
+	PromoCode      *string                       `json:"promo_code"`
+	Status         string                        `json:"status"`
+	SlotStartAt    *time.Time                    `json:"slot_start_at"`
+	ServiceOrderID *int64                        `json:"service_order_id"`
+	Options        []*OrderServiceOptionResponse `json:"options"`
+	CreatedAt      time.Time                     `json:"created_at"`
+	GuestNote      *string                       `json:"guest_note"`
+	StaffNote      *string                       `json:"staff_note"`
+	CancelReason   *string                       `json:"cancel_reason"`
+	RejectReason   *string                       `json:"reject_reason"`
 }
 
 type BasicOrderServiceResponse struct {
-	ID         int64     `json:"id"`
-	Service    string    `json:"service"`
-	Room       string    `json:"room"`
-	Quantity   uint32    `json:"quantity"`
-	TotalPrice float64   `json:"total_price"`
-	Status     string    `json:"status"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID         int64              `json:"id"`
+	Service    string             `json:"service"`
+	Room       string             `json:"room"`
+	Quantity   uint32             `json:"quantity"`
+	ListPrice  float64            `json:"list_price"`
+	TotalPrice float64            `json:"total_price"`
+	Status     string             `json:"status"`
+	Assignee   *BasicUserResponse `json:"assignee"`
+	CreatedAt  time.Time          `json:"created_at"`
 }
 
 type OrderServiceResponse struct {
-	ID           int64                   `json:"id"`
-	Service      *BasicServiceResponse   `json:"service"`
-	OrderRoom    *BasicOrderRoomResponse `json:"order_room"`
-	Quantity     uint32                  `json:"quantity"`
-	TotalPrice   float64                 `json:"total_price"`
-	Status       string                  `json:"status"`
-	CreatedAt    time.Time               `json:"created_at"`
-	UpdatedAt    time.Time               `json:"updated_at"`
-	GuestNote    *string                 `json:"guest_note"`
-	StaffNote    *string                 `json:"staff_note"`
-	CancelReason *string                 `json:"cancel_reason"`
-	RejectReason *string                 `json:"reject_reason"`
-	UpdatedBy    *BasicUserResponse      `json:"updated_by"`
+	ID             int64                         `json:"id"`
+	Service        *BasicServiceResponse         `json:"service"`
+	OrderRoom      *BasicOrderRoomResponse       `json:"order_room"`
+	Quantity       uint32                        `json:"quantity"`
+	ListPrice      float64                       `json:"list_price"`
+	TotalPrice     float64                       `json:"total_price"`
+	PricingRule    *string                       `json:"pricing_rule"`
+	PromoCode      *string                       `json:"promo_code"`
+	Status         string                        `json:"status"`
+	SlotStartAt    *time.Time                    `json:"slot_start_at"`
+	ServiceOrderID *int64                        `json:"service_order_id"`
+	Options        []*OrderServiceOptionResponse `json:"options"`
+	CreatedAt      time.Time                     `json:"created_at"`
+	UpdatedAt      time.Time                     `json:"updated_at"`
+	GuestNote      *string                       `json:"guest_note"`
+	StaffNote      *string                       `json:"staff_note"`
+	CancelReason   *string                       `json:"cancel_reason"`
+	RejectReason   *string                       `json:"reject_reason"`
+	UpdatedBy      *BasicUserResponse            `json:"updated_by"`
+	Assignee       *BasicUserResponse            `json:"assignee"`
+	AssignedAt     *time.Time                    `json:"assigned_at"`
+}
+
+type PricingRuleResponse struct {
+	ID            int64              `json:"id"`
+	Name          string             `json:"name"`
+	ServiceTypeID *int64             `json:"service_type_id"`
+	ServiceID     *int64             `json:"service_id"`
+	DayType       string             `json:"day_type"`
+	StartTime     *string            `json:"start_time"`
+	EndTime       *string            `json:"end_time"`
+	StartsAt      *time.Time         `json:"starts_at"`
+	EndsAt        *time.Time         `json:"ends_at"`
+	PromoCode     *string            `json:"promo_code"`
+	DiscountType  string             `json:"discount_type"`
+	DiscountValue float64            `json:"discount_value"`
+	IsActive      bool               `json:"is_active"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	CreatedBy     *BasicUserResponse `json:"created_by"`
+	UpdatedBy     *BasicUserResponse `json:"updated_by"`
+}
+
+type BasicServiceOrderResponse struct {
+	ID         int64      `json:"id"`
+	Room       string     `json:"room"`
+	LineCount  int        `json:"line_count"`
+	TotalPrice float64    `json:"total_price"`
+	Status     string     `json:"status"`
+	DeliveryAt *time.Time `json:"delivery_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type ServiceOrderResponse struct {
+	ID           int64                         `json:"id"`
+	OrderRoom    *BasicOrderRoomResponse       `json:"order_room"`
+	TotalPrice   float64                       `json:"total_price"`
+	Status       string                        `json:"status"`
+	GuestNote    *string                       `json:"guest_note"`
+	DeliveryAt   *time.Time                    `json:"delivery_at"`
+	StaffNote    *string                       `json:"staff_note"`
+	CancelReason *string                       `json:"cancel_reason"`
+	RejectReason *string                       `json:"reject_reason"`
+	UpdatedBy    *BasicUserResponse            `json:"updated_by"`
+	CreatedAt    time.Time                     `json:"created_at"`
+	UpdatedAt    time.Time                     `json:"updated_at"`
+	Lines        []*SimpleOrderServiceResponse `json:"lines"`
 }
 
 type BasicOrderRoomResponse struct {
@@ -334,51 +483,105 @@ type SimpleRequestResponse struct {
 	RequestType *SimpleRequestTypeResponse `json:"request_type"`
 	Content     string                     `json:"content"`
 	Status      string                     `json:"status"`
+	Priority    string                     `json:"priority"`
+	ScheduleID  *int64                     `json:"schedule_id"`
 	CreatedAt   time.Time                  `json:"created_at"`
 }
 
-type RequestResponse struct {
+type RequestScheduleResponse struct {
 	ID          int64                      `json:"id"`
 	RequestType *SimpleRequestTypeResponse `json:"request_type"`
-	OrderRoom   *BasicOrderRoomResponse    `json:"order_room"`
 	Content     string                     `json:"content"`
+	Priority    string                     `json:"priority"`
+	Recurrence  string                     `json:"recurrence"`
 	Status      string                     `json:"status"`
+	NextRunAt   time.Time                  `json:"next_run_at"`
+	LastRunAt   *time.Time                 `json:"last_run_at"`
+	CancelledAt *time.Time                 `json:"cancelled_at"`
 	CreatedAt   time.Time                  `json:"created_at"`
-	UpdatedAt   time.Time                  `json:"updated_at"`
-	UpdatedBy   *BasicUserResponse         `json:"updated_by"`
+}
+
+type RequestResponse struct {
+	ID                 int64                        `json:"id"`
+	RequestType        *SimpleRequestTypeResponse   `json:"request_type"`
+	OrderRoom          *BasicOrderRoomResponse      `json:"order_room"`
+	Content            string                       `json:"content"`
+	Status             string                       `json:"status"`
+	Priority           string                       `json:"priority"`
+	ScheduleID         *int64                       `json:"schedule_id"`
+	AcceptDueAt        *time.Time                   `json:"accept_due_at"`
+	CompleteDueAt      *time.Time                   `json:"complete_due_at"`
+	AcceptedAt         *time.Time                   `json:"accepted_at"`
+	DoneAt             *time.Time                   `json:"done_at"`
+	AcceptBreachedAt   *time.Time                   `json:"accept_breached_at"`
+	CompleteBreachedAt *time.Time                   `json:"complete_breached_at"`
+	Assignee           *BasicUserResponse           `json:"assignee"`
+	AssignedAt         *time.Time                   `json:"assigned_at"`
+	CreatedAt          time.Time                    `json:"created_at"`
+	UpdatedAt          time.Time                    `json:"updated_at"`
+	UpdatedBy          *BasicUserResponse           `json:"updated_by"`
+	Attachments        []*RequestAttachmentResponse `json:"attachments"`
+	Timeline           []*RequestNoteResponse       `json:"timeline"`
+}
+
+type RequestAttachmentResponse struct {
+	ID          int64  `json:"id"`
+	Key         string `json:"key"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url,omitempty"`
+}
+
+type RequestNoteResponse struct {
+	ID        int64              `json:"id"`
+	Type      string             `json:"type"`
+	Content   string             `json:"content"`
+	Staff     *BasicUserResponse `json:"staff"`
+	CreatedAt time.Time          `json:"created_at"`
 }
 
 type BasicRequestResponse struct {
-	ID          int64     `json:"id"`
-	RequestType string    `json:"request_type"`
-	Room        string    `json:"room"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          int64              `json:"id"`
+	RequestType string             `json:"request_type"`
+	Room        string             `json:"room"`
+	Status      string             `json:"status"`
+	Priority    string             `json:"priority"`
+	Assignee    *BasicUserResponse `json:"assignee"`
+	CreatedAt   time.Time          `json:"created_at"`
 }
 
 type SimpleMessageResponse struct {
-	ID         int64                   `json:"id"`
-	Content    *string                 `json:"content"`
-	ImageKey   *string                 `json:"image_key"`
-	SenderType string                  `json:"sender_type"`
-	Sender     *BasicUserResponse      `json:"sender"`
-	CreatedAt  time.Time               `json:"created_at"`
-	IsRead     bool                    `json:"is_read"`
-	ReadAt     *time.Time              `json:"read_at"`
-	StaffReads []*MessageStaffResponse `json:"staff_reads"`
+	ID                 int64                        `json:"id"`
+	Content            *string                      `json:"content"`
+	Language           *string                      `json:"language"`
+	TranslatedContent  *string                      `json:"translated_content"`
+	TranslatedLanguage *string                      `json:"translated_language"`
+	Attachments        []*MessageAttachmentResponse `json:"attachments"`
+	SenderType         string                       `json:"sender_type"`
+	Sender             *BasicUserResponse           `json:"sender"`
+	CreatedAt          time.Time                    `json:"created_at"`
+	DeliveredAt        *time.Time                   `json:"delivered_at"`
+	IsRead             bool                         `json:"is_read"`
+	ReadAt             *time.Time                   `json:"read_at"`
+	StaffReads         []*MessageStaffResponse      `json:"staff_reads"`
 }
 
 type MessageResponse struct {
-	ID         int64                   `json:"id"`
-	Content    *string                 `json:"content"`
-	ImageKey   *string                 `json:"image_key"`
-	SenderType string                  `json:"sender_type"`
-	Sender     *BasicUserResponse      `json:"sender"`
-	CreatedAt  time.Time               `json:"created_at"`
-	IsRead     bool                    `json:"is_read"`
-	ReadAt     *time.Time              `json:"read_at"`
-	StaffReads []*MessageStaffResponse `json:"staff_reads"`
-	ChatID     int64                   `json:"chat_id"`
+	ID                 int64                        `json:"id"`
+	Content            *string                      `json:"content"`
+	Language           *string                      `json:"language"`
+	TranslatedContent  *string                      `json:"translated_content"`
+	TranslatedLanguage *string                      `json:"translated_language"`
+	Attachments        []*MessageAttachmentResponse `json:"attachments"`
+	SenderType         string                       `json:"sender_type"`
+	Sender             *BasicUserResponse           `json:"sender"`
+	CreatedAt          time.Time                    `json:"created_at"`
+	DeliveredAt        *time.Time                   `json:"delivered_at"`
+	IsRead             bool                         `json:"is_read"`
+	ReadAt             *time.Time                   `json:"read_at"`
+	StaffReads         []*MessageStaffResponse      `json:"staff_reads"`
+	ChatID             int64                        `json:"chat_id"`
 }
 
 type MessageStaffResponse struct {
@@ -388,17 +591,95 @@ type MessageStaffResponse struct {
 }
 
 type SimpleChatResponse struct {
-	ID          int64                    `json:"id"`
-	OrderRoom   *SimpleOrderRoomResponse `json:"order_room"`
-	ExpiredAt   time.Time                `json:"expired_at"`
-	LastMessage *SimpleMessageResponse   `json:"last_message"`
+	ID          int64                     `json:"id"`
+	OrderRoom   *SimpleOrderRoomResponse  `json:"order_room"`
+	ExpiredAt   time.Time                 `json:"expired_at"`
+	Assignee    *BasicUserResponse        `json:"assignee"`
+	AssignedAt  *time.Time                `json:"assigned_at"`
+	Department  *SimpleDepartmentResponse `json:"department"`
+	EscalatedAt *time.Time                `json:"escalated_at"`
+	LastMessage *SimpleMessageResponse    `json:"last_message"`
 }
 
 type SimpleChatWithMessageResponse struct {
-	ID        int64                    `json:"id"`
-	OrderRoom *SimpleOrderRoomResponse `json:"order_room"`
-	ExpiredAt time.Time                `json:"expired_at"`
-	Messages  []*SimpleMessageResponse `json:"messages"`
+	ID          int64                     `json:"id"`
+	OrderRoom   *SimpleOrderRoomResponse  `json:"order_room"`
+	ExpiredAt   time.Time                 `json:"expired_at"`
+	Assignee    *BasicUserResponse        `json:"assignee"`
+	AssignedAt  *time.Time                `json:"assigned_at"`
+	Department  *SimpleDepartmentResponse `json:"department"`
+	EscalatedAt *time.Time                `json:"escalated_at"`
+	Messages    []*SimpleMessageResponse  `json:"messages"`
+	Transfers   []*ChatTransferResponse   `json:"transfers"`
+}
+
+type CannedResponseResponse struct {
+	ID         int64                     `json:"id"`
+	Title      string                    `json:"title"`
+	Shortcut   string                    `json:"shortcut"`
+	Content    string                    `json:"content"`
+	Department *SimpleDepartmentResponse `json:"department"`
+	CreatedAt  time.Time                 `json:"created_at"`
+	UpdatedAt  time.Time                 `json:"updated_at"`
+	CreatedBy  *BasicUserResponse        `json:"created_by"`
+	UpdatedBy  *BasicUserResponse        `json:"updated_by"`
+}
+
+type FAQResponse struct {
+	ID        int64              `json:"id"`
+	Question  string             `json:"question"`
+	Answer    string             `json:"answer"`
+	Keywords  []string           `json:"keywords"`
+	IsActive  bool               `json:"is_active"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	CreatedBy *BasicUserResponse `json:"created_by"`
+	UpdatedBy *BasicUserResponse `json:"updated_by"`
+}
+
+type MessageSearchResponse struct {
+	ID            int64     `json:"id"`
+	ChatID        int64     `json:"chat_id"`
+	SenderType    string    `json:"sender_type"`
+	Content       string    `json:"content"`
+	Highlight     string    `json:"highlight"`
+	Rank          float64   `json:"rank"`
+	CreatedAt     time.Time `json:"created_at"`
+	OrderRoomID   int64     `json:"order_room_id"`
+	RoomName      string    `json:"room_name"`
+	BookingNumber string    `json:"booking_number"`
+	GuestFullName string    `json:"guest_full_name"`
+}
+
+type ChatTranscriptResponse struct {
+	ChatID        int64                        `json:"chat_id"`
+	RoomName      string                       `json:"room_name"`
+	BookingNumber string                       `json:"booking_number"`
+	GuestFullName string                       `json:"guest_full_name"`
+	CheckIn       *time.Time                   `json:"check_in"`
+	CheckOut      *time.Time                   `json:"check_out"`
+	ExportedAt    time.Time                    `json:"exported_at"`
+	Messages      []*TranscriptMessageResponse `json:"messages"`
+}
+
+type TranscriptMessageResponse struct {
+	ID          int64     `json:"id"`
+	SenderType  string    `json:"sender_type"`
+	SenderName  string    `json:"sender_name"`
+	Content     *string   `json:"content"`
+	Attachments []string  `json:"attachments"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ChatTransferResponse struct {
+	ID           int64                     `json:"id"`
+	Action       string                    `json:"action"`
+	FromStaff    *BasicUserResponse        `json:"from_staff"`
+	ToStaff      *BasicUserResponse        `json:"to_staff"`
+	ToDepartment *SimpleDepartmentResponse `json:"to_department"`
+	Note         *string                   `json:"note"`
+	CreatedBy    *BasicUserResponse        `json:"created_by"`
+	CreatedAt    time.Time                 `json:"created_at"`
 }
 
 type BasicChatResponse struct {
@@ -415,13 +696,28 @@ type BasicChatWithMessageResponse struct {
 }
 
 type BasicMessageResponse struct {
-	ID         int64      `json:"id"`
-	Content    *string    `json:"content"`
-	ImageKey   *string    `json:"image_key"`
-	SenderType string     `json:"sender_type"`
-	CreatedAt  time.Time  `json:"created_at"`
-	IsRead     bool       `json:"is_read"`
-	ReadAt     *time.Time `json:"read_at"`
+	ID                 int64                        `json:"id"`
+	Content            *string                      `json:"content"`
+	Language           *string                      `json:"language"`
+	TranslatedContent  *string                      `json:"translated_content"`
+	TranslatedLanguage *string                      `json:"translated_language"`
+	Attachments        []*MessageAttachmentResponse `json:"attachments"`
+	SenderType         string                       `json:"sender_type"`
+	CreatedAt          time.Time                    `json:"created_at"`
+	DeliveredAt        *time.Time                   `json:"delivered_at"`
+	IsRead             bool                         `json:"is_read"`
+	ReadAt             *time.Time                   `json:"read_at"`
+}
+
+type MessageAttachmentResponse struct {
+	ID           int64   `json:"id"`
+	Key          string  `json:"key"`
+	FileName     string  `json:"file_name"`
+	ContentType  string  `json:"content_type"`
+	Size         int64   `json:"size"`
+	ThumbnailKey *string `json:"thumbnail_key"`
+	URL          string  `json:"url,omitempty"`
+	ThumbnailURL string  `json:"thumbnail_url,omitempty"`
 }
 
 type BasicNotificationResponse struct {
@@ -443,25 +739,65 @@ type WSResponse struct {
 type UpdateReadMessagesResponse struct {
 	ChatID     int64              `json:"chat_id"`
 	ReaderType string             `json:"reader_type"`
-	ReadAt     *time.Time          `json:"read_at"`
+	ReadAt     *time.Time         `json:"read_at"`
 	Reader     *BasicUserResponse `json:"reader"`
 }
 
+type UpdateDeliveredMessagesResponse struct {
+	ChatID       int64     `json:"chat_id"`
+	ReceiverType string    `json:"receiver_type"`
+	DeliveredAt  time.Time `json:"delivered_at"`
+}
+
+type ChatTypingResponse struct {
+	ChatID     int64              `json:"chat_id"`
+	SenderType string             `json:"sender_type"`
+	Sender     *BasicUserResponse `json:"sender"`
+	IsTyping   bool               `json:"is_typing"`
+}
+
+type ChatPresenceResponse struct {
+	ChatID        int64                `json:"chat_id"`
+	ViewingStaffs []*BasicUserResponse `json:"viewing_staffs"`
+	OnlineStaffs  int                  `json:"online_staffs"`
+	GuestOnline   bool                 `json:"guest_online"`
+}
+
 type SimpleReviewResponse struct {
-	ID        int64     `json:"id"`
-	Star      uint32    `json:"star"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          int64      `json:"id"`
+	Star        uint32     `json:"star"`
+	Content     string     `json:"content"`
+	CreatedAt   time.Time  `json:"created_at"`
+	Response    *string    `json:"response"`
+	RespondedAt *time.Time `json:"responded_at"`
+}
+
+type PublicReviewResponse struct {
+	ID          int64      `json:"id"`
+	Star        uint32     `json:"star"`
+	Content     string     `json:"content"`
+	IsFeatured  bool       `json:"is_featured"`
+	CreatedAt   time.Time  `json:"created_at"`
+	Response    *string    `json:"response"`
+	RespondedAt *time.Time `json:"responded_at"`
 }
 
 type ReviewResponse struct {
-	ID          int64     `json:"id"`
-	Email       string    `json:"email"`
-	Star        uint32    `json:"star"`
-	Content     string    `json:"content"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	OrderRoomID int64     `json:"order_room_id"`
+	ID               int64              `json:"id"`
+	Email            string             `json:"email"`
+	Star             uint32             `json:"star"`
+	Content          string             `json:"content"`
+	Status           string             `json:"status"`
+	ModerationReason *string            `json:"moderation_reason"`
+	ModeratedBy      *BasicUserResponse `json:"moderated_by"`
+	ModeratedAt      *time.Time         `json:"moderated_at"`
+	IsFeatured       bool               `json:"is_featured"`
+	Response         *string            `json:"response"`
+	RespondedBy      *BasicUserResponse `json:"responded_by"`
+	RespondedAt      *time.Time         `json:"responded_at"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+	OrderRoomID      int64              `json:"order_room_id"`
 }
 
 type DashboardResponse struct {
@@ -474,14 +810,31 @@ type DashboardResponse struct {
 
 	AverageReviewRating float64 `json:"average_review_rating"`
 
+	ServiceListRevenue float64 `json:"service_list_revenue"`
+	ServiceRevenue     float64 `json:"service_revenue"`
+	ServiceDiscount    float64 `json:"service_discount"`
+
 	BookingSourceStats   []*ChartData                `json:"booking_source_stats"`
 	ServiceUsageStats    []*ChartData                `json:"service_usage_stats"`
 	PopularRoomTypeStats []*PopularRoomTypeChartData `json:"popular_room_type_stats"`
 	RevenueSourceStats   []*ChartData                `json:"revenue_source_stats"`
 
+	PricingRuleRevenueStats []*PricingRuleRevenueChartData `json:"pricing_rule_revenue_stats"`
+
 	OrderServiceStats []*StatusChartResponse       `json:"order_service_stats"`
 	RequestStats      []*StatusChartResponse       `json:"request_stats"`
 	DailyBookingStats []*DailyBookingChartResponse `json:"daily_booking_stats"`
+
+	SLAComplianceStats []*SLAComplianceResponse `json:"sla_compliance_stats"`
+}
+
+type SLAComplianceResponse struct {
+	DepartmentID   int64   `json:"department_id"`
+	DepartmentName string  `json:"department_name"`
+	Total          int64   `json:"total"`
+	Met            int64   `json:"met"`
+	Breached       int64   `json:"breached"`
+	Compliance     float64 `json:"compliance"`
 }
 
 type StatusChartResponse struct {
@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v1-be/internal/config"
+	"github.com/InstaySystem/is_v1-be/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultChatWorkerInterval = time.Hour
+	chatWorkerTimeout         = 5 * time.Minute
+)
+
+type ChatWorker struct {
+	cfg     *config.Config
+	chatSvc service.ChatService
+	logger  *zap.Logger
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func NewChatWorker(
+	cfg *config.Config,
+	chatSvc service.ChatService,
+	logger *zap.Logger,
+) *ChatWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ChatWorker{
+		cfg,
+		chatSvc,
+		logger,
+		ctx,
+		cancel,
+	}
+}
+
+func (w *ChatWorker) Start() {
+	go w.run()
+}
+
+func (w *ChatWorker) Stop() {
+	w.cancel()
+}
+
+func (w *ChatWorker) run() {
+	interval := w.cfg.Chat.RetentionInterval
+	if interval <= 0 {
+		interval = defaultChatWorkerInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		w.process()
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *ChatWorker) process() {
+	ctx, cancel := context.WithTimeout(w.ctx, chatWorkerTimeout)
+	defer cancel()
+
+	if err := w.chatSvc.SendCheckoutTranscripts(ctx); err != nil {
+		w.logger.Error("send checkout transcripts failed", zap.Error(err))
+	}
+
+	if err := w.chatSvc.ApplyRetention(ctx); err != nil {
+		w.logger.Error("apply chat retention failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v1-be/internal/config"
+	"github.com/InstaySystem/is_v1-be/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultFileWorkerInterval = time.Hour
+	fileWorkerTimeout         = time.Minute
+)
+
+type FileWorker struct {
+	cfg        *config.Config
+	fileSvc    service.FileService
+	serviceSvc service.ServiceService
+	logger     *zap.Logger
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func NewFileWorker(
+	cfg *config.Config,
+	fileSvc service.FileService,
+	serviceSvc service.ServiceService,
+	logger *zap.Logger,
+) *FileWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &FileWorker{
+		cfg,
+		fileSvc,
+		serviceSvc,
+		logger,
+		ctx,
+		cancel,
+	}
+}
+
+func (w *FileWorker) Start() {
+	go w.run()
+}
+
+func (w *FileWorker) Stop() {
+	w.cancel()
+}
+
+func (w *FileWorker) run() {
+	interval := w.cfg.Upload.SweepInterval
+	if interval <= 0 {
+		interval = defaultFileWorkerInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		w.process()
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *FileWorker) process() {
+	w.sweepOrphanedUploads()
+	w.requeuePendingImages()
+}
+
+func (w *FileWorker) sweepOrphanedUploads() {
+	ctx, cancel := context.WithTimeout(w.ctx, fileWorkerTimeout)
+	defer cancel()
+
+	if err := w.fileSvc.SweepOrphanedUploads(ctx); err != nil {
+		w.logger.Error("sweep orphaned uploads failed", zap.Error(err))
+	}
+}
+
+func (w *FileWorker) requeuePendingImages() {
+	ctx, cancel := context.WithTimeout(w.ctx, fileWorkerTimeout)
+	defer cancel()
+
+	if err := w.serviceSvc.RequeuePendingServiceImages(ctx); err != nil {
+		w.logger.Error("requeue pending service images failed", zap.Error(err))
+	}
+}
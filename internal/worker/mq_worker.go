@@ -4,49 +4,57 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
-	"cloud.google.com/go/storage"
 	"github.com/InstaySystem/is_v1-be/internal/common"
 	"github.com/InstaySystem/is_v1-be/internal/config"
 	"github.com/InstaySystem/is_v1-be/internal/hub"
 	"github.com/InstaySystem/is_v1-be/internal/provider/mq"
 	"github.com/InstaySystem/is_v1-be/internal/provider/smtp"
+	"github.com/InstaySystem/is_v1-be/internal/provider/storage"
+	"github.com/InstaySystem/is_v1-be/internal/service"
 	"github.com/InstaySystem/is_v1-be/internal/types"
 	"go.uber.org/zap"
 )
 
 type MQWorker struct {
-	cfg    *config.Config
-	mq     mq.MessageQueueProvider
-	smtp   smtp.SMTPProvider
-	gcs    *storage.Client
-	logger *zap.Logger
-	sseHub *hub.SSEHub
+	cfg        *config.Config
+	mq         mq.MessageQueueProvider
+	smtp       smtp.SMTPProvider
+	storage    storage.StorageProvider
+	logger     *zap.Logger
+	sseHub     *hub.SSEHub
+	serviceSvc service.ServiceService
 }
 
 func NewMQWorker(
 	cfg *config.Config,
 	mq mq.MessageQueueProvider,
 	smtp smtp.SMTPProvider,
-	gcs *storage.Client,
+	storage storage.StorageProvider,
 	logger *zap.Logger,
 	sseHub *hub.SSEHub,
+	serviceSvc service.ServiceService,
 ) *MQWorker {
 	return &MQWorker{
 		cfg,
 		mq,
 		smtp,
-		gcs,
+		storage,
 		logger,
 		sseHub,
+		serviceSvc,
 	}
 }
 
 func (w *MQWorker) Start() {
 	go w.startSendAuthEmail()
+	go w.startSendTranscriptEmail()
 	go w.startDeleteFile()
+	go w.startProcessImage()
 	go w.startSendServiceNotification()
 	go w.startSendRequestNotification()
+	go w.startSendChatNotification()
 }
 
 func (w *MQWorker) startSendAuthEmail() {
@@ -67,15 +75,31 @@ func (w *MQWorker) startSendAuthEmail() {
 	}
 }
 
+func (w *MQWorker) startSendTranscriptEmail() {
+	if err := w.mq.ConsumeMessage(common.QueueNameTranscriptEmail, common.ExchangeEmail, common.RoutingKeyTranscriptEmail, func(body []byte) error {
+		var emailMsg types.TranscriptEmailMessage
+		if err := json.Unmarshal(body, &emailMsg); err != nil {
+			return err
+		}
+
+		if err := w.smtp.TranscriptEmail(emailMsg.To, emailMsg.Subject, emailMsg.GuestName, emailMsg.RoomName, emailMsg.Lines); err != nil {
+			return err
+		}
+
+		w.logger.Info(fmt.Sprintf("Transcript email sent successfully to: %s", emailMsg.To))
+		return nil
+	}); err != nil {
+		w.logger.Error("start consumer send transcript email failed", zap.Error(err))
+	}
+}
+
 func (w *MQWorker) startDeleteFile() {
 	if err := w.mq.ConsumeMessage(common.QueueNameDeleteFile, common.ExchangeFile, common.RoutingKeyDeleteFile, func(body []byte) error {
 		key := string(body)
 
 		ctx := context.Background()
 
-		obj := w.gcs.Bucket(w.cfg.GCS.Bucket).Object(key)
-
-		if _, err := obj.Attrs(ctx); err != nil {
+		if _, err := w.storage.Attrs(ctx, key); err != nil {
 			if err == storage.ErrObjectNotExist {
 				w.logger.Warn("file not found", zap.String("key", key))
 				return nil
@@ -84,7 +108,7 @@ func (w *MQWorker) startDeleteFile() {
 			return err
 		}
 
-		if err := obj.Delete(ctx); err != nil {
+		if err := w.storage.Delete(ctx, key); err != nil {
 			w.logger.Error("file delete failed", zap.Error(err))
 			return err
 		}
@@ -95,6 +119,27 @@ func (w *MQWorker) startDeleteFile() {
 	}
 }
 
+func (w *MQWorker) startProcessImage() {
+	if err := w.mq.ConsumeMessage(common.QueueNameProcessImage, common.ExchangeFile, common.RoutingKeyProcessImage, func(body []byte) error {
+		var processImageMsg types.ProcessImageMessage
+		if err := json.Unmarshal(body, &processImageMsg); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		if err := w.serviceSvc.ProcessServiceImage(ctx, processImageMsg.ServiceImageID); err != nil {
+			return err
+		}
+
+		w.logger.Info("Service image processed successfully", zap.Int64("id", processImageMsg.ServiceImageID))
+		return nil
+	}); err != nil {
+		w.logger.Error("start consumer process image failed", zap.Error(err))
+	}
+}
+
 func (w *MQWorker) startSendServiceNotification() {
 	if err := w.mq.ConsumeMessage(common.QueueNameServiceNotification, common.ExchangeNotification, common.RoutingKeyServiceNotification, func(body []byte) error {
 		var serviceNotificationMsg types.NotificationMessage
@@ -158,3 +203,35 @@ func (w *MQWorker) startSendRequestNotification() {
 		w.logger.Error("start consumer send request notification failed", zap.Error(err))
 	}
 }
+
+func (w *MQWorker) startSendChatNotification() {
+	if err := w.mq.ConsumeMessage(common.QueueNameChatNotification, common.ExchangeNotification, common.RoutingKeyChatNotification, func(body []byte) error {
+		var chatNotificationMsg types.NotificationMessage
+		if err := json.Unmarshal(body, &chatNotificationMsg); err != nil {
+			return err
+		}
+
+		data := map[string]any{
+			"content":      chatNotificationMsg.Content,
+			"content_id":   chatNotificationMsg.ContentID,
+			"content_type": chatNotificationMsg.Type,
+			"receiver":     chatNotificationMsg.Receiver,
+		}
+
+		event := types.SSEEventData{
+			Event:        "chat",
+			Type:         chatNotificationMsg.Receiver,
+			DepartmentID: chatNotificationMsg.DepartmentID,
+			Data:         data,
+		}
+
+		for _, clientID := range chatNotificationMsg.ReceiverIDs {
+			w.sseHub.SendToClient(clientID, event)
+		}
+
+		w.logger.Info("Chat notification sent successfully")
+		return nil
+	}); err != nil {
+		w.logger.Error("start consumer send chat notification failed", zap.Error(err))
+	}
+}
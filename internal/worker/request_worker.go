@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/InstaySystem/is_v1-be/internal/config"
+	"github.com/InstaySystem/is_v1-be/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRequestWorkerInterval = time.Minute
+	requestWorkerTimeout         = time.Minute
+)
+
+type RequestWorker struct {
+	cfg        *config.Config
+	requestSvc service.RequestService
+	logger     *zap.Logger
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func NewRequestWorker(
+	cfg *config.Config,
+	requestSvc service.RequestService,
+	logger *zap.Logger,
+) *RequestWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &RequestWorker{
+		cfg,
+		requestSvc,
+		logger,
+		ctx,
+		cancel,
+	}
+}
+
+func (w *RequestWorker) Start() {
+	go w.run()
+}
+
+func (w *RequestWorker) Stop() {
+	w.cancel()
+}
+
+func (w *RequestWorker) run() {
+	interval := w.cfg.SLA.SweepInterval
+	if interval <= 0 {
+		interval = defaultRequestWorkerInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		w.process()
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *RequestWorker) process() {
+	ctx, cancel := context.WithTimeout(w.ctx, requestWorkerTimeout)
+	defer cancel()
+
+	if err := w.requestSvc.EscalateSLABreaches(ctx); err != nil {
+		w.logger.Error("escalate sla breaches failed", zap.Error(err))
+	}
+
+	if err := w.requestSvc.MaterializeScheduledRequests(ctx); err != nil {
+		w.logger.Error("materialize scheduled requests failed", zap.Error(err))
+	}
+}
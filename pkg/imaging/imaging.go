@@ -0,0 +1,245 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"slices"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+
+	// MaxPixels bounds the decoded size of an image so a small, highly
+	// compressed upload cannot exhaust memory when it is decoded.
+	MaxPixels = 40_000_000
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("imaging: unsupported image format")
+	ErrInvalidDimensions = errors.New("imaging: invalid image dimensions")
+	ErrTooManyPixels     = errors.New("imaging: image has too many pixels")
+)
+
+type Processor interface {
+	Thumbnail(data []byte, maxSize int) ([]byte, error)
+	Process(data []byte, maxSize int, widths []int) (*Result, error)
+}
+
+// Result is the outcome of Process. Original is the source re-encoded in its
+// own format without any metadata, and Variants holds a JPEG and a WebP
+// rendition for every requested width that does not upscale the image.
+type Result struct {
+	Format   string
+	Width    int
+	Height   int
+	Original []byte
+	Variants []*Variant
+}
+
+type Variant struct {
+	Format string
+	Width  int
+	Height int
+	Data   []byte
+}
+
+type processorImpl struct {
+	quality int
+}
+
+func NewProcessor(quality int) Processor {
+	return &processorImpl{quality}
+}
+
+func (p *processorImpl) Thumbnail(data []byte, maxSize int) ([]byte, error) {
+	if _, err := checkConfig(data); err != nil {
+		return nil, err
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	dst := resize(src, maxSize)
+
+	var buf bytes.Buffer
+	if err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: p.quality}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (p *processorImpl) Process(data []byte, maxSize int, widths []int) (*Result, error) {
+	format, err := checkConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	// Decoding and re-encoding drops EXIF and every other metadata block, so
+	// the orientation has to be baked into the pixels first.
+	img := resize(src, maxSize)
+	if format == FormatJPEG {
+		img = orient(img, jpegOrientation(data))
+	}
+
+	original, err := p.encode(img, format)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	result := &Result{
+		Format:   format,
+		Width:    bounds.Dx(),
+		Height:   bounds.Dy(),
+		Original: original,
+	}
+
+	sizes := slices.Sorted(slices.Values(widths))
+	sizes = slices.Compact(slices.DeleteFunc(sizes, func(w int) bool {
+		return w <= 0 || w > result.Width
+	}))
+	if len(sizes) == 0 {
+		sizes = []int{result.Width}
+	}
+
+	for _, width := range sizes {
+		scaled := img
+		if width < result.Width {
+			height := max(1, result.Height*width/result.Width)
+			scaled = image.NewRGBA(image.Rect(0, 0, width, height))
+			draw.CatmullRom.Scale(scaled, scaled.Bounds(), img, bounds, draw.Over, nil)
+		}
+
+		for _, f := range []string{FormatJPEG, FormatWebP} {
+			out, err := p.encode(scaled, f)
+			if err != nil {
+				return nil, err
+			}
+			result.Variants = append(result.Variants, &Variant{
+				Format: f,
+				Width:  scaled.Bounds().Dx(),
+				Height: scaled.Bounds().Dy(),
+				Data:   out,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// checkConfig reads only the image header and rejects anything that is not a
+// supported format or would decode to more than MaxPixels.
+func checkConfig(data []byte) (string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", ErrUnsupportedFormat
+	}
+	if format != FormatJPEG && format != FormatPNG && format != FormatWebP {
+		return "", ErrUnsupportedFormat
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return "", ErrInvalidDimensions
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return "", ErrTooManyPixels
+	}
+
+	return format, nil
+}
+
+func (p *processorImpl) encode(img *image.RGBA, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	switch format {
+	case FormatJPEG:
+		if err := jpeg.Encode(&buf, flatten(img), &jpeg.Options{Quality: p.quality}); err != nil {
+			return nil, err
+		}
+	case FormatPNG:
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+	case FormatWebP:
+		return encodeWebP(img, p.quality)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ContentType returns the MIME type for one of the Format constants.
+func ContentType(format string) string {
+	switch format {
+	case FormatJPEG:
+		return "image/jpeg"
+	case FormatPNG:
+		return "image/png"
+	case FormatWebP:
+		return "image/webp"
+	}
+	return "application/octet-stream"
+}
+
+// Extension returns the file extension, without the dot, for one of the
+// Format constants.
+func Extension(format string) string {
+	if format == FormatJPEG {
+		return "jpg"
+	}
+	return format
+}
+
+func resize(src image.Image, maxSize int) *image.RGBA {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width <= maxSize && height <= maxSize {
+		dst := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+		return dst
+	}
+
+	if width >= height {
+		height = max(1, height*maxSize/width)
+		width = maxSize
+	} else {
+		width = max(1, width*maxSize/height)
+		height = maxSize
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	return dst
+}
+
+// flatten composites img onto white, since JPEG has no alpha channel and
+// transparent pixels would otherwise turn black.
+func flatten(img *image.RGBA) *image.RGBA {
+	if img.Opaque() {
+		return img
+	}
+
+	dst := image.NewRGBA(img.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	return dst
+}
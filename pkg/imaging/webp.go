@@ -0,0 +1,930 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"math"
+	"math/bits"
+)
+
+// This file implements a lossy WebP encoder. It produces a single VP8 key
+// frame (RFC 6386) using 16x16 luma and 8x8 chroma intra prediction, which
+// keeps the encoder small while still compressing photos well.
+
+const (
+	vp8NumPlanes   = 4
+	vp8NumBands    = 8
+	vp8NumContexts = 3
+	vp8NumProbs    = 11
+
+	vp8PlaneY1WithY2 = 0
+	vp8PlaneY2       = 1
+	vp8PlaneUV       = 2
+
+	vp8MaxDimension = 16383
+	vp8MaxLevel     = 2048
+)
+
+const (
+	vp8PredDC = iota
+	vp8PredTM
+	vp8PredVE
+	vp8PredHE
+)
+
+var (
+	vp8Bands   = [17]uint8{0, 1, 2, 3, 6, 4, 5, 6, 6, 6, 6, 6, 6, 6, 6, 7, 0}
+	vp8Zigzag  = [16]uint8{0, 1, 4, 8, 5, 2, 3, 6, 9, 12, 13, 10, 7, 11, 14, 15}
+	vp8Cat3456 = [4][]uint8{
+		{173, 148, 140},
+		{176, 155, 140, 135},
+		{180, 157, 141, 134, 130},
+		{254, 254, 243, 230, 196, 177, 153, 140, 133, 130, 129},
+	}
+)
+
+type vp8Matrix struct {
+	q    [2]int32
+	bias [2]int32
+}
+
+type vp8Macroblock struct {
+	yMode  uint8
+	uvMode uint8
+	skip   bool
+	y2     [16]int16
+	y      [16][16]int16
+	uv     [8][16]int16
+}
+
+type vp8NzContext struct {
+	nzY16 uint8
+	nz    uint8
+}
+
+type vp8Encoder struct {
+	width, height int
+	mbw, mbh      int
+	yStride       int
+	cStride       int
+	src           [3][]uint8
+	rec           [3][]uint8
+	qIndex        int
+	y1, y2, uv    vp8Matrix
+	mbs           []vp8Macroblock
+	tokenProb     [vp8NumPlanes][vp8NumBands][vp8NumContexts][vp8NumProbs]uint8
+	updated       [vp8NumPlanes][vp8NumBands][vp8NumContexts][vp8NumProbs]bool
+}
+
+// encodeWebP encodes img as a lossy WebP. quality ranges from 1 to 100.
+// Transparent pixels are composited onto white because a plain VP8
+// bitstream carries no alpha channel.
+func encodeWebP(img image.Image, quality int) ([]byte, error) {
+	b := img.Bounds()
+	if b.Dx() < 1 || b.Dy() < 1 || b.Dx() > vp8MaxDimension || b.Dy() > vp8MaxDimension {
+		return nil, ErrInvalidDimensions
+	}
+
+	e := &vp8Encoder{width: b.Dx(), height: b.Dy()}
+	e.mbw = (e.width + 15) / 16
+	e.mbh = (e.height + 15) / 16
+	e.yStride = e.mbw * 16
+	e.cStride = e.mbw * 8
+	e.setQuality(quality)
+	e.importImage(img)
+
+	e.mbs = make([]vp8Macroblock, e.mbw*e.mbh)
+	for mby := 0; mby < e.mbh; mby++ {
+		for mbx := 0; mbx < e.mbw; mbx++ {
+			e.encodeMacroblock(mbx, mby)
+		}
+	}
+
+	var stats vp8TokenStats
+	e.writeTokens(&stats)
+	e.tokenProb = vp8DefaultTokenProb
+	e.optimizeTokenProbs(&stats)
+
+	tokens := newBoolEncoder()
+	e.writeTokens(&vp8TokenWriter{tokens, &e.tokenProb})
+
+	header := newBoolEncoder()
+	e.writeHeader(header)
+
+	return e.container(header.finish(), tokens.finish()), nil
+}
+
+func (e *vp8Encoder) setQuality(quality int) {
+	// Map quality onto the quantizer index with the same curve as libwebp,
+	// so a given setting yields comparable results across encoders.
+	c := float64(min(max(quality, 1), 100)) / 100
+	if c < 0.75 {
+		c *= 2.0 / 3
+	} else {
+		c = 2*c - 1
+	}
+	q := int(math.Round(127 * (1 - math.Cbrt(c))))
+	e.qIndex = min(max(q, 0), 127)
+
+	y2AC := int32(vp8ACTable[e.qIndex]) * 155 / 100
+	e.y1 = newVP8Matrix(int32(vp8DCTable[e.qIndex]), int32(vp8ACTable[e.qIndex]), 96, 110)
+	e.y2 = newVP8Matrix(int32(vp8DCTable[e.qIndex])*2, max(y2AC, 8), 96, 108)
+	e.uv = newVP8Matrix(int32(vp8DCTable[min(e.qIndex, 117)]), int32(vp8ACTable[e.qIndex]), 110, 115)
+}
+
+func newVP8Matrix(dc, ac, dcBias, acBias int32) vp8Matrix {
+	return vp8Matrix{
+		q:    [2]int32{dc, ac},
+		bias: [2]int32{dc * dcBias / 256, ac * acBias / 256},
+	}
+}
+
+// importImage converts img to limited-range BT.601 YCbCr 4:2:0, which is what
+// WebP decoders expect, padding the planes to whole macroblocks by
+// replicating the right and bottom edges.
+func (e *vp8Encoder) importImage(img image.Image) {
+	b := img.Bounds()
+	cw, ch := e.mbw*8, e.mbh*8
+	for i := range e.src {
+		if i == 0 {
+			e.src[i] = make([]uint8, e.yStride*e.mbh*16)
+			e.rec[i] = make([]uint8, e.yStride*e.mbh*16)
+		} else {
+			e.src[i] = make([]uint8, e.cStride*ch)
+			e.rec[i] = make([]uint8, e.cStride*ch)
+		}
+	}
+
+	rgb := make([][3]int32, e.yStride*e.mbh*16)
+	for y := 0; y < e.mbh*16; y++ {
+		sy := min(y, e.height-1)
+		for x := 0; x < e.yStride; x++ {
+			sx := min(x, e.width-1)
+			c := color.NRGBAModel.Convert(img.At(b.Min.X+sx, b.Min.Y+sy)).(color.NRGBA)
+			a := int32(c.A)
+			// Composite onto white.
+			r := (int32(c.R)*a + 255*(255-a) + 127) / 255
+			g := (int32(c.G)*a + 255*(255-a) + 127) / 255
+			bl := (int32(c.B)*a + 255*(255-a) + 127) / 255
+			rgb[y*e.yStride+x] = [3]int32{r, g, bl}
+			e.src[0][y*e.yStride+x] = uint8((16839*r + 33059*g + 6420*bl + (16 << 16) + (1 << 15)) >> 16)
+		}
+	}
+
+	for y := 0; y < ch; y++ {
+		for x := 0; x < cw; x++ {
+			var r, g, bl int32
+			for _, p := range [4]int{
+				(2*y)*e.yStride + 2*x,
+				(2*y)*e.yStride + 2*x + 1,
+				(2*y+1)*e.yStride + 2*x,
+				(2*y+1)*e.yStride + 2*x + 1,
+			} {
+				r += rgb[p][0]
+				g += rgb[p][1]
+				bl += rgb[p][2]
+			}
+			e.src[1][y*e.cStride+x] = clip8((-9719*r - 19081*g + 28800*bl + (128 << 18) + (1 << 17)) >> 18)
+			e.src[2][y*e.cStride+x] = clip8((28800*r - 24116*g - 4684*bl + (128 << 18) + (1 << 17)) >> 18)
+		}
+	}
+}
+
+func (e *vp8Encoder) encodeMacroblock(mbx, mby int) {
+	mb := &e.mbs[mby*e.mbw+mbx]
+
+	// Luma: pick the 16x16 predictor closest to the source.
+	var pred, best [256]uint8
+	bestSSE := -1
+	for _, mode := range e.candidateModes(mbx, mby) {
+		e.predict(&pred, 0, mbx, mby, 16, mode)
+		if sse := e.sse(pred[:], 0, mbx, mby, 16); bestSSE < 0 || sse < bestSSE {
+			bestSSE, best, mb.yMode = sse, pred, mode
+		}
+	}
+
+	var coeffs [16][16]int32
+	for n := range 16 {
+		e.forwardDCT(&coeffs[n], best[:], 0, mbx*16+(n%4)*4, mby*16+(n/4)*4, 16, (n/4)*4*16+(n%4)*4)
+	}
+
+	var dcs, y2 [16]int32
+	for n := range 16 {
+		dcs[n] = coeffs[n][0]
+	}
+	forwardWHT(&dcs, &y2)
+	var y2Deq [16]int32
+	for i := range 16 {
+		mb.y2[i], y2Deq[i] = e.y2.quantize(y2[i], i)
+	}
+	var dcOut [16]int32
+	inverseWHT(&y2Deq, &dcOut)
+
+	nonZero := false
+	for _, l := range mb.y2 {
+		nonZero = nonZero || l != 0
+	}
+	for n := range 16 {
+		var deq [16]int32
+		deq[0] = dcOut[n]
+		for i := 1; i < 16; i++ {
+			mb.y[n][i], deq[i] = e.y1.quantize(coeffs[n][i], i)
+			nonZero = nonZero || mb.y[n][i] != 0
+		}
+		inverseDCT(&deq, best[:], (n/4)*4*16+(n%4)*4, 16)
+	}
+	e.store(best[:], 0, mbx, mby, 16)
+
+	// Chroma: both planes share one predictor mode.
+	var predU, predV, bestU, bestV [64]uint8
+	bestSSE = -1
+	for _, mode := range e.candidateModes(mbx, mby) {
+		copy(predU[:], e.predictBuf(1, mbx, mby, mode))
+		copy(predV[:], e.predictBuf(2, mbx, mby, mode))
+		sse := e.sse(predU[:], 1, mbx, mby, 8) + e.sse(predV[:], 2, mbx, mby, 8)
+		if bestSSE < 0 || sse < bestSSE {
+			bestSSE, bestU, bestV, mb.uvMode = sse, predU, predV, mode
+		}
+	}
+
+	for plane, buf := range [2][]uint8{bestU[:], bestV[:]} {
+		for n := range 4 {
+			var c, deq [16]int32
+			off := (n/2)*4*8 + (n%2)*4
+			e.forwardDCT(&c, buf, plane+1, mbx*8+(n%2)*4, mby*8+(n/2)*4, 8, off)
+			levels := &mb.uv[plane*4+n]
+			for i := range 16 {
+				levels[i], deq[i] = e.uv.quantize(c[i], i)
+				nonZero = nonZero || levels[i] != 0
+			}
+			inverseDCT(&deq, buf, off, 8)
+		}
+		e.store(buf, plane+1, mbx, mby, 8)
+	}
+
+	mb.skip = !nonZero
+}
+
+// candidateModes returns the predictors whose neighbouring pixels are all
+// inside the frame, so the decoder's edge substitutes never come into play.
+func (e *vp8Encoder) candidateModes(mbx, mby int) []uint8 {
+	modes := []uint8{vp8PredDC}
+	if mby > 0 {
+		modes = append(modes, vp8PredVE)
+	}
+	if mbx > 0 {
+		modes = append(modes, vp8PredHE)
+	}
+	if mbx > 0 && mby > 0 {
+		modes = append(modes, vp8PredTM)
+	}
+	return modes
+}
+
+func (e *vp8Encoder) predictBuf(plane, mbx, mby int, mode uint8) []uint8 {
+	var buf [256]uint8
+	e.predict(&buf, plane, mbx, mby, 8, mode)
+	return buf[:64]
+}
+
+// predict fills dst with a size x size prediction built from the
+// reconstructed neighbours, following section 12.2 of RFC 6386.
+func (e *vp8Encoder) predict(dst *[256]uint8, plane, mbx, mby, size int, mode uint8) {
+	stride := e.cStride
+	if plane == 0 {
+		stride = e.yStride
+	}
+	rec := e.rec[plane]
+	x0, y0 := mbx*size, mby*size
+	top := func(i int) int32 { return int32(rec[(y0-1)*stride+x0+i]) }
+	left := func(j int) int32 { return int32(rec[(y0+j)*stride+x0-1]) }
+
+	switch mode {
+	case vp8PredVE:
+		for j := range size {
+			for i := range size {
+				dst[j*size+i] = uint8(top(i))
+			}
+		}
+	case vp8PredHE:
+		for j := range size {
+			for i := range size {
+				dst[j*size+i] = uint8(left(j))
+			}
+		}
+	case vp8PredTM:
+		corner := int32(rec[(y0-1)*stride+x0-1])
+		for j := range size {
+			for i := range size {
+				dst[j*size+i] = clip8(left(j) + top(i) - corner)
+			}
+		}
+	default:
+		shift := bits.Len(uint(size)) - 1
+		var sum int32
+		var dc uint8
+		switch {
+		case mbx > 0 && mby > 0:
+			for i := range size {
+				sum += top(i) + left(i)
+			}
+			dc = uint8((sum + int32(size)) >> (shift + 1))
+		case mby > 0:
+			for i := range size {
+				sum += top(i)
+			}
+			dc = uint8((sum + int32(size/2)) >> shift)
+		case mbx > 0:
+			for j := range size {
+				sum += left(j)
+			}
+			dc = uint8((sum + int32(size/2)) >> shift)
+		default:
+			dc = 0x80
+		}
+		for i := range size * size {
+			dst[i] = dc
+		}
+	}
+}
+
+func (e *vp8Encoder) sse(pred []uint8, plane, mbx, mby, size int) int {
+	stride := e.cStride
+	if plane == 0 {
+		stride = e.yStride
+	}
+	src := e.src[plane]
+	sum := 0
+	for j := range size {
+		for i := range size {
+			d := int(src[(mby*size+j)*stride+mbx*size+i]) - int(pred[j*size+i])
+			sum += d * d
+		}
+	}
+	return sum
+}
+
+func (e *vp8Encoder) store(buf []uint8, plane, mbx, mby, size int) {
+	stride := e.cStride
+	if plane == 0 {
+		stride = e.yStride
+	}
+	for j := range size {
+		copy(e.rec[plane][(mby*size+j)*stride+mbx*size:], buf[j*size:(j+1)*size])
+	}
+}
+
+// forwardDCT transforms the 4x4 difference between the source block at
+// (x, y) and the prediction in pred at offset off.
+func (e *vp8Encoder) forwardDCT(out *[16]int32, pred []uint8, plane, x, y, predStride, off int) {
+	stride := e.cStride
+	if plane == 0 {
+		stride = e.yStride
+	}
+	src := e.src[plane]
+
+	var tmp [16]int32
+	for i := range 4 {
+		s := src[(y+i)*stride+x:]
+		p := pred[off+i*predStride:]
+		d0 := int32(s[0]) - int32(p[0])
+		d1 := int32(s[1]) - int32(p[1])
+		d2 := int32(s[2]) - int32(p[2])
+		d3 := int32(s[3]) - int32(p[3])
+		a0, a1, a2, a3 := d0+d3, d1+d2, d1-d2, d0-d3
+		tmp[0+i*4] = (a0 + a1) * 8
+		tmp[1+i*4] = (a2*2217 + a3*5352 + 1812) >> 9
+		tmp[2+i*4] = (a0 - a1) * 8
+		tmp[3+i*4] = (a3*2217 - a2*5352 + 937) >> 9
+	}
+	for i := range 4 {
+		a0 := tmp[0+i] + tmp[12+i]
+		a1 := tmp[4+i] + tmp[8+i]
+		a2 := tmp[4+i] - tmp[8+i]
+		a3 := tmp[0+i] - tmp[12+i]
+		out[0+i] = (a0 + a1 + 7) >> 4
+		out[4+i] = (a2*2217 + a3*5352 + 12000) >> 16
+		if a3 != 0 {
+			out[4+i]++
+		}
+		out[8+i] = (a0 - a1 + 7) >> 4
+		out[12+i] = (a3*2217 - a2*5352 + 51000) >> 16
+	}
+}
+
+// inverseDCT adds the inverse transform of coeffs to the 4x4 block of buf at
+// off. It matches the reference decoder bit for bit so the encoder predicts
+// from exactly the pixels the decoder will reconstruct.
+func inverseDCT(coeffs *[16]int32, buf []uint8, off, stride int) {
+	const (
+		c1 = 85627
+		c2 = 35468
+	)
+	var m [4][4]int32
+	for i := range 4 {
+		a := coeffs[i] + coeffs[8+i]
+		b := coeffs[i] - coeffs[8+i]
+		c := (coeffs[4+i]*c2)>>16 - (coeffs[12+i]*c1)>>16
+		d := (coeffs[4+i]*c1)>>16 + (coeffs[12+i]*c2)>>16
+		m[i][0] = a + d
+		m[i][1] = b + c
+		m[i][2] = b - c
+		m[i][3] = a - d
+	}
+	for j := range 4 {
+		dc := m[0][j] + 4
+		a := dc + m[2][j]
+		b := dc - m[2][j]
+		c := (m[1][j]*c2)>>16 - (m[3][j]*c1)>>16
+		d := (m[1][j]*c1)>>16 + (m[3][j]*c2)>>16
+		row := buf[off+j*stride:]
+		row[0] = clip8(int32(row[0]) + (a+d)>>3)
+		row[1] = clip8(int32(row[1]) + (b+c)>>3)
+		row[2] = clip8(int32(row[2]) + (b-c)>>3)
+		row[3] = clip8(int32(row[3]) + (a-d)>>3)
+	}
+}
+
+func forwardWHT(in, out *[16]int32) {
+	var tmp [16]int32
+	for i := range 4 {
+		a0 := in[i*4+0] + in[i*4+2]
+		a1 := in[i*4+1] + in[i*4+3]
+		a2 := in[i*4+1] - in[i*4+3]
+		a3 := in[i*4+0] - in[i*4+2]
+		tmp[0+i*4] = a0 + a1
+		tmp[1+i*4] = a3 + a2
+		tmp[2+i*4] = a3 - a2
+		tmp[3+i*4] = a0 - a1
+	}
+	for i := range 4 {
+		a0 := tmp[0+i] + tmp[8+i]
+		a1 := tmp[4+i] + tmp[12+i]
+		a2 := tmp[4+i] - tmp[12+i]
+		a3 := tmp[0+i] - tmp[8+i]
+		out[0+i] = (a0 + a1) >> 1
+		out[4+i] = (a3 + a2) >> 1
+		out[8+i] = (a3 - a2) >> 1
+		out[12+i] = (a0 - a1) >> 1
+	}
+}
+
+func inverseWHT(in, out *[16]int32) {
+	var m [16]int32
+	for i := range 4 {
+		a0 := in[0+i] + in[12+i]
+		a1 := in[4+i] + in[8+i]
+		a2 := in[4+i] - in[8+i]
+		a3 := in[0+i] - in[12+i]
+		m[0+i] = a0 + a1
+		m[8+i] = a0 - a1
+		m[4+i] = a3 + a2
+		m[12+i] = a3 - a2
+	}
+	for i := range 4 {
+		dc := m[0+i*4] + 3
+		a0 := dc + m[3+i*4]
+		a1 := m[1+i*4] + m[2+i*4]
+		a2 := m[1+i*4] - m[2+i*4]
+		a3 := dc - m[3+i*4]
+		out[i*4+0] = (a0 + a1) >> 3
+		out[i*4+1] = (a3 + a2) >> 3
+		out[i*4+2] = (a0 - a1) >> 3
+		out[i*4+3] = (a3 - a2) >> 3
+	}
+}
+
+// quantize returns the level for the coefficient at natural position i and
+// the value the decoder will dequantize it to.
+func (m *vp8Matrix) quantize(c int32, i int) (int16, int32) {
+	k := 0
+	if i > 0 {
+		k = 1
+	}
+	a := c
+	if a < 0 {
+		a = -a
+	}
+	level := min((a+m.bias[k])/m.q[k], vp8MaxLevel)
+	if c < 0 {
+		level = -level
+	}
+	return int16(level), level * m.q[k]
+}
+
+type vp8TokenSink interface {
+	putToken(bit bool, plane, band, ctx, node int)
+	putFixed(bit bool, prob uint8)
+}
+
+type vp8TokenStats [vp8NumPlanes][vp8NumBands][vp8NumContexts][vp8NumProbs][2]uint32
+
+func (s *vp8TokenStats) putToken(bit bool, plane, band, ctx, node int) {
+	if bit {
+		s[plane][band][ctx][node][1]++
+	} else {
+		s[plane][band][ctx][node][0]++
+	}
+}
+
+func (s *vp8TokenStats) putFixed(bool, uint8) {}
+
+type vp8TokenWriter struct {
+	enc  *boolEncoder
+	prob *[vp8NumPlanes][vp8NumBands][vp8NumContexts][vp8NumProbs]uint8
+}
+
+func (w *vp8TokenWriter) putToken(bit bool, plane, band, ctx, node int) {
+	w.enc.putBit(bit, w.prob[plane][band][ctx][node])
+}
+
+func (w *vp8TokenWriter) putFixed(bit bool, prob uint8) {
+	w.enc.putBit(bit, prob)
+}
+
+// writeTokens emits the residuals of every macroblock, tracking the
+// non-zero contexts exactly as section 13.3 of RFC 6386 describes.
+func (e *vp8Encoder) writeTokens(sink vp8TokenSink) {
+	up := make([]vp8NzContext, e.mbw)
+	for mby := 0; mby < e.mbh; mby++ {
+		var left vp8NzContext
+		for mbx := 0; mbx < e.mbw; mbx++ {
+			mb := &e.mbs[mby*e.mbw+mbx]
+			if mb.skip {
+				left, up[mbx] = vp8NzContext{}, vp8NzContext{}
+				continue
+			}
+
+			nz := writeVP8Block(sink, vp8PlaneY2, left.nzY16+up[mbx].nzY16, &mb.y2, 0)
+			left.nzY16, up[mbx].nzY16 = nz, nz
+
+			var lnz, unz [4]uint8
+			unpackNz(&lnz, left.nz)
+			unpackNz(&unz, up[mbx].nz)
+			for y := range 4 {
+				nz := lnz[y]
+				for x := range 4 {
+					nz = writeVP8Block(sink, vp8PlaneY1WithY2, nz+unz[x], &mb.y[y*4+x], 1)
+					unz[x] = nz
+				}
+				lnz[y] = nz
+			}
+			leftMask, upMask := packNz(lnz), packNz(unz)
+
+			unpackNz(&lnz, left.nz>>4)
+			unpackNz(&unz, up[mbx].nz>>4)
+			for c := 0; c < 4; c += 2 {
+				for y := range 2 {
+					nz := lnz[y+c]
+					for x := range 2 {
+						nz = writeVP8Block(sink, vp8PlaneUV, nz+unz[x+c], &mb.uv[c*2+y*2+x], 0)
+						unz[x+c] = nz
+					}
+					lnz[y+c] = nz
+				}
+			}
+			left.nz = leftMask | packNz(lnz)<<4
+			up[mbx].nz = upMask | packNz(unz)<<4
+		}
+	}
+}
+
+func unpackNz(dst *[4]uint8, mask uint8) {
+	for i := range 4 {
+		dst[i] = mask >> i & 1
+	}
+}
+
+func packNz(nz [4]uint8) uint8 {
+	return nz[0] | nz[1]<<1 | nz[2]<<2 | nz[3]<<3
+}
+
+// writeVP8Block codes one 4x4 block of levels, skipping the first first
+// positions, and reports whether it had any non-zero level.
+func writeVP8Block(sink vp8TokenSink, plane int, ctx uint8, levels *[16]int16, first int) uint8 {
+	last := -1
+	for n := 15; n >= first; n-- {
+		if levels[vp8Zigzag[n]] != 0 {
+			last = n
+			break
+		}
+	}
+
+	c := int(ctx)
+	if last < 0 {
+		sink.putToken(false, plane, int(vp8Bands[first]), c, 0)
+		return 0
+	}
+	sink.putToken(true, plane, int(vp8Bands[first]), c, 0)
+
+	for n := first; n < 16; n++ {
+		v := int32(levels[vp8Zigzag[n]])
+		a := v
+		if a < 0 {
+			a = -a
+		}
+		b := int(vp8Bands[n])
+
+		if a == 0 {
+			sink.putToken(false, plane, b, c, 1)
+			c = 0
+			continue
+		}
+		sink.putToken(true, plane, b, c, 1)
+
+		if a == 1 {
+			sink.putToken(false, plane, b, c, 2)
+			c = 1
+		} else {
+			sink.putToken(true, plane, b, c, 2)
+			switch {
+			case a <= 4:
+				sink.putToken(false, plane, b, c, 3)
+				if a == 2 {
+					sink.putToken(false, plane, b, c, 4)
+				} else {
+					sink.putToken(true, plane, b, c, 4)
+					sink.putToken(a == 4, plane, b, c, 5)
+				}
+			case a <= 10:
+				sink.putToken(true, plane, b, c, 3)
+				sink.putToken(false, plane, b, c, 6)
+				if a <= 6 {
+					sink.putToken(false, plane, b, c, 7)
+					sink.putFixed(a == 6, 159)
+				} else {
+					sink.putToken(true, plane, b, c, 7)
+					sink.putFixed((a-7)&2 != 0, 165)
+					sink.putFixed((a-7)&1 != 0, 145)
+				}
+			default:
+				sink.putToken(true, plane, b, c, 3)
+				sink.putToken(true, plane, b, c, 6)
+				cat := 3
+				switch {
+				case a <= 18:
+					cat = 0
+				case a <= 34:
+					cat = 1
+				case a <= 66:
+					cat = 2
+				}
+				sink.putToken(cat >= 2, plane, b, c, 8)
+				sink.putToken(cat&1 != 0, plane, b, c, 9+cat>>1)
+				extra := a - (3 + 8<<cat)
+				tab := vp8Cat3456[cat]
+				for i, prob := range tab {
+					sink.putFixed(extra>>(len(tab)-1-i)&1 != 0, prob)
+				}
+			}
+			c = 2
+		}
+		sink.putFixed(v < 0, 128)
+
+		if n == 15 {
+			break
+		}
+		more := n < last
+		sink.putToken(more, plane, int(vp8Bands[n+1]), c, 0)
+		if !more {
+			break
+		}
+	}
+
+	return 1
+}
+
+// optimizeTokenProbs replaces default token probabilities with ones fitted
+// to this image wherever the saving outweighs the cost of signalling them.
+func (e *vp8Encoder) optimizeTokenProbs(stats *vp8TokenStats) {
+	for i := range e.tokenProb {
+		for j := range e.tokenProb[i] {
+			for k := range e.tokenProb[i][j] {
+				for l := range e.tokenProb[i][j][k] {
+					c0, c1 := stats[i][j][k][l][0], stats[i][j][k][l][1]
+					if c0+c1 == 0 {
+						continue
+					}
+					old := e.tokenProb[i][j][k][l]
+					p := uint8(min(max((255*uint64(c0)+uint64(c0+c1)/2)/uint64(c0+c1), 1), 255))
+					updateProb := vp8TokenUpdateProb[i][j][k][l]
+
+					oldCost := branchCost(c0, c1, old) + bitCost(false, updateProb)
+					newCost := branchCost(c0, c1, p) + bitCost(true, updateProb) + 8
+					if newCost < oldCost {
+						e.tokenProb[i][j][k][l] = p
+						e.updated[i][j][k][l] = true
+					}
+				}
+			}
+		}
+	}
+}
+
+func bitCost(bit bool, prob uint8) float64 {
+	p := float64(prob) / 256
+	if bit {
+		p = 1 - p
+	}
+	return -math.Log2(p)
+}
+
+func branchCost(c0, c1 uint32, prob uint8) float64 {
+	return float64(c0)*bitCost(false, prob) + float64(c1)*bitCost(true, prob)
+}
+
+func (e *vp8Encoder) writeHeader(h *boolEncoder) {
+	// Color space and clamping type.
+	h.putBits(0, 2)
+	// No segmentation.
+	h.putBits(0, 1)
+	// Normal loop filter, level, sharpness, no per-mode deltas.
+	h.putBits(0, 1)
+	h.putBits(uint32(e.filterLevel()), 6)
+	h.putBits(0, 3)
+	h.putBits(0, 1)
+	// One token partition.
+	h.putBits(0, 2)
+	// Quantizer index without per-plane deltas.
+	h.putBits(uint32(e.qIndex), 7)
+	h.putBits(0, 5)
+	// Refresh entropy probabilities.
+	h.putBits(0, 1)
+
+	for i := range e.tokenProb {
+		for j := range e.tokenProb[i] {
+			for k := range e.tokenProb[i][j] {
+				for l := range e.tokenProb[i][j][k] {
+					h.putBit(e.updated[i][j][k][l], vp8TokenUpdateProb[i][j][k][l])
+					if e.updated[i][j][k][l] {
+						h.putBits(uint32(e.tokenProb[i][j][k][l]), 8)
+					}
+				}
+			}
+		}
+	}
+
+	skipped := 0
+	for i := range e.mbs {
+		if e.mbs[i].skip {
+			skipped++
+		}
+	}
+	useSkip := skipped > 0
+	var skipProb uint8
+	h.putBit(useSkip, 128)
+	if useSkip {
+		total := len(e.mbs)
+		skipProb = uint8(min(max((255*(total-skipped)+total/2)/total, 1), 255))
+		h.putBits(uint32(skipProb), 8)
+	}
+
+	for i := range e.mbs {
+		mb := &e.mbs[i]
+		if useSkip {
+			h.putBit(mb.skip, skipProb)
+		}
+
+		h.putBit(true, 145)
+		switch mb.yMode {
+		case vp8PredDC:
+			h.putBit(false, 156)
+			h.putBit(false, 163)
+		case vp8PredVE:
+			h.putBit(false, 156)
+			h.putBit(true, 163)
+		case vp8PredHE:
+			h.putBit(true, 156)
+			h.putBit(false, 128)
+		case vp8PredTM:
+			h.putBit(true, 156)
+			h.putBit(true, 128)
+		}
+
+		switch mb.uvMode {
+		case vp8PredDC:
+			h.putBit(false, 142)
+		case vp8PredVE:
+			h.putBit(true, 142)
+			h.putBit(false, 114)
+		case vp8PredHE:
+			h.putBit(true, 142)
+			h.putBit(true, 114)
+			h.putBit(false, 183)
+		case vp8PredTM:
+			h.putBit(true, 142)
+			h.putBit(true, 114)
+			h.putBit(true, 183)
+		}
+	}
+}
+
+// filterLevel scales the loop filter with the quantizer so that coarser
+// quantization gets stronger deblocking.
+func (e *vp8Encoder) filterLevel() int {
+	return min(e.qIndex*3/8, 63)
+}
+
+func (e *vp8Encoder) container(header, tokens []byte) []byte {
+	frameSize := 10 + len(header) + len(tokens)
+	pad := frameSize & 1
+
+	out := make([]byte, 0, 20+frameSize+pad)
+	out = append(out, "RIFF"...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(12+frameSize+pad))
+	out = append(out, "WEBPVP8 "...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(frameSize))
+
+	// Key frame, version 0, shown, followed by the first partition size.
+	tag := uint32(1<<4) | uint32(len(header))<<5
+	out = append(out, byte(tag), byte(tag>>8), byte(tag>>16))
+	out = append(out, 0x9d, 0x01, 0x2a)
+	out = binary.LittleEndian.AppendUint16(out, uint16(e.width))
+	out = binary.LittleEndian.AppendUint16(out, uint16(e.height))
+	out = append(out, header...)
+	out = append(out, tokens...)
+	if pad == 1 {
+		out = append(out, 0)
+	}
+
+	return out
+}
+
+// boolEncoder is the boolean entropy coder from section 7 of RFC 6386.
+type boolEncoder struct {
+	buf    []byte
+	rng    int32
+	value  int32
+	run    int
+	nbBits int
+}
+
+func newBoolEncoder() *boolEncoder {
+	return &boolEncoder{rng: 254, nbBits: -8}
+}
+
+func (e *boolEncoder) putBit(bit bool, prob uint8) {
+	split := (e.rng * int32(prob)) >> 8
+	if bit {
+		e.value += split + 1
+		e.rng -= split + 1
+	} else {
+		e.rng = split
+	}
+	if e.rng < 127 {
+		shift := 8 - bits.Len32(uint32(e.rng+1))
+		e.rng = ((e.rng + 1) << shift) - 1
+		e.value <<= shift
+		e.nbBits += shift
+		if e.nbBits > 0 {
+			e.flush()
+		}
+	}
+}
+
+func (e *boolEncoder) putBits(value uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		e.putBit(value>>i&1 != 0, 128)
+	}
+}
+
+func (e *boolEncoder) flush() {
+	s := 8 + e.nbBits
+	out := e.value >> s
+	e.value -= out << s
+	e.nbBits -= 8
+	if out&0xff == 0xff {
+		// Hold back 0xff bytes until we know whether a carry reaches them.
+		e.run++
+		return
+	}
+	if out&0x100 != 0 && len(e.buf) > 0 {
+		e.buf[len(e.buf)-1]++
+	}
+	for ; e.run > 0; e.run-- {
+		if out&0x100 != 0 {
+			e.buf = append(e.buf, 0x00)
+		} else {
+			e.buf = append(e.buf, 0xff)
+		}
+	}
+	e.buf = append(e.buf, byte(out))
+}
+
+func (e *boolEncoder) finish() []byte {
+	e.putBits(0, 9-e.nbBits)
+	e.nbBits = 0
+	e.flush()
+	return e.buf
+}
+
+func clip8(v int32) uint8 {
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
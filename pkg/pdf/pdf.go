@@ -0,0 +1,87 @@
+package pdf
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/text/unicode/norm"
+)
+
+const fontFamily = "transcript"
+
+type Generator interface {
+	Document(title string, paragraphs []string) ([]byte, error)
+}
+
+type generatorImpl struct {
+	fontPath string
+}
+
+func NewGenerator(fontPath string) Generator {
+	return &generatorImpl{fontPath}
+}
+
+func (g *generatorImpl) Document(title string, paragraphs []string) ([]byte, error) {
+	doc := fpdf.New("P", "mm", "A4", "")
+	doc.SetTitle(title, true)
+	doc.SetMargins(15, 15, 15)
+	doc.SetAutoPageBreak(true, 15)
+
+	family, text := g.font(doc)
+	if err := doc.Error(); err != nil {
+		return nil, err
+	}
+
+	doc.AddPage()
+
+	doc.SetFont(family, "", 14)
+	doc.MultiCell(0, 8, text(title), "", "L", false)
+	doc.Ln(4)
+
+	doc.SetFont(family, "", 10)
+	for _, p := range paragraphs {
+		doc.MultiCell(0, 5, text(p), "", "L", false)
+		doc.Ln(1)
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (g *generatorImpl) font(doc *fpdf.Fpdf) (string, func(string) string) {
+	if g.fontPath != "" {
+		doc.AddUTF8Font(fontFamily, "", g.fontPath)
+		return fontFamily, func(s string) string { return s }
+	}
+
+	translate := doc.UnicodeTranslatorFromDescriptor("")
+	return "Helvetica", func(s string) string {
+		return translate(stripDiacritics(s))
+	}
+}
+
+func stripDiacritics(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r == 'đ':
+			b.WriteRune('d')
+		case r == 'Đ':
+			b.WriteRune('D')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}